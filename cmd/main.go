@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"makers.anchor/incident/internal/config"
 	"makers.anchor/incident/internal/database"
+	"makers.anchor/incident/internal/handlers"
 	"makers.anchor/incident/internal/kafka"
+	"makers.anchor/incident/internal/middleware"
 	"makers.anchor/incident/internal/routes"
 )
 
@@ -22,17 +30,24 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
-	defer db.Close()
 
 	// Initialize services
 	var brokerList = []string{"localhost:9092"}
-	kafkaClient, err := kafka.NewProducer(brokerList)
+	kafkaClient, err := kafka.NewProducer(brokerList, kafka.ProducerOptions{
+		Mode:      kafka.DeliveryMode(cfg.KafkaProduceMode),
+		Timeout:   cfg.KafkaProduceTimeout,
+		BatchSize: cfg.KafkaBatchSize,
+		Linger:    cfg.KafkaLinger,
+
+		Acks:               kafka.Acks(cfg.KafkaAcks),
+		DisableIdempotence: !cfg.KafkaIdempotent,
+	})
 	if err != nil {
 		log.Fatalf("Failed to create Kafka client")
 	}
 
 	// Initialize Fiber app
-	app := fiber.New(fiber.Config{
+	fiberConfig := fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
 			code := fiber.StatusInternalServerError
 			if e, ok := err.(*fiber.Error); ok {
@@ -42,27 +57,103 @@ func main() {
 				"error": err.Error(),
 			})
 		},
-	})
+	}
+	if cfg.StrictJSON {
+		fiberConfig.JSONDecoder = handlers.StrictJSONDecoder
+	}
+	if cfg.ResponseFieldCase == "camel" {
+		fiberConfig.JSONEncoder = handlers.CamelCaseJSONEncoder
+	}
+	app := fiber.New(fiberConfig)
 
 	// Middleware
 	app.Use(recover.New())
-	app.Use(logger.New(logger.Config{
-		Format: "[${ip}]:${port} ${status} - ${method} ${path}\n",
-	}))
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE",
-		AllowHeaders: "Origin, Content-Type, Accept",
-	}))
+	app.Use(middleware.LimitConcurrency(cfg.MaxConcurrentRequests, time.Second))
+	app.Use(middleware.PayloadSizeMetrics())
+	app.Use(middleware.RequestTimeout(cfg.RequestTimeout))
+	if cfg.VerboseLogging() {
+		app.Use(middleware.RequestLogger(os.Stdout, middleware.RedactionRules{
+			Headers:    cfg.LogRedactHeaders,
+			MaskEmails: cfg.LogMaskEmails,
+			LogBodies:  cfg.LogRequestBodies,
+		}))
+	}
+	if origins := cfg.AllowedOrigins(); origins != "" {
+		app.Use(cors.New(cors.Config{
+			AllowOrigins:  origins,
+			AllowMethods:  "GET,POST,PUT,DELETE",
+			AllowHeaders:  "Origin, Content-Type, Accept, If-None-Match, " + fiber.HeaderAuthorization + ", " + handlers.IdempotencyKeyHeader + ", " + middleware.TenantHeader,
+			ExposeHeaders: "ETag, " + handlers.IdempotentReplayHeader,
+		}))
+	}
+
+	// Cancelled on SIGINT or SIGTERM, which also stops the background jobs
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// API routes
-	routes.SetupRoutes(app, db, kafkaClient)
+	background := routes.SetupRoutes(ctx, app, db, kafkaClient, cfg)
 
 	log.Printf("Server starting on port %s", cfg.Port)
-	log.Printf("Environment: %s", cfg.Environment)
-	log.Printf("API Base URL: http://localhost:%s/api/v1", cfg.Port)
+	config.Infof("Environment: %s", cfg.Environment)
+	config.Infof("API Base URL: http://localhost:%s/api/v1", cfg.Port)
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- app.Listen(":" + cfg.Port)
+	}()
 
-	if err := app.Listen(":" + cfg.Port); err != nil {
+	select {
+	case err := <-listenErr:
 		log.Fatalf("Failed to start server: %v", err)
+	case <-ctx.Done():
+		stop()
+	}
+
+	if err := shutdown(app, background, kafkaClient, db, cfg.ShutdownTimeout); err != nil {
+		log.Printf("Shutdown failed: %v", err)
+		os.Exit(1)
+	}
+	log.Println("Shutdown complete")
+}
+
+// shutdown drains in-flight requests, then waits for the background jobs and
+// webhook deliveries before flushing the Kafka producer and finally closing the
+// database, so nothing still running is left without its dependencies. The jobs
+// and webhooks share one timeout, after which pending webhook retries are
+// cancelled. Every stage runs even if an earlier one fails.
+func shutdown(app *fiber.App, background *routes.Background, producer *kafka.Producer, db *database.DB, timeout time.Duration) error {
+	var errs []error
+
+	log.Printf("Shutting down HTTP server (timeout %s)", timeout)
+	if err := app.ShutdownWithTimeout(timeout); err != nil {
+		errs = append(errs, fmt.Errorf("failed to stop HTTP server: %w", err))
 	}
+
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), timeout)
+	defer cancelWait()
+
+	log.Printf("Waiting for background jobs (timeout %s)", timeout)
+	if err := background.WaitForJobs(waitCtx); err != nil {
+		errs = append(errs, err)
+	}
+
+	log.Println("Waiting for webhook deliveries")
+	if err := background.CloseWebhooks(waitCtx); err != nil {
+		errs = append(errs, err)
+	}
+
+	log.Printf("Flushing and closing Kafka producer (timeout %s)", timeout)
+	flushCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := producer.Close(flushCtx); err != nil {
+		errs = append(errs, err)
+	}
+
+	log.Println("Closing database connection")
+	if err := db.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close database connection: %w", err))
+	}
+
+	return errors.Join(errs...)
 }
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"log"
+	"slices"
+)
+
+// Permission is an action a route requires the caller's roles to grant
+type Permission string
+
+const (
+	PermissionWriteIncidents Permission = "incidents:write"    // Create, publish, reassign and change status, priority or watchers
+	PermissionChangeSeverity Permission = "incidents:severity" // Change an incident's severity
+	PermissionWriteNotes     Permission = "notes:write"        // Add and edit notes
+	PermissionDelete         Permission = "delete"             // Delete resources
+)
+
+// Permissions lists every permission a route can require
+var Permissions = []Permission{
+	PermissionWriteIncidents,
+	PermissionChangeSeverity,
+	PermissionWriteNotes,
+	PermissionDelete,
+}
+
+// DefaultRolePermissions is the policy used when no role permissions are configured
+var DefaultRolePermissions = map[string][]Permission{
+	"admins":     Permissions,
+	"responders": {PermissionWriteIncidents, PermissionWriteNotes},
+	"viewers":    {},
+}
+
+// Policy maps bearer tokens to roles and roles to the permissions they grant
+type Policy struct {
+	tokens      map[string][]string
+	permissions map[string][]Permission
+}
+
+// NewPolicy builds a policy from the configured role tokens and role permissions,
+// falling back to DefaultRolePermissions when none are configured. Unknown
+// permission names are logged and skipped.
+func NewPolicy(roleTokens map[string][]string, rolePermissions map[string][]string) *Policy {
+	if len(rolePermissions) == 0 {
+		return &Policy{tokens: roleTokens, permissions: DefaultRolePermissions}
+	}
+
+	permissions := make(map[string][]Permission, len(rolePermissions))
+	for role, names := range rolePermissions {
+		granted := []Permission{}
+		for _, name := range names {
+			if !slices.Contains(Permissions, Permission(name)) {
+				log.Printf("Unknown permission %q for role %s, skipping", name, role)
+				continue
+			}
+			granted = append(granted, Permission(name))
+		}
+		permissions[role] = granted
+	}
+	return &Policy{tokens: roleTokens, permissions: permissions}
+}
+
+// Enabled reports whether routes are authorized, which requires role tokens
+func (p *Policy) Enabled() bool {
+	return p != nil && len(p.tokens) > 0
+}
+
+// Roles returns the roles a bearer token belongs to
+func (p *Policy) Roles(token string) ([]string, bool) {
+	roles, ok := p.tokens[token]
+	return roles, ok
+}
+
+// Allows reports whether any of the roles grants the permission
+func (p *Policy) Allows(roles []string, permission Permission) bool {
+	for _, role := range roles {
+		if slices.Contains(p.permissions[role], permission) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"errors"
 	"log"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -13,6 +18,140 @@ type Config struct {
 	MongoURI     string
 	DatabaseName string
 	Environment  string
+	Features     []string      // Optional endpoint groups enabled in this environment
+	DedupWindow  time.Duration // Reject titles matching an open incident created within this window (0 disables)
+	MaxNotes     int           // Maximum notes per incident (0 is unlimited)
+
+	IncidentsCollection string // Collection holding incidents, so environments can share a database
+
+	DuplicateMatch         string   // "title" flags open incidents with the same normalized title, "title_and_service" also requires a shared service, "off" disables
+	DuplicateNormalization []string // Steps applied to titles before matching: lowercase, punctuation, whitespace
+
+	MaxTitleLength int    // Longest incident title accepted, in characters (0 is unlimited)
+	TitleOverflow  string // "reject" refuses longer titles, "truncate" shortens them and flags the incident
+
+	MinDescriptionLengths map[string]int // Shortest description accepted when creating incidents of each severity; severities without one accept any
+
+	IncidentKeyMode string // "global" numbers incidents 1, 2, 3...; "yearly" restarts at YYYY-0001 each year
+
+	AttentionThreshold time.Duration // Open incidents not updated within this period need attention (0 disables)
+
+	MinTimeInProgress time.Duration // How long an incident must stay in_progress before it can be resolved, which open incidents cannot skip (0 disables)
+
+	RequireAssigneeToResolve bool // Unassigned incidents cannot be resolved or closed
+
+	PostmortemRequiredSeverities []string // Incidents of these severities cannot be closed without a postmortem
+
+	SeverityChangeCooldown time.Duration // Minimum time between severity changes of an incident (0 disables)
+
+	TransactionMaxRetries int // Retries for transactions failing with transient MongoDB errors
+
+	RequireNoteAuthor bool   // Reject notes without a valid author email
+	SystemActorEmail  string // Author recorded on incidents and notes created by webhooks and jobs
+
+	NoteTemplates        map[string][]string // Required headings per note type, overriding the predefined templates
+	EnforceNoteTemplates bool                // Reject typed notes missing their template's headings
+
+	DefaultNoteVisibility string // Visibility of notes added without one: "internal" or "public"
+
+	StatusPageRateLimit int // Requests per minute per client allowed on the public status page
+
+	TransitionNotes map[string]string // Note type required when moving into a status, e.g. resolved -> resolution
+
+	CreateLatencyBudget time.Duration // Incident creates slower than this log a timing breakdown (0 disables)
+
+	DefaultWatchers map[string][]string // Emails added as watchers to every new incident of a severity
+
+	IncidentCacheSize int           // Incidents cached by key in memory (0 disables the cache)
+	IncidentCacheTTL  time.Duration // How long a cached incident is served before it is refetched
+
+	ListCountCacheTTL time.Duration // How long a paginated list's total is reused before it is recounted (0 disables)
+
+	StrictJSON bool // Reject request bodies with fields the endpoint does not accept
+
+	ResponseFieldCase string // "snake" keeps response fields as stored, "camel" renames them to camelCase
+
+	DescriptionTemplate string // Seeds empty incident descriptions, "\n" starts a new line (empty disables)
+
+	SeverityWeights map[string]int // How much an open incident of each severity adds to the operational load
+
+	NoteTypeEvents []string // Note types emitting a dedicated event besides the generic note event
+
+	OnCallAssignees []string // Round-robin assignees for incidents created without one
+
+	EscalationChains map[string][]string // Ordered responders paged for each severity
+	AckSLA           time.Duration       // How long an open incident may go unacknowledged before paging the next responder
+
+	SLATargets       map[string]time.Duration // Resolution target per severity; severities without one have no SLA
+	SLAAtRiskPercent int                      // Share of the target after which an incident is at risk
+
+	StaleThresholds    []time.Duration // Ages at which unresolved incidents are flagged stale, ascending (empty disables)
+	StaleCheckInterval time.Duration   // How often the stale incident job runs (0 or less disables)
+
+	ArchiveClosedAfter   time.Duration // Closed incidents not updated for this long move to the archive (0 disables)
+	ArchiveCollection    string        // Collection archived incidents are moved to, still read by lookups
+	ArchiveCheckInterval time.Duration // How often the archival job runs (0 or less disables)
+
+	ScheduleCheckInterval time.Duration // How often due scheduled incidents are opened (0 or less disables)
+
+	CreateConflictRules []string // Cross-field conflict rules enforced when creating incidents; none unless configured
+
+	CustomFieldSchema map[string]string // Allowed custom field keys and their types (string, number, bool)
+
+	Webhooks      []WebhookTarget // Outbound webhooks receiving incident events
+	WebhookSecret string          // HMAC secret used to sign outbound webhook payloads
+
+	WebhookConcurrency int // Maximum webhook deliveries in flight at once (0 is unlimited)
+	WebhookMaxAttempts int // Delivery attempts per webhook before giving up
+
+	InboundWebhookSecret string // HMAC secret inbound webhook requests must be signed with
+
+	AdminToken string // Bearer token for the admin API, which is disabled when empty
+
+	MultiTenant  bool              // Scope incident routes to the caller's tenant
+	TenantTokens map[string]string // Bearer tokens and the tenant each belongs to; X-Tenant-ID is trusted when empty
+
+	RoleTokens      map[string][]string // Bearer tokens and the roles each holds; routes are not authorized when empty
+	RolePermissions map[string][]string // Permissions each role grants; the built-in policy is used when empty
+
+	CORSAllowOrigins string // Comma separated origins allowed in production
+
+	MaxConcurrentRequests int // In-flight request limit before answering 503 (0 is unlimited)
+
+	RequestTimeout time.Duration // Deadline passed to Mongo and Kafka for each request (0 disables)
+
+	ShutdownTimeout time.Duration // How long in-flight requests, and then buffered Kafka records, may drain on SIGINT or SIGTERM
+
+	LogRedactHeaders []string // Request headers whose values are never logged
+	LogMaskEmails    bool     // Mask email local-parts in request logs
+	LogRequestBodies bool     // Include redacted request bodies in request logs
+
+	KafkaProduceMode    string        // "sync" waits for the broker ack, "async" is fire-and-forget
+	KafkaProduceTimeout time.Duration // How long a sync produce waits for the broker ack
+	KafkaBatchSize      int           // Events buffered into one produce call (0 or 1 disables batching)
+	KafkaLinger         time.Duration // How long a partial batch waits before it is flushed
+	MinEventSeverity    string        // Kafka events for incidents below this severity are suppressed (empty emits all)
+
+	KafkaAcks       string // Broker acks required per record: "all", "leader" or "none"
+	KafkaIdempotent bool   // Enable the idempotent producer, which requires acks=all
+
+	ListSortField     string // Default sort field for listing incidents
+	ListSortDirection int    // Default sort direction for listing incidents: 1 ascending, -1 descending
+
+	ExportFormat string // Format of single incident reports
+
+	AttachmentStorageEndpoint  string        // S3-compatible endpoint; attachments are disabled when empty
+	AttachmentStorageBucket    string        // Bucket attachments are uploaded to
+	AttachmentStorageRegion    string        // Region used to sign upload URLs
+	AttachmentStorageAccessKey string        // Access key used to sign upload URLs
+	AttachmentStorageSecretKey string        // Secret key used to sign upload URLs
+	AttachmentUploadExpiry     time.Duration // How long presigned upload URLs stay valid
+}
+
+// WebhookTarget represents an outbound webhook and the event types it receives
+type WebhookTarget struct {
+	URL        string
+	EventTypes []string // Empty receives every event type
 }
 
 // Load loads configuration from environment variables
@@ -24,21 +163,259 @@ func Load() *Config {
 
 	config := &Config{
 		Port:         getEnvWithDefault("PORT", "8080"),
-		MongoURI:     getEnvWithDefault("MONGO_URI", "mongo dummy"),
+		MongoURI:     os.Getenv("MONGO_URI"),
 		DatabaseName: getEnvWithDefault("DATABASE_NAME", "localdevincidents"),
 		Environment:  getEnvWithDefault("ENVIRONMENT", "development"),
+		Features:     getListWithDefault("FEATURES", []string{"outages", "export"}),
+		DedupWindow:  getDurationWithDefault("DEDUP_WINDOW", 0),
+		MaxNotes:     getIntWithDefault("MAX_NOTES", 0),
+
+		IncidentsCollection: getEnvWithDefault("INCIDENTS_COLLECTION", "incidents"),
+
+		DuplicateMatch:         getChoiceWithDefault("DUPLICATE_MATCH", "off", "off", "title", "title_and_service"),
+		DuplicateNormalization: getListWithDefault("DUPLICATE_NORMALIZATION", []string{"lowercase", "punctuation", "whitespace"}),
+
+		MaxTitleLength: getIntWithDefault("MAX_TITLE_LENGTH", 255),
+		TitleOverflow:  getChoiceWithDefault("TITLE_OVERFLOW", "reject", "reject", "truncate"),
+
+		MinDescriptionLengths: parseMinDescriptionLengths(os.Getenv("MIN_DESCRIPTION_LENGTHS")),
+
+		IncidentKeyMode: getChoiceWithDefault("INCIDENT_KEY_MODE", "global", "global", "yearly"),
+
+		AttentionThreshold: getDurationWithDefault("ATTENTION_THRESHOLD", 4*time.Hour),
+
+		MinTimeInProgress: getDurationWithDefault("MIN_TIME_IN_PROGRESS", 0),
+
+		RequireAssigneeToResolve: getBoolWithDefault("REQUIRE_ASSIGNEE_TO_RESOLVE", false),
+
+		PostmortemRequiredSeverities: getListWithDefault("POSTMORTEM_REQUIRED_SEVERITIES", nil),
+
+		SeverityChangeCooldown: getDurationWithDefault("SEVERITY_CHANGE_COOLDOWN", 0),
+
+		TransactionMaxRetries: getIntWithDefault("MONGO_TRANSACTION_MAX_RETRIES", 3),
+
+		RequireNoteAuthor: getBoolWithDefault("REQUIRE_NOTE_AUTHOR", false),
+		SystemActorEmail:  getEnvWithDefault("SYSTEM_ACTOR_EMAIL", "system@incident.local"),
+
+		NoteTemplates:        parseNoteTemplates(),
+		EnforceNoteTemplates: getBoolWithDefault("ENFORCE_NOTE_TEMPLATES", false),
+
+		DefaultNoteVisibility: getChoiceWithDefault("DEFAULT_NOTE_VISIBILITY", "internal", "internal", "public"),
+
+		StatusPageRateLimit: getIntWithDefault("STATUS_PAGE_RATE_LIMIT", 60),
+
+		TransitionNotes: parseTransitionNotes(os.Getenv("TRANSITION_NOTES")),
+
+		CreateLatencyBudget: getDurationWithDefault("CREATE_LATENCY_BUDGET", 500*time.Millisecond),
+
+		DefaultWatchers: parseDefaultWatchers(),
+
+		IncidentCacheSize: getIntWithDefault("INCIDENT_CACHE_SIZE", 0),
+		IncidentCacheTTL:  getDurationWithDefault("INCIDENT_CACHE_TTL", 30*time.Second),
+
+		ListCountCacheTTL: getDurationWithDefault("LIST_COUNT_CACHE_TTL", 5*time.Second),
+
+		StrictJSON: getBoolWithDefault("STRICT_JSON", false),
+
+		ResponseFieldCase: getChoiceWithDefault("RESPONSE_FIELD_CASE", "snake", "snake", "camel"),
+
+		SeverityWeights: parseSeverityWeights(os.Getenv("SEVERITY_WEIGHTS")),
+
+		NoteTypeEvents: getListWithDefault("NOTE_TYPE_EVENTS", []string{"resolution"}),
+
+		DescriptionTemplate: strings.ReplaceAll(os.Getenv("DESCRIPTION_TEMPLATE"), `\n`, "\n"),
+
+		OnCallAssignees: getListWithDefault("ONCALL_ASSIGNEES", nil),
+
+		EscalationChains: parseEscalationChains(),
+		AckSLA:           getDurationWithDefault("ACK_SLA", 15*time.Minute),
+
+		SLATargets:       parseSLATargets(os.Getenv("SLA_TARGETS")),
+		SLAAtRiskPercent: getIntWithDefault("SLA_AT_RISK_PERCENT", 80),
+
+		StaleThresholds:    parseStaleThresholds(os.Getenv("STALE_THRESHOLDS")),
+		StaleCheckInterval: getDurationWithDefault("STALE_CHECK_INTERVAL", 5*time.Minute),
+
+		ArchiveClosedAfter:   getDurationWithDefault("ARCHIVE_CLOSED_AFTER", 0),
+		ArchiveCollection:    getEnvWithDefault("ARCHIVE_COLLECTION", "incidents_archive"),
+		ArchiveCheckInterval: getDurationWithDefault("ARCHIVE_CHECK_INTERVAL", time.Hour),
+
+		ScheduleCheckInterval: getDurationWithDefault("SCHEDULE_CHECK_INTERVAL", time.Minute),
+
+		CreateConflictRules: getListWithDefault("CREATE_CONFLICT_RULES", nil),
+
+		CustomFieldSchema: parseCustomFieldSchema(os.Getenv("CUSTOM_FIELDS")),
+
+		Webhooks:      parseWebhookTargets(os.Getenv("WEBHOOKS")),
+		WebhookSecret: os.Getenv("WEBHOOK_SECRET"),
+
+		WebhookConcurrency: getIntWithDefault("WEBHOOK_CONCURRENCY", 10),
+		WebhookMaxAttempts: getIntWithDefault("WEBHOOK_MAX_ATTEMPTS", 3),
+
+		InboundWebhookSecret: os.Getenv("INBOUND_WEBHOOK_SECRET"),
+
+		AdminToken: os.Getenv("ADMIN_TOKEN"),
+
+		MultiTenant:  getBoolWithDefault("MULTI_TENANT", false),
+		TenantTokens: parseTenantTokens(os.Getenv("TENANT_TOKENS")),
+
+		RoleTokens:      parseKeyedLists("ROLE_TOKENS"),
+		RolePermissions: parseKeyedLists("ROLE_PERMISSIONS"),
+
+		CORSAllowOrigins: os.Getenv("CORS_ALLOW_ORIGINS"),
+
+		MaxConcurrentRequests: getIntWithDefault("MAX_CONCURRENT_REQUESTS", 0),
+
+		RequestTimeout: getDurationWithDefault("REQUEST_TIMEOUT", 0),
+
+		ShutdownTimeout: getDurationWithDefault("SHUTDOWN_TIMEOUT", 10*time.Second),
+
+		LogRedactHeaders: getListWithDefault("LOG_REDACT_HEADERS", []string{"Authorization", "Cookie", "X-Incident-Signature"}),
+		LogMaskEmails:    getBoolWithDefault("LOG_MASK_EMAILS", true),
+		LogRequestBodies: getBoolWithDefault("LOG_REQUEST_BODIES", false),
+
+		KafkaProduceMode:    getChoiceWithDefault("KAFKA_PRODUCE_MODE", "async", "sync", "async"),
+		KafkaProduceTimeout: getDurationWithDefault("KAFKA_PRODUCE_TIMEOUT", 5*time.Second),
+		KafkaBatchSize:      getIntWithDefault("KAFKA_BATCH_SIZE", 0),
+		KafkaLinger:         getDurationWithDefault("KAFKA_LINGER", 10*time.Millisecond),
+		MinEventSeverity:    strings.ToLower(os.Getenv("MIN_EVENT_SEVERITY")),
+
+		KafkaAcks:       getChoiceWithDefault("KAFKA_ACKS", "all", "all", "leader", "none"),
+		KafkaIdempotent: getBoolWithDefault("KAFKA_IDEMPOTENT", true),
+
+		ListSortField:     getSortFieldWithDefault("LIST_SORT_FIELD", "created_at"),
+		ListSortDirection: parseSortDirection(getEnvWithDefault("LIST_SORT_DIRECTION", "desc")),
+
+		ExportFormat: getEnvWithDefault("EXPORT_FORMAT", "markdown"),
+
+		AttachmentStorageEndpoint:  os.Getenv("ATTACHMENT_STORAGE_ENDPOINT"),
+		AttachmentStorageBucket:    getEnvWithDefault("ATTACHMENT_STORAGE_BUCKET", "incident-attachments"),
+		AttachmentStorageRegion:    getEnvWithDefault("ATTACHMENT_STORAGE_REGION", "us-east-1"),
+		AttachmentStorageAccessKey: os.Getenv("ATTACHMENT_STORAGE_ACCESS_KEY"),
+		AttachmentStorageSecretKey: os.Getenv("ATTACHMENT_STORAGE_SECRET_KEY"),
+		AttachmentUploadExpiry:     getDurationWithDefault("ATTACHMENT_UPLOAD_EXPIRY", 15*time.Minute),
 	}
 
+	// Development keeps working without a configured database URI
+	if config.MongoURI == "" && !config.IsProduction() {
+		config.MongoURI = "mongo dummy"
+	}
+
+	if err := config.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	quiet.Store(!config.VerboseLogging())
+
 	// Log loaded configuration (excluding sensitive data)
-	log.Printf("Configuration loaded:")
-	log.Printf("- Port: %s", config.Port)
-	log.Printf("- Database Name: %s", config.DatabaseName)
-	log.Printf("- Environment: %s", config.Environment)
-	log.Printf("- MongoDB URI: %s", maskURI(config.MongoURI))
+	Infof("Configuration loaded:")
+	Infof("- Port: %s", config.Port)
+	Infof("- Database Name: %s", config.DatabaseName)
+	Infof("- Incidents Collection: %s", config.IncidentsCollection)
+	Infof("- Environment: %s", config.Environment)
+	Infof("- MongoDB URI: %s", maskURI(config.MongoURI))
+	Infof("- Features: %s", strings.Join(config.Features, ","))
+	Infof("- Dedup Window: %s", config.DedupWindow)
+	Infof("- Duplicate Match: %s (normalization %v)", config.DuplicateMatch, config.DuplicateNormalization)
+	Infof("- Max Notes: %d", config.MaxNotes)
+	Infof("- Max Title Length: %d (%s)", config.MaxTitleLength, config.TitleOverflow)
+	Infof("- Min Description Lengths: %v", config.MinDescriptionLengths)
+	Infof("- Incident Key Mode: %s", config.IncidentKeyMode)
+	Infof("- Attention Threshold: %s", config.AttentionThreshold)
+	Infof("- Min Time In Progress: %s", config.MinTimeInProgress)
+	Infof("- Require Assignee To Resolve: %t", config.RequireAssigneeToResolve)
+	Infof("- Postmortem Required For: %v", config.PostmortemRequiredSeverities)
+	Infof("- Severity Change Cooldown: %s", config.SeverityChangeCooldown)
+	Infof("- Transaction Max Retries: %d", config.TransactionMaxRetries)
+	Infof("- Require Note Author: %t", config.RequireNoteAuthor)
+	Infof("- System Actor: %s", config.SystemActorEmail)
+	Infof("- Enforce Note Templates: %t", config.EnforceNoteTemplates)
+	Infof("- Default Note Visibility: %s", config.DefaultNoteVisibility)
+	Infof("- Status Page Rate Limit: %d/min", config.StatusPageRateLimit)
+	Infof("- Transitions Requiring Notes: %d", len(config.TransitionNotes))
+	Infof("- Create Latency Budget: %s", config.CreateLatencyBudget)
+	Infof("- Default Watchers: %d severities", len(config.DefaultWatchers))
+	Infof("- Incident Cache: %d entries (TTL %s)", config.IncidentCacheSize, config.IncidentCacheTTL)
+	Infof("- List Count Cache TTL: %s", config.ListCountCacheTTL)
+	Infof("- Strict JSON: %t", config.StrictJSON)
+	Infof("- Response Field Case: %s", config.ResponseFieldCase)
+	Infof("- Description Template: %t", config.DescriptionTemplate != "")
+	Infof("- Severity Weights: %v", config.SeverityWeights)
+	Infof("- Note Type Events: %s", strings.Join(config.NoteTypeEvents, ","))
+	Infof("- On-call Assignees: %d", len(config.OnCallAssignees))
+	Infof("- Escalation Chains: %d (ack SLA %s)", len(config.EscalationChains), config.AckSLA)
+	Infof("- SLA Targets: %v (at risk after %d%%)", config.SLATargets, config.SLAAtRiskPercent)
+	Infof("- Stale Thresholds: %v (checked every %s)", config.StaleThresholds, config.StaleCheckInterval)
+	Infof("- Archive Closed After: %s to %s (checked every %s)", config.ArchiveClosedAfter, config.ArchiveCollection, config.ArchiveCheckInterval)
+	Infof("- Schedule Check Interval: %s", config.ScheduleCheckInterval)
+	Infof("- Create Conflict Rules: %s", strings.Join(config.CreateConflictRules, ","))
+	Infof("- Custom Fields: %d", len(config.CustomFieldSchema))
+	Infof("- Webhooks: %d (concurrency %d, max attempts %d)", len(config.Webhooks), config.WebhookConcurrency, config.WebhookMaxAttempts)
+	Infof("- Admin API: %t", config.AdminToken != "")
+	Infof("- Multi-tenant: %t (%d tenant tokens)", config.MultiTenant, len(config.TenantTokens))
+	Infof("- Role-based authorization: %t (%d role tokens)", len(config.RoleTokens) > 0, len(config.RoleTokens))
+	Infof("- CORS Origins: %s", config.AllowedOrigins())
+	Infof("- Max Concurrent Requests: %d", config.MaxConcurrentRequests)
+	Infof("- Request Timeout: %s", config.RequestTimeout)
+	Infof("- Shutdown Timeout: %s", config.ShutdownTimeout)
+	Infof("- Log Redaction: headers=%s mask_emails=%t bodies=%t",
+		strings.Join(config.LogRedactHeaders, ","), config.LogMaskEmails, config.LogRequestBodies)
+	Infof("- Kafka Produce: %s (timeout %s)", config.KafkaProduceMode, config.KafkaProduceTimeout)
+	Infof("- Kafka Batching: %d events (linger %s)", config.KafkaBatchSize, config.KafkaLinger)
+	Infof("- Kafka Acks: %s (idempotent %t)", config.KafkaAcks, config.KafkaIdempotent)
+	Infof("- Min Event Severity: %s", config.MinEventSeverity)
+	Infof("- List Sort: %s %d", config.ListSortField, config.ListSortDirection)
+	Infof("- Export Format: %s", config.ExportFormat)
+	Infof("- Attachment Storage: %s", maskURI(config.AttachmentStorageEndpoint))
 
 	return config
 }
 
+// IsProduction reports whether the service runs in the production environment
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.Environment, "production")
+}
+
+// Validate checks the configuration required by the current environment
+func (c *Config) Validate() error {
+	if c.IsProduction() && c.MongoURI == "" {
+		return errors.New("MONGO_URI is required in production")
+	}
+	return nil
+}
+
+// FeatureEnabled reports whether the named feature is enabled
+func (c *Config) FeatureEnabled(feature string) bool {
+	for _, enabled := range c.Features {
+		if strings.EqualFold(enabled, feature) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedOrigins returns the CORS origins to allow. Development allows any
+// origin; production only allows the explicitly configured origins and never
+// the wildcard. An empty result means cross-origin requests are not allowed.
+func (c *Config) AllowedOrigins() string {
+	if !c.IsProduction() {
+		return "*"
+	}
+
+	var origins []string
+	for _, origin := range strings.Split(c.CORSAllowOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" && origin != "*" {
+			origins = append(origins, origin)
+		}
+	}
+	return strings.Join(origins, ",")
+}
+
+// VerboseLogging reports whether per-request and informational logs are enabled
+func (c *Config) VerboseLogging() bool {
+	return !c.IsProduction()
+}
+
 // getEnvWithDefault returns environment variable value or default if not set
 func getEnvWithDefault(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -47,6 +424,372 @@ func getEnvWithDefault(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getDurationWithDefault returns environment variable parsed as a duration or default if not set or invalid
+func getDurationWithDefault(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s: %v, using default %s", key, err, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+// getIntWithDefault returns environment variable parsed as an integer or default if not set or invalid
+func getIntWithDefault(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s: %v, using default %d", key, err, defaultValue)
+		return defaultValue
+	}
+	return number
+}
+
+// getBoolWithDefault returns environment variable parsed as a boolean or default if not set or invalid
+func getBoolWithDefault(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean for %s: %v, using default %t", key, err, defaultValue)
+		return defaultValue
+	}
+	return enabled
+}
+
+// getListWithDefault returns a comma separated environment variable as a list or default if not set
+func getListWithDefault(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseEscalationChains reads the comma separated responders paged for each
+// severity from ESCALATION_CHAIN_<SEVERITY>, e.g. ESCALATION_CHAIN_CRITICAL
+func parseEscalationChains() map[string][]string {
+	chains := make(map[string][]string)
+	for _, severity := range []string{"low", "medium", "high", "critical"} {
+		if chain := getListWithDefault("ESCALATION_CHAIN_"+strings.ToUpper(severity), nil); len(chain) > 0 {
+			chains[severity] = chain
+		}
+	}
+	return chains
+}
+
+// parseDefaultWatchers reads the comma separated emails watching every new incident
+// of a severity from DEFAULT_WATCHERS_<SEVERITY>, e.g. DEFAULT_WATCHERS_CRITICAL
+func parseDefaultWatchers() map[string][]string {
+	watchers := make(map[string][]string)
+	for _, severity := range []string{"low", "medium", "high", "critical"} {
+		if emails := getListWithDefault("DEFAULT_WATCHERS_"+strings.ToUpper(severity), nil); len(emails) > 0 {
+			watchers[severity] = emails
+		}
+	}
+	return watchers
+}
+
+// parseNoteTemplates reads the comma separated headings required for each note
+// type from NOTE_TEMPLATE_<TYPE>, e.g. NOTE_TEMPLATE_RESOLUTION
+func parseNoteTemplates() map[string][]string {
+	templates := make(map[string][]string)
+	for _, noteType := range []string{"update", "investigation", "resolution", "communication"} {
+		if sections := getListWithDefault("NOTE_TEMPLATE_"+strings.ToUpper(noteType), nil); len(sections) > 0 {
+			templates[noteType] = sections
+		}
+	}
+	return templates
+}
+
+// parseWebhookTargets parses webhooks in the form "url|type,type;url", where the
+// event type filter after '|' is optional
+func parseWebhookTargets(value string) []WebhookTarget {
+	var targets []WebhookTarget
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		url, types, _ := strings.Cut(entry, "|")
+		target := WebhookTarget{URL: strings.TrimSpace(url)}
+		for _, eventType := range strings.Split(types, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				target.EventTypes = append(target.EventTypes, eventType)
+			}
+		}
+		targets = append(targets, target)
+	}
+	return targets
+}
+
+// parseTenantTokens parses tenant tokens in the form "token:tenant,token:tenant"
+func parseTenantTokens(value string) map[string]string {
+	tokens := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		token, tenant, _ := strings.Cut(entry, ":")
+		token, tenant = strings.TrimSpace(token), strings.TrimSpace(tenant)
+		if token == "" || tenant == "" {
+			log.Printf("Invalid entry in TENANT_TOKENS, skipping")
+			continue
+		}
+		tokens[token] = tenant
+	}
+	return tokens
+}
+
+// parseKeyedLists parses an environment variable in the form "key:a|b,key:c", as
+// used for the roles of each token and the permissions of each role
+func parseKeyedLists(name string) map[string][]string {
+	lists := make(map[string][]string)
+	for _, entry := range strings.Split(os.Getenv(name), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, values, _ := strings.Cut(entry, ":")
+		if key = strings.TrimSpace(key); key == "" {
+			log.Printf("Invalid entry in %s, skipping", name)
+			continue
+		}
+		for _, value := range strings.Split(values, "|") {
+			if value = strings.TrimSpace(value); value != "" {
+				lists[key] = append(lists[key], value)
+			}
+		}
+		if _, ok := lists[key]; !ok {
+			lists[key] = []string{}
+		}
+	}
+	return lists
+}
+
+// parseCustomFieldSchema parses custom fields in the form "key:type,key:type",
+// where type is string, number or bool and defaults to string
+func parseCustomFieldSchema(value string) map[string]string {
+	schema := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, fieldType, _ := strings.Cut(entry, ":")
+		fieldType = strings.ToLower(strings.TrimSpace(fieldType))
+		switch fieldType {
+		case "":
+			fieldType = "string"
+		case "string", "number", "bool":
+		default:
+			log.Printf("Invalid type %q for custom field %s, using string", fieldType, key)
+			fieldType = "string"
+		}
+		schema[strings.TrimSpace(key)] = fieldType
+	}
+	return schema
+}
+
+// parseSeverityWeights parses load weights in the form "severity:weight,...", e.g.
+// "critical:20". Severities left out keep their default weight.
+func parseSeverityWeights(value string) map[string]int {
+	weights := map[string]int{"low": 1, "medium": 2, "high": 5, "critical": 10}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		severity, weight, _ := strings.Cut(entry, ":")
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if _, known := weights[severity]; !known {
+			log.Printf("Invalid severity %q in SEVERITY_WEIGHTS, skipping", severity)
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil || number < 0 {
+			log.Printf("Invalid weight %q for %s in SEVERITY_WEIGHTS, keeping %d", weight, severity, weights[severity])
+			continue
+		}
+		weights[severity] = number
+	}
+	return weights
+}
+
+// parseSLATargets parses resolution targets in the form "severity:duration,...",
+// e.g. "critical:4h,high:24h"
+func parseSLATargets(value string) map[string]time.Duration {
+	targets := make(map[string]time.Duration)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		severity, target, _ := strings.Cut(entry, ":")
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if !slices.Contains([]string{"low", "medium", "high", "critical"}, severity) {
+			log.Printf("Invalid severity %q in SLA_TARGETS, skipping", severity)
+			continue
+		}
+		duration, err := time.ParseDuration(strings.TrimSpace(target))
+		if err != nil || duration <= 0 {
+			log.Printf("Invalid target %q for %s in SLA_TARGETS, skipping", target, severity)
+			continue
+		}
+		targets[severity] = duration
+	}
+	return targets
+}
+
+// parseMinDescriptionLengths parses minimum description lengths in the form
+// "severity:characters,...", e.g. "critical:50,high:20"
+func parseMinDescriptionLengths(value string) map[string]int {
+	lengths := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		severity, length, _ := strings.Cut(entry, ":")
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if !slices.Contains([]string{"low", "medium", "high", "critical"}, severity) {
+			log.Printf("Invalid severity %q in MIN_DESCRIPTION_LENGTHS, skipping", severity)
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimSpace(length))
+		if err != nil || number < 0 {
+			log.Printf("Invalid length %q for %s in MIN_DESCRIPTION_LENGTHS, skipping", length, severity)
+			continue
+		}
+		lengths[severity] = number
+	}
+	return lengths
+}
+
+// parseStaleThresholds parses comma separated durations, e.g. "24h,72h,168h",
+// into ascending order without duplicates
+func parseStaleThresholds(value string) []time.Duration {
+	var thresholds []time.Duration
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		threshold, err := time.ParseDuration(entry)
+		if err != nil || threshold <= 0 {
+			log.Printf("Invalid threshold %q in STALE_THRESHOLDS, skipping", entry)
+			continue
+		}
+		if !slices.Contains(thresholds, threshold) {
+			thresholds = append(thresholds, threshold)
+		}
+	}
+	slices.Sort(thresholds)
+	return thresholds
+}
+
+// parseTransitionNotes parses required transition notes in the form
+// "status:note_type,status:note_type", e.g. "resolved:resolution,closed:update".
+// Unknown statuses or note types are skipped.
+func parseTransitionNotes(value string) map[string]string {
+	notes := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		status, noteType, _ := strings.Cut(entry, ":")
+		status = strings.ToLower(strings.TrimSpace(status))
+		noteType = strings.ToLower(strings.TrimSpace(noteType))
+		switch status {
+		case "open", "in_progress", "resolved", "closed":
+		default:
+			log.Printf("Invalid status %q in TRANSITION_NOTES, skipping", status)
+			continue
+		}
+		switch noteType {
+		case "update", "investigation", "resolution", "communication":
+		default:
+			log.Printf("Invalid note type %q for status %s in TRANSITION_NOTES, skipping", noteType, status)
+			continue
+		}
+		notes[status] = noteType
+	}
+	return notes
+}
+
+// sortableFields lists the incident fields the list endpoint may be sorted by
+var sortableFields = []string{"created_at", "updated_at", "incident_key"}
+
+// getSortFieldWithDefault returns environment variable as a sortable incident field or default if not set or unknown
+func getSortFieldWithDefault(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	for _, sortable := range sortableFields {
+		if value == sortable {
+			return value
+		}
+	}
+	log.Printf("Invalid sort field for %s: %s, using default %s", key, value, defaultValue)
+	return defaultValue
+}
+
+// parseSortDirection converts "asc"/"desc" into a MongoDB sort direction, defaulting to descending
+func parseSortDirection(value string) int {
+	if strings.EqualFold(strings.TrimSpace(value), "asc") {
+		return 1
+	}
+	return -1
+}
+
+// getChoiceWithDefault returns environment variable as one of the allowed choices or default if not set or unknown
+func getChoiceWithDefault(key, defaultValue string, choices ...string) string {
+	value := strings.ToLower(os.Getenv(key))
+	if value == "" {
+		return defaultValue
+	}
+
+	for _, choice := range choices {
+		if value == choice {
+			return value
+		}
+	}
+	log.Printf("Invalid value for %s: %s, using default %s", key, value, defaultValue)
+	return defaultValue
+}
+
 // maskURI masks sensitive information in URI for logging
 func maskURI(uri string) string {
 	if len(uri) > 20 {
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoad_EnvironmentToggles(t *testing.T) {
+	tests := []struct {
+		name            string
+		environment     string
+		corsOrigins     string
+		expectedOrigins string
+		expectedVerbose bool
+	}{
+		{
+			name:            "development keeps conveniences",
+			environment:     "development",
+			corsOrigins:     "https://ops.example.com",
+			expectedOrigins: "*",
+			expectedVerbose: true,
+		},
+		{
+			name:            "production restricts origins and logs",
+			environment:     "production",
+			corsOrigins:     "https://ops.example.com, *",
+			expectedOrigins: "https://ops.example.com",
+			expectedVerbose: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("ENVIRONMENT", tt.environment)
+			t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+			t.Setenv("CORS_ALLOW_ORIGINS", tt.corsOrigins)
+
+			// Act
+			cfg := Load()
+
+			// Assert
+			if got := cfg.AllowedOrigins(); got != tt.expectedOrigins {
+				t.Errorf("Expected origins %q, got %q", tt.expectedOrigins, got)
+			}
+			if got := cfg.VerboseLogging(); got != tt.expectedVerbose {
+				t.Errorf("Expected verbose logging %t, got %t", tt.expectedVerbose, got)
+			}
+		})
+	}
+}
+
+func TestLoad_ProductionSilencesInfoLogs(t *testing.T) {
+	tests := []struct {
+		environment    string
+		expectInfoLogs bool
+	}{
+		{environment: "development", expectInfoLogs: true},
+		{environment: "production", expectInfoLogs: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			// Arrange
+			t.Setenv("ENVIRONMENT", tt.environment)
+			t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+			var output bytes.Buffer
+			log.SetOutput(&output)
+			t.Cleanup(func() {
+				log.SetOutput(os.Stderr)
+				quiet.Store(false)
+			})
+
+			// Act
+			Load()
+			Infof("Fetched %d incidents", 3)
+
+			// Assert
+			logged := output.String()
+			if got := strings.Contains(logged, "Configuration loaded"); got != tt.expectInfoLogs {
+				t.Errorf("Expected configuration dump %t, got %t:\n%s", tt.expectInfoLogs, got, logged)
+			}
+			if got := strings.Contains(logged, "Fetched 3 incidents"); got != tt.expectInfoLogs {
+				t.Errorf("Expected info logs %t, got %t:\n%s", tt.expectInfoLogs, got, logged)
+			}
+		})
+	}
+}
+
+func TestLoad_CreateConflictRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{name: "none by default", value: "", expected: nil},
+		{name: "enabled explicitly", value: "resolution_note, assignee_is_watcher", expected: []string{"resolution_note", "assignee_is_watcher"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			t.Setenv("MONGO_URI", "mongodb://localhost:27017")
+			t.Setenv("CREATE_CONFLICT_RULES", tt.value)
+
+			// Act
+			cfg := Load()
+
+			// Assert
+			if !slices.Equal(cfg.CreateConflictRules, tt.expected) {
+				t.Errorf("Expected rules %v, got %v", tt.expected, cfg.CreateConflictRules)
+			}
+		})
+	}
+}
+
+func TestValidate_RequiresMongoURIInProduction(t *testing.T) {
+	// Arrange
+	production := &Config{Environment: "production"}
+	development := &Config{Environment: "development"}
+
+	// Act & Assert
+	if err := production.Validate(); err == nil {
+		t.Error("Expected error for production without MONGO_URI, got nil")
+	}
+	if err := development.Validate(); err != nil {
+		t.Errorf("Expected no error for development, got %v", err)
+	}
+}
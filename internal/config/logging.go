@@ -0,0 +1,18 @@
+package config
+
+import (
+	"log"
+	"sync/atomic"
+)
+
+// quiet silences Infof. Load sets it when VerboseLogging is off.
+var quiet atomic.Bool
+
+// Infof logs an informational message unless verbose logging is off. Warnings and
+// errors go straight to log so they are never silenced.
+func Infof(format string, args ...interface{}) {
+	if quiet.Load() {
+		return
+	}
+	log.Printf(format, args...)
+}
@@ -1 +1,16 @@
 db.createCollection("incidents")
+
+// One incident per external ticket and tenant, see EnsureExternalIDIndex
+db.incidents.createIndex(
+  { tenant_id: 1, external_id: 1 },
+  { name: "incident_external_id", unique: true, partialFilterExpression: { external_id: { $exists: true } } }
+)
+
+// One incident per idempotency key and tenant, see EnsureIdempotencyKeyIndex
+db.incidents.createIndex(
+  { tenant_id: 1, idempotency_key: 1 },
+  { name: "incident_idempotency_key", unique: true, partialFilterExpression: { idempotency_key: { $exists: true } } }
+)
+
+// Full-text search over incident titles and descriptions
+db.incidents.createIndex({ title: "text", description: "text" }, { name: "incident_text" })
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Error labels MongoDB attaches to transaction errors that are safe to retry
+const (
+	transientTransactionLabel = "TransientTransactionError"
+	unknownCommitResultLabel  = "UnknownTransactionCommitResult"
+)
+
+// labeledError is implemented by driver errors that carry error labels
+type labeledError interface {
+	HasErrorLabel(label string) bool
+}
+
+// hasErrorLabel reports whether err, or any error it wraps, carries the label
+func hasErrorLabel(err error, label string) bool {
+	var labeled labeledError
+	return errors.As(err, &labeled) && labeled.HasErrorLabel(label)
+}
+
+// RunInTransaction runs fn inside a transaction, retrying the whole transaction on
+// TransientTransactionError and the commit on UnknownTransactionCommitResult, each
+// at most maxRetries times. Transactions require a replica set or sharded cluster.
+func RunInTransaction(ctx context.Context, client *mongo.Client, maxRetries int, fn func(sessCtx mongo.SessionContext) error) error {
+	session, err := client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	return mongo.WithSession(ctx, session, func(sessCtx mongo.SessionContext) error {
+		return retryTransient(maxRetries, transientTransactionLabel, func() error {
+			if err := sessCtx.StartTransaction(); err != nil {
+				return err
+			}
+			if err := fn(sessCtx); err != nil {
+				if abortErr := sessCtx.AbortTransaction(context.Background()); abortErr != nil {
+					log.Printf("Error aborting transaction: %v", abortErr)
+				}
+				return err
+			}
+			return retryTransient(maxRetries, unknownCommitResultLabel, func() error {
+				return sessCtx.CommitTransaction(sessCtx)
+			})
+		})
+	})
+}
+
+// retryTransient runs attempt, retrying up to maxRetries more times while it fails
+// with an error carrying the label
+func retryTransient(maxRetries int, label string, attempt func() error) error {
+	err := attempt()
+	for retry := 1; retry <= maxRetries && err != nil && hasErrorLabel(err, label); retry++ {
+		log.Printf("Retrying transaction after %s (retry %d of %d): %v", label, retry, maxRetries, err)
+		err = attempt()
+	}
+	return err
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestRetryTransient_SucceedsAfterTransientError(t *testing.T) {
+	// Arrange
+	attempts := 0
+	transientErr := mongo.CommandError{Code: 112, Name: "WriteConflict", Labels: []string{transientTransactionLabel}}
+
+	// Act
+	err := retryTransient(3, transientTransactionLabel, func() error {
+		attempts++
+		if attempts == 1 {
+			return transientErr
+		}
+		return nil
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryTransient_StopsAtMaxRetries(t *testing.T) {
+	// Arrange
+	attempts := 0
+	transientErr := mongo.CommandError{Code: 112, Name: "WriteConflict", Labels: []string{transientTransactionLabel}}
+
+	// Act
+	err := retryTransient(2, transientTransactionLabel, func() error {
+		attempts++
+		return transientErr
+	})
+
+	// Assert
+	if !hasErrorLabel(err, transientTransactionLabel) {
+		t.Fatalf("Expected the transient error, got %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d", attempts)
+	}
+}
+
+func TestRetryTransient_DoesNotRetryOtherErrors(t *testing.T) {
+	// Arrange
+	attempts := 0
+	permanentErr := errors.New("duplicate key")
+
+	// Act
+	err := retryTransient(3, transientTransactionLabel, func() error {
+		attempts++
+		return permanentErr
+	})
+
+	// Assert
+	if !errors.Is(err, permanentErr) {
+		t.Fatalf("Expected the original error, got %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected a single attempt, got %d", attempts)
+	}
+}
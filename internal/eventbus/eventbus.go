@@ -0,0 +1,123 @@
+package eventbus
+
+import (
+	"log"
+	"sync"
+
+	"makers.anchor/incident/internal/kafka"
+)
+
+// DefaultBufferSize is the number of events buffered per subscriber before it is dropped
+const DefaultBufferSize = 64
+
+// Subscription represents a single subscriber to a topic
+type Subscription struct {
+	topic  string
+	events chan kafka.KafkaEvent
+}
+
+// Events returns the channel the subscriber receives events on. The channel is
+// closed when the subscription is removed, either explicitly or because the
+// subscriber fell too far behind.
+func (s *Subscription) Events() <-chan kafka.KafkaEvent {
+	return s.events
+}
+
+// EventBus is an in-process, topic-based publish/subscribe hub. Publishing never
+// blocks: subscribers whose buffer is full are dropped instead.
+type EventBus struct {
+	mu          sync.RWMutex
+	bufferSize  int
+	subscribers map[string]map[*Subscription]struct{}
+}
+
+// New creates a new event bus
+func New(bufferSize int) *EventBus {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &EventBus{
+		bufferSize:  bufferSize,
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe registers a new subscriber for the given topic
+func (b *EventBus) Subscribe(topic string) *Subscription {
+	sub := &Subscription{
+		topic:  topic,
+		events: make(chan kafka.KafkaEvent, b.bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[topic][sub] = struct{}{}
+
+	return sub
+}
+
+// Unsubscribe removes the subscriber and closes its channel. It is safe to call
+// more than once.
+func (b *EventBus) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(sub)
+}
+
+// Publish delivers the event to every subscriber of the topic
+func (b *EventBus) Publish(topic string, event kafka.KafkaEvent) {
+	var slow []*Subscription
+
+	b.mu.RLock()
+	for sub := range b.subscribers[topic] {
+		select {
+		case sub.events <- event:
+		default:
+			slow = append(slow, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, sub := range slow {
+		log.Printf("Dropping slow event bus subscriber on topic %s", topic)
+		b.remove(sub)
+	}
+}
+
+// SubscriberCount returns the number of active subscribers for the topic
+func (b *EventBus) SubscriberCount(topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers[topic])
+}
+
+// remove deletes the subscriber and closes its channel; callers must hold the write lock
+func (b *EventBus) remove(sub *Subscription) {
+	subs, ok := b.subscribers[sub.topic]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+	close(sub.events)
+
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.topic)
+	}
+}
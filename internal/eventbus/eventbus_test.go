@@ -0,0 +1,126 @@
+package eventbus
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+const testTopic = "incidents"
+
+func TestEventBus_FanOutToMultipleSubscribers(t *testing.T) {
+	// Arrange
+	bus := New(DefaultBufferSize)
+	first := bus.Subscribe(testTopic)
+	second := bus.Subscribe(testTopic)
+	other := bus.Subscribe("other")
+
+	event := models.IncidentCreated{Id: "abc", Title: "Disk full"}
+
+	// Act
+	bus.Publish(testTopic, event)
+
+	// Assert
+	for i, sub := range []*Subscription{first, second} {
+		select {
+		case received := <-sub.Events():
+			if received.(models.IncidentCreated).Id != event.Id {
+				t.Errorf("Subscriber %d expected event %s, got %s", i, event.Id, received.(models.IncidentCreated).Id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Subscriber %d did not receive the event", i)
+		}
+	}
+
+	select {
+	case received := <-other.Events():
+		t.Errorf("Expected no event on other topic, got %v", received)
+	default:
+	}
+}
+
+func TestEventBus_UnsubscribeClosesChannel(t *testing.T) {
+	// Arrange
+	bus := New(DefaultBufferSize)
+	sub := bus.Subscribe(testTopic)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range sub.Events() {
+		}
+	}()
+
+	// Act
+	bus.Unsubscribe(sub)
+	bus.Unsubscribe(sub) // safe to call twice
+	bus.Publish(testTopic, models.IncidentCreated{})
+
+	// Assert
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected consumer goroutine to exit after unsubscribe")
+	}
+
+	if count := bus.SubscriberCount(testTopic); count != 0 {
+		t.Errorf("Expected 0 subscribers, got %d", count)
+	}
+}
+
+func TestEventBus_DropsSlowSubscriberWithoutBlocking(t *testing.T) {
+	// Arrange
+	bus := New(1)
+	slow := bus.Subscribe(testTopic)
+	fast := bus.Subscribe(testTopic)
+
+	var received int
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range fast.Events() {
+			received++
+		}
+	}()
+
+	// Act
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(testTopic, models.IncidentCreated{Id: "1"})
+		bus.Publish(testTopic, models.IncidentCreated{Id: "2"})
+		close(published)
+	}()
+
+	// Assert
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Expected publish not to block on a slow subscriber")
+	}
+
+	if _, ok := <-slow.Events(); !ok {
+		t.Fatal("Expected the buffered event before the channel was closed")
+	}
+	if _, ok := <-slow.Events(); ok {
+		t.Error("Expected slow subscriber channel to be closed")
+	}
+
+	bus.Unsubscribe(fast)
+	wg.Wait()
+
+	if count := bus.SubscriberCount(testTopic); count != 0 {
+		t.Errorf("Expected 0 subscribers, got %d", count)
+	}
+	if received == 0 {
+		t.Error("Expected fast subscriber to receive events")
+	}
+}
@@ -0,0 +1,29 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+const (
+	FormatMarkdown = "markdown"
+)
+
+// Renderer renders a single incident as a report document
+type Renderer interface {
+	ContentType() string
+	FileExtension() string
+	Render(incident *models.Incident) ([]byte, error)
+}
+
+// NewRenderer returns the renderer for the configured format
+func NewRenderer(format string) (Renderer, error) {
+	switch strings.ToLower(format) {
+	case "", FormatMarkdown:
+		return MarkdownRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
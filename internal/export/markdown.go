@@ -0,0 +1,93 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// MarkdownRenderer renders incident reports as Markdown
+type MarkdownRenderer struct{}
+
+func (MarkdownRenderer) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+func (MarkdownRenderer) FileExtension() string {
+	return "md"
+}
+
+// Render renders the incident summary, timeline, notes and resolution
+func (MarkdownRenderer) Render(incident *models.Incident) ([]byte, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Incident #%s: %s\n\n", models.FormatIncidentKey(incident.IncidentKey), incident.Title)
+
+	b.WriteString("| Field | Value |\n")
+	b.WriteString("| --- | --- |\n")
+	fmt.Fprintf(&b, "| Severity | %s |\n", incident.Severity)
+	fmt.Fprintf(&b, "| Status | %s |\n", incident.Status)
+	fmt.Fprintf(&b, "| Assignee | %s |\n", valueOrNone(incident.Assignee))
+	fmt.Fprintf(&b, "| Created By | %s |\n", valueOrNone(incident.CreatedBy))
+	fmt.Fprintf(&b, "| Created At | %s |\n", formatTime(incident.CreatedAt))
+	fmt.Fprintf(&b, "| Updated At | %s |\n\n", formatTime(incident.UpdatedAt))
+
+	b.WriteString("## Description\n\n")
+	b.WriteString(valueOrNone(incident.Description))
+	b.WriteString("\n\n")
+
+	notes := make([]models.Note, len(incident.Notes))
+	copy(notes, incident.Notes)
+	sort.SliceStable(notes, func(i, j int) bool {
+		return notes[i].CreatedAt.Before(notes[j].CreatedAt)
+	})
+
+	b.WriteString("## Timeline\n\n")
+	fmt.Fprintf(&b, "- %s — Incident created with severity %s\n", formatTime(incident.CreatedAt), incident.Severity)
+	for _, note := range notes {
+		fmt.Fprintf(&b, "- %s — %s note added by %s\n", formatTime(note.CreatedAt), valueOrNone(string(note.Type)), valueOrNone(note.AuthorEmail))
+	}
+	fmt.Fprintf(&b, "- %s — Last updated, status %s\n\n", formatTime(incident.UpdatedAt), incident.Status)
+
+	b.WriteString("## Notes\n\n")
+	if len(notes) == 0 {
+		b.WriteString("None\n\n")
+	}
+	for i, note := range notes {
+		fmt.Fprintf(&b, "### %d. %s by %s at %s\n\n", i+1, valueOrNone(string(note.Type)), valueOrNone(note.AuthorEmail), formatTime(note.CreatedAt))
+		b.WriteString(note.Content)
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString("## Resolution\n\n")
+	resolved := false
+	for _, note := range notes {
+		if note.Type == models.Resolution {
+			b.WriteString(note.Content)
+			b.WriteString("\n\n")
+			resolved = true
+		}
+	}
+	if !resolved {
+		fmt.Fprintf(&b, "No resolution recorded (status: %s)\n", incident.Status)
+	}
+
+	return []byte(b.String()), nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "None"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func valueOrNone(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "None"
+	}
+	return value
+}
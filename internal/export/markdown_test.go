@@ -0,0 +1,65 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+func TestMarkdownRenderer_Render(t *testing.T) {
+	// Arrange
+	created := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	incident := &models.Incident{
+		IncidentKey: 42,
+		Title:       "Checkout failures",
+		Severity:    models.Critical,
+		Status:      models.Resolved,
+		CreatedAt:   created,
+		UpdatedAt:   created.Add(2 * time.Hour),
+		Notes: []models.Note{
+			{Content: "Rolled back release 1.8.2", Type: models.Resolution, AuthorEmail: "bob@example.com", CreatedAt: created.Add(90 * time.Minute)},
+			{Content: "Payment provider returning 502s", Type: models.Investigation, AuthorEmail: "alice@example.com", CreatedAt: created.Add(10 * time.Minute)},
+		},
+	}
+
+	// Act
+	report, err := MarkdownRenderer{}.Render(incident)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	markdown := string(report)
+	if !strings.Contains(markdown, "# Incident #42: Checkout failures") {
+		t.Errorf("Expected report to contain incident key and title, got:\n%s", markdown)
+	}
+
+	notes := markdown[strings.Index(markdown, "## Notes"):]
+	first := strings.Index(notes, "Payment provider returning 502s")
+	second := strings.Index(notes, "Rolled back release 1.8.2")
+	if first == -1 || second == -1 {
+		t.Fatalf("Expected report to contain every note, got:\n%s", markdown)
+	}
+	if first > second {
+		t.Error("Expected notes in chronological order")
+	}
+}
+
+func TestMarkdownRenderer_Render_FormatsYearlyKey(t *testing.T) {
+	// Arrange
+	incident := &models.Incident{IncidentKey: models.YearlyIncidentKey(2024, 1), Title: "Checkout failures"}
+
+	// Act
+	report, err := MarkdownRenderer{}.Render(incident)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(report), "# Incident #2024-0001: Checkout failures") {
+		t.Errorf("Expected the yearly key to be formatted, got:\n%s", report)
+	}
+}
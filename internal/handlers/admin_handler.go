@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/services"
+)
+
+type AdminHandler struct {
+	service *services.IncidentService
+}
+
+func NewAdminHandler(service *services.IncidentService) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+	}
+}
+
+// ReplayIncidentEvents handles POST /admin/incidents/:id/replay
+func (h *AdminHandler) ReplayIncidentEvents(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	events, err := h.service.ReplayIncidentEvents(c.UserContext(), id)
+	if err != nil {
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":    "Failed to replay incident events",
+			"details":  err.Error(),
+			"replayed": len(events),
+		})
+	}
+
+	eventTypes := make([]string, len(events))
+	for i, event := range events {
+		eventTypes[i] = event.GetEventType()
+	}
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": fiber.Map{
+			"replayed": len(events),
+			"events":   eventTypes,
+		},
+	})
+}
+
+// BackfillDerivedFields handles POST /admin/backfill
+func (h *AdminHandler) BackfillDerivedFields(c *fiber.Ctx) error {
+	result, err := h.service.BackfillDerivedFields(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to backfill derived fields",
+			"details": err.Error(),
+			"data":    result,
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
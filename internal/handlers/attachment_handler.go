@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+// AttachmentHandler handles HTTP requests for incident attachments
+type AttachmentHandler struct {
+	service *services.AttachmentService
+}
+
+// NewAttachmentHandler creates a new attachment handler
+func NewAttachmentHandler(service *services.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+	}
+}
+
+// PresignUpload handles POST /incidents/:id/attachments/presign
+func (h *AttachmentHandler) PresignUpload(c *fiber.Ctx) error {
+	var req models.PresignAttachmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.FileName == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "File name is required",
+		})
+	}
+
+	presigned, err := h.service.PresignUpload(c.UserContext(), c.Params("id"), &req)
+	if err != nil {
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to presign attachment upload",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    presigned,
+	})
+}
+
+// ConfirmUpload handles POST /incidents/:id/attachments/:attachmentId/confirm
+func (h *AttachmentHandler) ConfirmUpload(c *fiber.Ctx) error {
+	incident, err := h.service.ConfirmUpload(c.UserContext(), c.Params("id"), c.Params("attachmentId"))
+	if err != nil {
+		if incidentNotFound(err) || errors.Is(err, repository.ErrAttachmentNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to confirm attachment upload",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
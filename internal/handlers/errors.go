@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+// incidentNotFound reports whether a lookup failed because no incident has the
+// identifier. A malformed identifier cannot match an incident either.
+func incidentNotFound(err error) bool {
+	return errors.Is(err, repository.ErrIncidentNotFound) || errors.Is(err, repository.ErrInvalidIncidentID)
+}
+
+// invalidValueResponse responds 400 naming the invalid field and its allowed values
+func invalidValueResponse(c *fiber.Ctx, err *services.InvalidValueError) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":          "Invalid " + err.Field,
+		"details":        err.Error(),
+		"field":          err.Field,
+		"allowed_values": err.Allowed,
+	})
+}
+
+// missingSectionsResponse responds 400 listing the template headings a note lacks
+func missingSectionsResponse(c *fiber.Ctx, err *services.MissingSectionsError) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":            "Note does not match its template",
+		"details":          err.Error(),
+		"missing_sections": err.Missing,
+	})
+}
+
+// unknownFieldPrefix starts the error encoding/json returns for a field the
+// target struct does not declare when unknown fields are disallowed
+const unknownFieldPrefix = "json: unknown field "
+
+// StrictJSONDecoder decodes JSON request bodies like json.Unmarshal, but rejects
+// fields the target does not declare, catching typos such as "severty". Set it as
+// the app's fiber.Config.JSONDecoder to apply it to every BodyParser call.
+func StrictJSONDecoder(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(v)
+}
+
+// invalidBodyResponse responds 400 for a body that failed to parse, pointing at
+// the offending field and expected type, or the position of a syntax error
+func invalidBodyResponse(c *fiber.Ctx, err error) error {
+	body := fiber.Map{
+		"error":   "Invalid request body",
+		"details": err.Error(),
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		body["details"] = fmt.Sprintf("malformed JSON at offset %d: %v", syntaxErr.Offset, syntaxErr)
+		body["offset"] = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		expected := jsonTypeName(typeErr.Type)
+		if typeErr.Field != "" {
+			body["details"] = fmt.Sprintf("field %s must be %s, got %s", typeErr.Field, expected, typeErr.Value)
+			body["field"] = typeErr.Field
+		} else {
+			body["details"] = fmt.Sprintf("body must be %s, got %s", expected, typeErr.Value)
+		}
+		body["expected"] = expected
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		body["details"] = "malformed JSON: body ends unexpectedly"
+	case strings.HasPrefix(err.Error(), unknownFieldPrefix):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), unknownFieldPrefix), `"`)
+		body["details"] = fmt.Sprintf("unknown field %s", field)
+		body["field"] = field
+	}
+
+	return c.Status(fiber.StatusBadRequest).JSON(body)
+}
+
+// jsonTypeName describes a Go type by the JSON value it decodes from
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
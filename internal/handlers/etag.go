@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// sendJSONWithETag sends the body as JSON tagged with a hash of its content,
+// answering 304 Not Modified when the client already holds that version
+func sendJSONWithETag(c *fiber.Ctx, body interface{}) error {
+	payload, err := c.App().Config().JSONEncoder(body)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(payload)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Set(fiber.HeaderETag, etag)
+
+	if etagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Send(payload)
+}
+
+// etagMatches reports whether an If-None-Match header lists the ETag, comparing
+// weakly so W/ prefixed tags added by proxies still match
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
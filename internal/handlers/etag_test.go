@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestSendJSONWithETag_ConditionalGet(t *testing.T) {
+	// Arrange
+	title := "Checkout down"
+	app := fiber.New()
+	app.Get("/incidents", func(c *fiber.Ctx) error {
+		return sendJSONWithETag(c, fiber.Map{"success": true, "data": []string{title}})
+	})
+	get := func(ifNoneMatch string) (int, string) {
+		req := httptest.NewRequest("GET", "/incidents", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		return resp.StatusCode, resp.Header.Get("ETag")
+	}
+
+	// Act
+	firstStatus, firstETag := get("")
+	repeatStatus, _ := get(firstETag)
+	weakStatus, _ := get("W/" + firstETag)
+	title = "Checkout degraded"
+	changedStatus, changedETag := get(firstETag)
+
+	// Assert
+	if firstStatus != fiber.StatusOK || firstETag == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d %q", firstStatus, firstETag)
+	}
+	if repeatStatus != fiber.StatusNotModified {
+		t.Errorf("Expected 304 for an unchanged dataset, got %d", repeatStatus)
+	}
+	if weakStatus != fiber.StatusNotModified {
+		t.Errorf("Expected 304 for a weak ETag match, got %d", weakStatus)
+	}
+	if changedStatus != fiber.StatusOK {
+		t.Errorf("Expected 200 for a changed dataset, got %d", changedStatus)
+	}
+	if changedETag == firstETag {
+		t.Errorf("Expected a new ETag for a changed dataset, got %s again", changedETag)
+	}
+}
@@ -1,20 +1,42 @@
 package handlers
 
 import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/export"
 	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
 	"makers.anchor/incident/internal/services"
+	"makers.anchor/incident/internal/tenant"
+)
+
+const (
+	// IdempotencyKeyHeader lets clients retry a create without creating a second incident
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayHeader marks a create answered with the incident of an earlier request
+	IdempotentReplayHeader = "Idempotent-Replayed"
 )
 
 // IncidentHandler handles HTTP requests for incidents
 type IncidentHandler struct {
-	service *services.IncidentService
+	service  *services.IncidentService
+	renderer export.Renderer
 }
 
 // NewIncidentHandler creates a new incident handler
-func NewIncidentHandler(service *services.IncidentService) *IncidentHandler {
+func NewIncidentHandler(service *services.IncidentService, renderer export.Renderer) *IncidentHandler {
 	return &IncidentHandler{
-		service: service,
+		service:  service,
+		renderer: renderer,
 	}
 }
 
@@ -23,10 +45,7 @@ func (h *IncidentHandler) CreateIncident(c *fiber.Ctx) error {
 	var req models.CreateIncidentRequest
 
 	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
-			"details": err.Error(),
-		})
+		return invalidBodyResponse(c, err)
 	}
 
 	// Basic validation
@@ -41,110 +60,449 @@ func (h *IncidentHandler) CreateIncident(c *fiber.Ctx) error {
 			"error": "Severity is required",
 		})
 	}
+	req.IdempotencyKey = strings.TrimSpace(c.Get(IdempotencyKeyHeader))
 
-	incident, err := h.service.CreateIncident(c.Context(), &req)
+	incident, err := h.service.CreateIncident(c.UserContext(), &req)
 	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if errors.Is(err, services.ErrTitleTooLong) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Title is too long",
+				"details": err.Error(),
+			})
+		}
+		var descriptionErr *services.DescriptionTooShortError
+		if errors.As(err, &descriptionErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":      "Description is too short",
+				"details":    err.Error(),
+				"min_length": descriptionErr.Minimum,
+			})
+		}
+		if errors.Is(err, services.ErrActivationNotInFuture) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid activation time",
+				"details": err.Error(),
+			})
+		}
+		var fieldErr *services.CustomFieldError
+		if errors.As(err, &fieldErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid custom field",
+				"details": err.Error(),
+				"field":   fieldErr.Key,
+			})
+		}
+		var duplicateErr *services.DuplicateIncidentError
+		if errors.As(err, &duplicateErr) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":                 "Duplicate incident",
+				"details":               err.Error(),
+				"existing_incident_key": duplicateErr.ExistingKey,
+			})
+		}
+		if errors.Is(err, repository.ErrDuplicateIncident) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Incident already exists",
+				"details": err.Error(),
+			})
+		}
+		var conflictErr *services.FieldConflictError
+		if errors.As(err, &conflictErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Conflicting fields",
+				"details": conflictErr.Message,
+				"rule":    conflictErr.Rule,
+				"fields":  conflictErr.Fields,
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to create incident",
 			"details": err.Error(),
 		})
 	}
 
+	if incident.IdempotentReplay {
+		c.Set(IdempotentReplayHeader, "true")
+		return c.JSON(fiber.Map{
+			"success": true,
+			"data":    incident,
+		})
+	}
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"success": true,
 		"data":    incident,
 	})
 }
 
+// listParamsFromQuery reads the incident list filters from the query string,
+// including any ?or= filter groups. Each ?or= parameter is a separate group.
+func listParamsFromQuery(c *fiber.Ctx) (models.ListIncidentsParams, error) {
+	params := searchFilterFromQuery(c).ListParams()
+
+	if params.Status != "" && !params.Status.IsValid() {
+		return params, services.InvalidStatus(params.Status)
+	}
+	if params.Severity != "" && !params.Severity.IsValid() {
+		return params, services.InvalidSeverity(params.Severity)
+	}
+	for _, noteType := range []models.NoteType{params.HasNoteType, params.MissingNoteType} {
+		if noteType != "" && !noteType.IsValid() {
+			return params, services.InvalidNoteType(noteType)
+		}
+	}
+	if params.SLAStatus != "" && !params.SLAStatus.IsValid() {
+		return params, services.InvalidSLAStatus(params.SLAStatus)
+	}
+
+	for _, expression := range c.Context().QueryArgs().PeekMulti("or") {
+		group, err := services.ParseFilterGroup(string(expression))
+		if err != nil {
+			return params, err
+		}
+		params.AnyOf = append(params.AnyOf, group)
+	}
+	return params, nil
+}
+
+// pageFromQuery reads ?page= and ?limit=, leaving unset values for the service to default
+func pageFromQuery(c *fiber.Ctx) (models.PageParams, error) {
+	var page models.PageParams
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"page", &page.Page}, {"limit", &page.PageSize}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return page, fmt.Errorf("%w: %s must be a number", services.ErrInvalidPage, param.name)
+		}
+		*param.target = number
+	}
+	return page, nil
+}
+
+// invalidPageResponse responds 400 for a page or limit out of range
+func invalidPageResponse(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":   "Invalid pagination",
+		"details": err.Error(),
+	})
+}
+
+// listParamsErrorResponse responds 400 for list filters that failed to parse
+func listParamsErrorResponse(c *fiber.Ctx, err error) error {
+	var invalidErr *services.InvalidValueError
+	if errors.As(err, &invalidErr) {
+		return invalidValueResponse(c, invalidErr)
+	}
+	return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+		"error":   "Invalid filter",
+		"details": err.Error(),
+	})
+}
+
+// searchFilterFromQuery reads the incident list filters from the query string
+func searchFilterFromQuery(c *fiber.Ctx) models.SearchFilter {
+	filter := models.SearchFilter{
+		Status:          models.IncidentStatus(c.Query("status")),
+		Severity:        models.IncidentSeverity(c.Query("severity")),
+		Assignee:        c.Query("assignee"),
+		HasNoteType:     models.NoteType(c.Query("has_note_type")),
+		MissingNoteType: models.NoteType(c.Query("missing_note_type")),
+		NeedsAttention:  c.QueryBool("needs_attention"),
+		Drafts:          c.QueryBool("drafts"),
+		Scheduled:       c.QueryBool("scheduled"),
+		SLA:             models.SLAStatus(c.Query("sla")),
+	}
+
+	for key, value := range c.Queries() {
+		if field, ok := strings.CutPrefix(key, "custom."); ok {
+			if filter.CustomFields == nil {
+				filter.CustomFields = make(map[string]string)
+			}
+			filter.CustomFields[field] = value
+		}
+	}
+	return filter
+}
+
 // GetAllIncidents handles GET /incidents
 func (h *IncidentHandler) GetAllIncidents(c *fiber.Ctx) error {
-	incidents, err := h.service.GetAllIncidents(c.Context())
+	params, err := listParamsFromQuery(c)
+	if err != nil {
+		return listParamsErrorResponse(c, err)
+	}
+	params.Page, err = pageFromQuery(c)
+	if err != nil {
+		return invalidPageResponse(c, err)
+	}
+
+	loc, err := timeZoneFromQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid time zone",
+			"details": err.Error(),
+		})
+	}
+
+	page, err := h.service.GetAllIncidents(c.UserContext(), params)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidPage) {
+			return invalidPageResponse(c, err)
+		}
+		var fieldErr *services.CustomFieldError
+		if errors.As(err, &fieldErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid custom field filter",
+				"details": err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Failed to retrieve incidents",
 			"details": err.Error(),
 		})
 	}
 
+	return sendJSONWithETag(c, fiber.Map{
+		"success":    true,
+		"data":       incidentsInLocation(page.Items, loc),
+		"pagination": page.Pagination,
+	})
+}
+
+// SearchIncidents handles GET /incidents/search
+func (h *IncidentHandler) SearchIncidents(c *fiber.Ctx) error {
+	var fields []models.SearchField
+	for _, field := range strings.Split(c.Query("in"), ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, models.SearchField(field))
+		}
+	}
+
+	results, err := h.service.SearchIncidents(c.UserContext(), c.Query("q"), fields)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptySearchQuery) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Search query is required",
+			})
+		}
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to search incidents",
+			"details": err.Error(),
+		})
+	}
+
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    incidents,
+		"data":    results,
 	})
 }
 
-// GetIncidentByID handles GET /incidents/:id
-func (h *IncidentHandler) GetIncidentByID(c *fiber.Ctx) error {
-	id := c.Params("id")
-	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Incident ID is required",
-		})
+// GetIncidentFacets handles GET /incidents/facets
+func (h *IncidentHandler) GetIncidentFacets(c *fiber.Ctx) error {
+	params, err := listParamsFromQuery(c)
+	if err != nil {
+		return listParamsErrorResponse(c, err)
 	}
 
-	incident, err := h.service.GetByID(c.Context(), id)
+	facets, err := h.service.GetIncidentFacets(c.UserContext(), params)
 	if err != nil {
-		// Check if it's a "not found" error
-		if err.Error() == "incident not found" || err.Error() == "no documents found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Incident not found",
+		var fieldErr *services.CustomFieldError
+		if errors.As(err, &fieldErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid custom field filter",
+				"details": err.Error(),
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to retrieve incident",
+			"error":   "Failed to retrieve incident facets",
 			"details": err.Error(),
 		})
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    incident,
+		"data":    facets,
 	})
 }
 
-// UpdateIncidentStatus handles PUT /incidents/:id/status
-func (h *IncidentHandler) UpdateIncidentStatus(c *fiber.Ctx) error {
-	id := c.Params("id")
-	if id == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Incident ID is required",
+// GetIncidentStats handles GET /incidents/stats
+func (h *IncidentHandler) GetIncidentStats(c *fiber.Ctx) error {
+	stats, err := h.service.GetIncidentStats(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve incident stats",
+			"details": err.Error(),
 		})
 	}
 
-	var req models.UpdateIncidentStatusRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+// GetAssigneeStats handles GET /incidents/stats/by-assignee
+func (h *IncidentHandler) GetAssigneeStats(c *fiber.Ctx) error {
+	var page models.PageParams
+	for _, param := range []struct {
+		name   string
+		target *int
+	}{{"page", &page.Page}, {"page_size", &page.PageSize}} {
+		value := c.Query(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid " + param.name,
+				"details": err.Error(),
+			})
+		}
+		*param.target = number
+	}
+
+	stats, err := h.service.GetAssigneeStats(c.UserContext(), page)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidPage) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid pagination",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve assignee stats",
 			"details": err.Error(),
 		})
 	}
 
-	if req.Status == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Status is required",
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    stats,
+	})
+}
+
+// GetIncidentLoad handles GET /incidents/load
+func (h *IncidentHandler) GetIncidentLoad(c *fiber.Ctx) error {
+	load, err := h.service.GetIncidentLoad(c.UserContext())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve incident load",
+			"details": err.Error(),
 		})
 	}
 
-	incident, err := h.service.UpdateIncidentStatus(c.Context(), id, &req)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    load,
+	})
+}
+
+// GetSeverityTrend handles GET /incidents/metrics/trend
+func (h *IncidentHandler) GetSeverityTrend(c *fiber.Ctx) error {
+	params := models.TrendParams{
+		Interval: models.TrendInterval(c.Query("interval")),
+	}
+
+	for _, bound := range []struct {
+		name   string
+		target *time.Time
+	}{{"from", &params.From}, {"to", &params.To}} {
+		value := c.Query(bound.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   fmt.Sprintf("Invalid %s date, expected RFC3339", bound.name),
+				"details": err.Error(),
+			})
+		}
+		*bound.target = parsed
+	}
+
+	trend, err := h.service.GetSeverityTrend(c.UserContext(), params)
 	if err != nil {
-		if err.Error() == "incident not found" {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Incident not found",
+		if errors.Is(err, services.ErrInvalidTrendParams) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid trend parameters",
+				"details": err.Error(),
 			})
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Failed to update incident status",
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve incident trend",
 			"details": err.Error(),
 		})
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"data":    incident,
+		"data":    trend,
 	})
 }
 
-// UpdateIncidentSeverity handles PUT /incidents/:id/severity
-func (h *IncidentHandler) UpdateIncidentSeverity(c *fiber.Ctx) error {
+// StreamIncidentEvents handles GET /incidents/stream as a server-sent event stream
+func (h *IncidentHandler) StreamIncidentEvents(c *fiber.Ctx) error {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+
+	// Only the caller's tenant's events are streamed
+	tenantID := tenant.FromContext(c.UserContext())
+	sub := h.service.SubscribeToEvents()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer h.service.UnsubscribeFromEvents(sub)
+
+		heartbeat := time.NewTicker(15 * time.Second)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case event, ok := <-sub.Events():
+				if !ok {
+					// Dropped by the bus for falling behind
+					return
+				}
+				if !services.EventVisibleToTenant(event, tenantID) {
+					continue
+				}
+				payload, err := event.GetPayload()
+				if err != nil {
+					log.Printf("Error encoding %s event for stream: %v", event.GetEventType(), err)
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.GetEventType(), payload)
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+			}
+
+			if err := w.Flush(); err != nil {
+				// Client disconnected
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// GetIncidentByID handles GET /incidents/:id
+func (h *IncidentHandler) GetIncidentByID(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -152,41 +510,86 @@ func (h *IncidentHandler) UpdateIncidentSeverity(c *fiber.Ctx) error {
 		})
 	}
 
-	var req models.UpdateIncidentSeverityRequest
-	if err := c.BodyParser(&req); err != nil {
+	loc, err := timeZoneFromQuery(c)
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
+			"error":   "Invalid time zone",
 			"details": err.Error(),
 		})
 	}
 
-	if req.Severity == "" {
+	incident, err := h.service.GetByID(c.UserContext(), id)
+	if err != nil {
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve incident",
+			"details": err.Error(),
+		})
+	}
+
+	return sendJSONWithETag(c, fiber.Map{
+		"success": true,
+		"data":    incident.InLocation(loc),
+	})
+}
+
+// GetIncidentByKey handles GET /incidents/key/:key
+func (h *IncidentHandler) GetIncidentByKey(c *fiber.Ctx) error {
+	key := c.Params("key")
+	return h.sendIncident(c, func(ctx context.Context) (*models.Incident, error) {
+		return h.service.GetByIncidentKey(ctx, key)
+	})
+}
+
+// GetIncidentByObjectID handles GET /incidents/id/:objectId
+func (h *IncidentHandler) GetIncidentByObjectID(c *fiber.Ctx) error {
+	objectID := c.Params("objectId")
+	return h.sendIncident(c, func(ctx context.Context) (*models.Incident, error) {
+		return h.service.GetByObjectID(ctx, objectID)
+	})
+}
+
+// sendIncident responds with the incident returned by lookup, in the ?tz= time zone
+func (h *IncidentHandler) sendIncident(c *fiber.Ctx, lookup func(ctx context.Context) (*models.Incident, error)) error {
+	loc, err := timeZoneFromQuery(c)
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Severity is required",
+			"error":   "Invalid time zone",
+			"details": err.Error(),
 		})
 	}
 
-	incident, err := h.service.UpdateIncidentSeverity(c.Context(), id, &req)
+	incident, err := lookup(c.UserContext())
 	if err != nil {
-		if err.Error() == "incident not found" {
+		if errors.Is(err, repository.ErrInvalidIncidentID) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid incident identifier",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Incident not found",
 			})
 		}
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Failed to update incident severity",
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve incident",
 			"details": err.Error(),
 		})
 	}
 
-	return c.JSON(fiber.Map{
+	return sendJSONWithETag(c, fiber.Map{
 		"success": true,
-		"data":    incident,
+		"data":    incident.InLocation(loc),
 	})
 }
 
-// AddNoteToIncident handles POST /incidents/:id/notes
-func (h *IncidentHandler) AddNoteToIncident(c *fiber.Ctx) error {
+// GetRelatedIncidents handles GET /incidents/:id/related
+func (h *IncidentHandler) GetRelatedIncidents(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -194,41 +597,61 @@ func (h *IncidentHandler) AddNoteToIncident(c *fiber.Ctx) error {
 		})
 	}
 
-	var req models.AddNoteRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
+	related, err := h.service.GetRelatedIncidents(c.UserContext(), id)
+	if err != nil {
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve related incidents",
 			"details": err.Error(),
 		})
 	}
 
-	if req.Content == "" {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    related,
+	})
+}
+
+// PublishIncident handles POST /incidents/:id/publish
+func (h *IncidentHandler) PublishIncident(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Note content is required",
+			"error": "Incident ID is required",
 		})
 	}
 
-	incident, err := h.service.AddNoteToIncident(c.Context(), id, &req)
+	incident, err := h.service.PublishIncident(c.UserContext(), id)
 	if err != nil {
-		if err.Error() == "incident not found" {
+		if errors.Is(err, repository.ErrNotDraft) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Incident is not a draft",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Incident not found",
 			})
 		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error":   "Failed to add note to incident",
+			"error":   "Failed to publish incident",
 			"details": err.Error(),
 		})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    incident,
 	})
 }
 
-// AddWatcherToIncident
-func (h *IncidentHandler) AddWatcherToIncident(c *fiber.Ctx) error {
+// GetNextResponder handles GET /incidents/:id/next-responder
+func (h *IncidentHandler) GetNextResponder(c *fiber.Ctx) error {
 	id := c.Params("id")
 	if id == "" {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -236,17 +659,715 @@ func (h *IncidentHandler) AddWatcherToIncident(c *fiber.Ctx) error {
 		})
 	}
 
-	var req models.Watcher
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error":   "Invalid request body",
+	responder, err := h.service.GetNextResponder(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrNoEscalationChain) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "No escalation chain configured",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to find next responder",
 			"details": err.Error(),
 		})
 	}
 
-	incident, err := h.service.AddWatcherToIncident(c.Context(), id, &req)
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    responder,
+	})
+}
+
+// ExportIncident handles GET /incidents/:id/export
+func (h *IncidentHandler) ExportIncident(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	incident, err := h.service.GetByID(c.UserContext(), id)
+	if err != nil {
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve incident",
+			"details": err.Error(),
+		})
+	}
+
+	report, err := h.renderer.Render(incident)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to export incident",
+			"details": err.Error(),
+		})
+	}
+
+	c.Attachment(fmt.Sprintf("incident-%s.%s", models.FormatIncidentKey(incident.IncidentKey), h.renderer.FileExtension()))
+	c.Set(fiber.HeaderContentType, h.renderer.ContentType())
+	return c.Send(report)
+}
+
+// UpdateIncidentStatus handles PUT /incidents/:id/status
+func (h *IncidentHandler) UpdateIncidentStatus(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.UpdateIncidentStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Status == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Status is required",
+		})
+	}
+
+	incident, changed, err := h.service.UpdateIncidentStatus(c.UserContext(), id, &req)
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		var noteRequiredErr *services.TransitionNoteRequiredError
+		if errors.As(err, &noteRequiredErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":     "Note required for this status change",
+				"details":   err.Error(),
+				"note_type": noteRequiredErr.Type,
+			})
+		}
+		var sectionsErr *services.MissingSectionsError
+		if errors.As(err, &sectionsErr) {
+			return missingSectionsResponse(c, sectionsErr)
+		}
+		if errors.Is(err, services.ErrNoteLimitReached) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Note limit reached",
+				"details": err.Error(),
+			})
+		}
+		var tooSoonErr *services.TooSoonError
+		if errors.As(err, &tooSoonErr) {
+			remaining := int(math.Ceil(tooSoonErr.Remaining.Seconds()))
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(remaining))
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":             "Status changed too recently",
+				"details":           err.Error(),
+				"remaining_seconds": remaining,
+			})
+		}
+		if errors.Is(err, services.ErrAssigneeRequired) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Assignee required",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrInProgressRequired) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Incident must be in progress",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrPostmortemRequired) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Postmortem required",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to update incident status",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+		"changed": changed,
+	})
+}
+
+// ReassignIncidents handles POST /incidents/bulk/assignee
+func (h *IncidentHandler) ReassignIncidents(c *fiber.Ctx) error {
+	var req models.BulkReassignRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	result, err := h.service.ReassignIncidents(c.UserContext(), &req)
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if errors.Is(err, services.ErrInvalidBulkSelection) || errors.Is(err, services.ErrInvalidAssignee) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid bulk reassignment",
+				"details": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to reassign incidents",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// PreviewStatusChange handles POST /incidents/:id/status/preview
+func (h *IncidentHandler) PreviewStatusChange(c *fiber.Ctx) error {
+	var req models.UpdateIncidentStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Status == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Status is required",
+		})
+	}
+
+	preview, err := h.service.PreviewStatusChange(c.UserContext(), c.Params("id"), &req)
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to preview status change",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    preview,
+	})
+}
+
+// UpdateIncident handles PUT /incidents/:id
+func (h *IncidentHandler) UpdateIncident(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.UpdateIncidentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	incident, err := h.service.UpdateIncident(c.UserContext(), id, &req)
+	if err != nil {
+		var descriptionErr *services.DescriptionTooShortError
+		if errors.As(err, &descriptionErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":      "Description is too short",
+				"details":    err.Error(),
+				"min_length": descriptionErr.Minimum,
+			})
+		}
+		switch {
+		case errors.Is(err, services.ErrNoIncidentChanges),
+			errors.Is(err, services.ErrInvalidTitleLength),
+			errors.Is(err, services.ErrTitleTooLong),
+			errors.Is(err, services.ErrInvalidAssignee):
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid incident update",
+				"details": err.Error(),
+			})
+		case incidentNotFound(err):
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to update incident",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
+
+// UpdateIncidentSeverity handles PUT /incidents/:id/severity
+func (h *IncidentHandler) UpdateIncidentSeverity(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.UpdateIncidentSeverityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Severity == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Severity is required",
+		})
+	}
+
+	incident, changed, err := h.service.UpdateIncidentSeverity(c.UserContext(), id, &req)
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		var cooldownErr *services.SeverityCooldownError
+		if errors.As(err, &cooldownErr) {
+			remaining := int(math.Ceil(cooldownErr.Remaining.Seconds()))
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(remaining))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":             "Severity changed too recently",
+				"details":           err.Error(),
+				"remaining_seconds": remaining,
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to update incident severity",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+		"changed": changed,
+	})
+}
+
+// UpdateIncidentPriority handles PUT /incidents/:id/priority
+func (h *IncidentHandler) UpdateIncidentPriority(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.UpdateIncidentPriorityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Priority == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Priority is required",
+		})
+	}
+
+	incident, changed, err := h.service.UpdateIncidentPriority(c.UserContext(), id, &req)
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to update incident priority",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+		"changed": changed,
+	})
+}
+
+// AddNoteToIncident handles POST /incidents/:id/notes
+func (h *IncidentHandler) AddNoteToIncident(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.AddNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Content == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Note content is required",
+		})
+	}
+
+	incident, err := h.service.AddNoteToIncident(c.UserContext(), id, &req)
+	if err != nil {
+		var sectionsErr *services.MissingSectionsError
+		if errors.As(err, &sectionsErr) {
+			return missingSectionsResponse(c, sectionsErr)
+		}
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if errors.Is(err, services.ErrNoteAuthorRequired) || errors.Is(err, services.ErrInvalidNoteAuthor) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid note author",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrNoteLimitReached) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Note limit reached",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to add note to incident",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
+
+// ImportNotes handles POST /incidents/:id/notes/bulk
+func (h *IncidentHandler) ImportNotes(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var reqs []models.ImportNoteRequest
+	if err := c.BodyParser(&reqs); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	incident, err := h.service.ImportNotes(c.UserContext(), id, reqs)
+	if err != nil {
+		var importErr *services.NoteImportError
+		if errors.As(err, &importErr) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid note",
+				"details": err.Error(),
+				"index":   importErr.Index,
+			})
+		}
+		if errors.Is(err, services.ErrNoNotesToImport) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "No notes to import",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrNoteLimitReached) {
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"error":   "Note limit reached",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to import notes",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
+
+// EditNote handles PUT /incidents/:id/notes/:noteId
+func (h *IncidentHandler) EditNote(c *fiber.Ctx) error {
+	id := c.Params("id")
+	noteID := c.Params("noteId")
+
+	var req models.EditNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Content == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Note content is required",
+		})
+	}
+
+	incident, err := h.service.EditNote(c.UserContext(), id, noteID, &req)
+	if err != nil {
+		var sectionsErr *services.MissingSectionsError
+		if errors.As(err, &sectionsErr) {
+			return missingSectionsResponse(c, sectionsErr)
+		}
+		if errors.Is(err, services.ErrNoteAuthorRequired) || errors.Is(err, services.ErrInvalidNoteAuthor) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid note author",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrNoteNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Note not found",
+			})
+		}
+		if errors.Is(err, repository.ErrNoteConflict) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Note was modified concurrently",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to edit note",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
+
+// GetNoteTemplates handles GET /note-templates
+func (h *IncidentHandler) GetNoteTemplates(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    h.service.NoteTemplates(),
+	})
+}
+
+// ListNotes handles GET /incidents/:id/notes
+func (h *IncidentHandler) ListNotes(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	notes, err := h.service.ListNotes(c.UserContext(), id, models.NoteVisibility(c.Query("visibility")))
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve notes",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    notes,
+	})
+}
+
+// SavePostmortem handles POST /incidents/:id/postmortem
+func (h *IncidentHandler) SavePostmortem(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.PostmortemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	postmortem, err := h.service.SavePostmortem(c.UserContext(), id, &req)
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if errors.Is(err, services.ErrPostmortemIncomplete) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Postmortem is incomplete",
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrPostmortemNotResolved) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Incident is not resolved",
+				"details": err.Error(),
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to save postmortem",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    postmortem,
+	})
+}
+
+// GetPostmortem handles GET /incidents/:id/postmortem
+func (h *IncidentHandler) GetPostmortem(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	postmortem, err := h.service.GetPostmortem(c.UserContext(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrPostmortemNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Postmortem not found",
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve postmortem",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    postmortem,
+	})
+}
+
+// SearchNotes handles GET /incidents/:id/notes/search
+func (h *IncidentHandler) SearchNotes(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	notes, err := h.service.SearchNotes(c.UserContext(), id, c.Query("q"), models.NoteVisibility(c.Query("visibility")))
+	if err != nil {
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		if errors.Is(err, services.ErrEmptyNoteQuery) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Search query is required",
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to search notes",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    notes,
+	})
+}
+
+// AddWatcherToIncident
+func (h *IncidentHandler) AddWatcherToIncident(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Incident ID is required",
+		})
+	}
+
+	var req models.Watcher
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	incident, err := h.service.AddWatcherToIncident(c.UserContext(), id, &req)
 	if err != nil {
-		if err.Error() == "incident not found" {
+		if incidentNotFound(err) {
 			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
 				"error": "Incident not found",
 			})
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/export"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+func TestCreateIncident_InvalidSeverityListsAllowedValues(t *testing.T) {
+	// Arrange
+	service := services.NewIncidentService(nil, nil, nil, nil, &config.Config{})
+	handler := NewIncidentHandler(service, export.MarkdownRenderer{})
+	app := fiber.New()
+	app.Post("/incidents", handler.CreateIncident)
+
+	req := httptest.NewRequest("POST", "/incidents", strings.NewReader(`{"title":"Checkout down","severity":"sev1"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	var payload struct {
+		Field         string   `json:"field"`
+		AllowedValues []string `json:"allowed_values"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("Expected JSON body, got %v", err)
+	}
+	if payload.Field != "severity" {
+		t.Errorf("Expected field severity, got %q", payload.Field)
+	}
+	if strings.Join(payload.AllowedValues, ",") != "low,medium,high,critical" {
+		t.Errorf("Expected allowed values low,medium,high,critical, got %v", payload.AllowedValues)
+	}
+}
+
+func TestCreateIncident_ShortCriticalDescription(t *testing.T) {
+	// Arrange
+	service := services.NewIncidentService(nil, nil, nil, nil, &config.Config{MinDescriptionLengths: map[string]int{"critical": 50}})
+	handler := NewIncidentHandler(service, export.MarkdownRenderer{})
+	app := fiber.New()
+	app.Post("/incidents", handler.CreateIncident)
+
+	req := httptest.NewRequest("POST", "/incidents", strings.NewReader(`{"title":"Checkout down","severity":"critical","description":"Down"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	var payload struct {
+		MinLength int `json:"min_length"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("Expected JSON body, got %v", err)
+	}
+	if payload.MinLength != 50 {
+		t.Errorf("Expected min_length 50, got %d", payload.MinLength)
+	}
+}
+
+func TestGetAllIncidents_InvalidNoteTypeListsAllowedValues(t *testing.T) {
+	// Arrange
+	handler := NewIncidentHandler(nil, export.MarkdownRenderer{})
+	app := fiber.New()
+	app.Get("/incidents", handler.GetAllIncidents)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/incidents?has_note_type=postmortem", nil))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != fiber.StatusBadRequest {
+		t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+	}
+
+	var payload struct {
+		AllowedValues []string `json:"allowed_values"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("Expected JSON body, got %v", err)
+	}
+	if strings.Join(payload.AllowedValues, ",") != "update,investigation,resolution,communication" {
+		t.Errorf("Expected the note types, got %v", payload.AllowedValues)
+	}
+}
+
+func TestGetAllIncidents_InvalidOrFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedField string
+	}{
+		{name: "empty group", query: "or="},
+		{name: "term without value", query: "or=status:open,severity"},
+		{name: "empty term", query: "or=status:open,,severity:critical"},
+		{name: "term without field", query: "or=:open"},
+		{name: "unknown field", query: "or=priority:p1", expectedField: "filter field"},
+		{name: "invalid status", query: "or=status:pending,severity:critical", expectedField: "status"},
+		{name: "any malformed group", query: "or=status:open&or=severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := NewIncidentHandler(nil, export.MarkdownRenderer{})
+			app := fiber.New()
+			app.Get("/incidents", handler.GetAllIncidents)
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest("GET", "/incidents?"+tt.query, nil))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+			}
+			var payload struct {
+				Field string `json:"field"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if payload.Field != tt.expectedField {
+				t.Errorf("Expected field %q, got %q", tt.expectedField, payload.Field)
+			}
+		})
+	}
+}
+
+func TestGetAllIncidents_InvalidPagination(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		expectedError string
+	}{
+		{name: "non-numeric limit", query: "limit=ten", expectedError: "Invalid pagination"},
+		{name: "negative page", query: "page=-1", expectedError: "Invalid pagination"},
+		{name: "negative limit", query: "limit=-5", expectedError: "Invalid pagination"},
+		{name: "unknown status", query: "status=pending", expectedError: "Invalid status"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := services.NewIncidentService(nil, nil, nil, nil, &config.Config{})
+			handler := NewIncidentHandler(service, export.MarkdownRenderer{})
+			app := fiber.New()
+			app.Get("/incidents", handler.GetAllIncidents)
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest("GET", "/incidents?"+tt.query, nil))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+			}
+			var payload struct {
+				Error string `json:"error"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if payload.Error != tt.expectedError {
+				t.Errorf("Expected error %q, got %q", tt.expectedError, payload.Error)
+			}
+		})
+	}
+}
+
+func TestCreateIncident_InvalidBodyDetails(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectField    string
+		expectExpected string
+		expectDetails  string
+	}{
+		{
+			name:          "malformed JSON",
+			body:          `{"title":"Checkout down",}`,
+			expectDetails: "malformed JSON at offset 26",
+		},
+		{
+			name:           "type mismatch",
+			body:           `{"title":"Checkout down","severity":3}`,
+			expectField:    "severity",
+			expectExpected: "a string",
+			expectDetails:  "field severity must be a string, got number",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := NewIncidentHandler(nil, export.MarkdownRenderer{})
+			app := fiber.New()
+			app.Post("/incidents", handler.CreateIncident)
+
+			req := httptest.NewRequest("POST", "/incidents", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+			}
+
+			var payload struct {
+				Details  string `json:"details"`
+				Field    string `json:"field"`
+				Expected string `json:"expected"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if !strings.HasPrefix(payload.Details, tt.expectDetails) {
+				t.Errorf("Expected details starting %q, got %q", tt.expectDetails, payload.Details)
+			}
+			if payload.Field != tt.expectField {
+				t.Errorf("Expected field %q, got %q", tt.expectField, payload.Field)
+			}
+			if payload.Expected != tt.expectExpected {
+				t.Errorf("Expected expected type %q, got %q", tt.expectExpected, payload.Expected)
+			}
+		})
+	}
+}
+
+func TestCreateIncident_UnknownFieldStrictness(t *testing.T) {
+	tests := []struct {
+		name          string
+		decoder       func(data []byte, v interface{}) error
+		expectError   string
+		expectField   string
+		expectDetails string
+	}{
+		{
+			name:          "strict rejects the typo",
+			decoder:       StrictJSONDecoder,
+			expectError:   "Invalid request body",
+			expectField:   "severty",
+			expectDetails: "unknown field severty",
+		},
+		{
+			name:        "lenient ignores the typo",
+			decoder:     json.Unmarshal,
+			expectError: "Severity is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			handler := NewIncidentHandler(nil, export.MarkdownRenderer{})
+			app := fiber.New(fiber.Config{JSONDecoder: tt.decoder})
+			app.Post("/incidents", handler.CreateIncident)
+
+			req := httptest.NewRequest("POST", "/incidents", strings.NewReader(`{"title":"Checkout down","severty":"high"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d", fiber.StatusBadRequest, resp.StatusCode)
+			}
+
+			var payload struct {
+				Error   string `json:"error"`
+				Details string `json:"details"`
+				Field   string `json:"field"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+			if payload.Error != tt.expectError || payload.Field != tt.expectField || payload.Details != tt.expectDetails {
+				t.Errorf("Expected %q on field %q (%q), got %+v", tt.expectError, tt.expectField, tt.expectDetails, payload)
+			}
+		})
+	}
+}
+
+func TestGetIncident_MissingIncidentIsNotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+	}{
+		{name: "unknown key by ID", path: "/incidents/4242", expectedStatus: fiber.StatusNotFound},
+		{name: "unknown key", path: "/incidents/key/4242", expectedStatus: fiber.StatusNotFound},
+		{name: "unknown object ID", path: "/incidents/id/65a1f0c2e4b0a1b2c3d4e5f6", expectedStatus: fiber.StatusNotFound},
+		{name: "malformed key", path: "/incidents/key/abc", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			service := services.NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, nil, nil, &config.Config{})
+			handler := NewIncidentHandler(service, export.MarkdownRenderer{})
+			app := fiber.New()
+			app.Get("/incidents/key/:key", handler.GetIncidentByKey)
+			app.Get("/incidents/id/:objectId", handler.GetIncidentByObjectID)
+			app.Get("/incidents/:id", handler.GetIncidentByID)
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest("GET", tt.path, nil))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+type JiraWebhookHandler struct {
+	service *services.IncidentService
+}
+
+func NewJiraWebhookHandler(service *services.IncidentService) *JiraWebhookHandler {
+	return &JiraWebhookHandler{
+		service: service,
+	}
+}
+
+// SyncIssue handles POST /webhooks/jira
+func (h *JiraWebhookHandler) SyncIssue(c *fiber.Ctx) error {
+	var event models.JiraIssueEvent
+	if err := c.BodyParser(&event); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	incident, created, err := h.service.SyncJiraIssue(c.UserContext(), &event)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedJiraEvent) {
+			return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+				"success": true,
+				"ignored": true,
+				"details": err.Error(),
+			})
+		}
+		if errors.Is(err, services.ErrMissingJiraKey) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Jira issue key is required",
+			})
+		}
+		if errors.Is(err, repository.ErrDuplicateIncident) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error":   "Incident for this Jira issue already exists",
+				"details": err.Error(),
+			})
+		}
+		var invalidErr *services.InvalidValueError
+		if errors.As(err, &invalidErr) {
+			return invalidValueResponse(c, invalidErr)
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to sync Jira issue",
+			"details": err.Error(),
+		})
+	}
+
+	status := fiber.StatusOK
+	if created {
+		status = fiber.StatusCreated
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+func TestSyncIssue_RacingCreateIsConflict(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("another sync inserted the issue first", func(mt *mtest.T) {
+		// Arrange
+		service := services.NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, nil, nil, &config.Config{})
+		handler := NewJiraWebhookHandler(service)
+		app := fiber.New()
+		app.Post("/webhooks/jira", handler.SyncIssue)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "E11000 duplicate key error collection: db.incidents index: incident_external_id"}),
+		)
+		body := `{"webhookEvent":"jira:issue_created","issue":{"key":"OPS-12","fields":{"summary":"Payments failing in eu-west"}}}`
+		req := httptest.NewRequest("POST", "/webhooks/jira", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		// Act
+		resp, err := app.Test(req)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected status %d, got %d", fiber.StatusConflict, resp.StatusCode)
+		}
+	})
+}
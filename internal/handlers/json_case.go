@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// dataKeyedFields hold objects keyed by data, such as custom field names and
+// severities, whose keys are returned as stored
+var dataKeyedFields = map[string]bool{
+	"custom_fields": true,
+	"by_severity":   true,
+	"weights":       true,
+}
+
+// CamelCaseJSONEncoder encodes responses like json.Marshal, then renames every
+// object key from snake_case to camelCase, e.g. incident_key to incidentKey. Set
+// it as the app's fiber.Config.JSONEncoder to apply it to every response.
+func CamelCaseJSONEncoder(v interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(camelCaseKeys(value))
+}
+
+// camelCaseKeys renames the object keys within a decoded JSON value
+func camelCaseKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		renamed := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			if dataKeyedFields[key] {
+				renamed[camelCase(key)] = child
+				continue
+			}
+			renamed[camelCase(key)] = camelCaseKeys(child)
+		}
+		return renamed
+	case []interface{}:
+		for i := range v {
+			v[i] = camelCaseKeys(v[i])
+		}
+		return v
+	}
+	return value
+}
+
+// camelCase converts a snake_case key to camelCase
+func camelCase(key string) string {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/models"
+)
+
+func TestCamelCaseJSONEncoder_RenamesResponseFields(t *testing.T) {
+	// Arrange
+	app := fiber.New(fiber.Config{JSONEncoder: CamelCaseJSONEncoder})
+	app.Get("/incidents/:id", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"success": true, "data": models.Incident{
+			IncidentKey:  42,
+			Title:        "Checkout down",
+			CustomFields: map[string]interface{}{"jira_ticket": "OPS-12"},
+			WatchList:    []models.Watcher{{Email: "lead@example.com"}},
+		}})
+	})
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/incidents/42", nil))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	var decoded struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Expected a JSON body, got %s", body)
+	}
+	if string(decoded.Data["incidentKey"]) != "42" {
+		t.Errorf("Expected incidentKey 42, got %s", body)
+	}
+	if _, ok := decoded.Data["incident_key"]; ok {
+		t.Errorf("Expected no snake_case incident_key, got %s", body)
+	}
+	if string(decoded.Data["customFields"]) != `{"jira_ticket":"OPS-12"}` {
+		t.Errorf("Expected custom field keys unchanged, got %s", decoded.Data["customFields"])
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"incident_key":        "incidentKey",
+		"status_changed_at":   "statusChangedAt",
+		"id":                  "id",
+		"existing_incident_2": "existingIncident2",
+	}
+
+	for key, expected := range tests {
+		if got := camelCase(key); got != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, key, got)
+		}
+	}
+}
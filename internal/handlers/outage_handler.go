@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+// OutageHandler handles HTTP requests for outages
+type OutageHandler struct {
+	service *services.OutageService
+}
+
+// NewOutageHandler creates a new outage handler
+func NewOutageHandler(service *services.OutageService) *OutageHandler {
+	return &OutageHandler{
+		service: service,
+	}
+}
+
+// CreateOutage handles POST /outages
+func (h *OutageHandler) CreateOutage(c *fiber.Ctx) error {
+	var req models.CreateOutageRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	if req.Title == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Title is required",
+		})
+	}
+
+	outage, err := h.service.CreateOutage(c.UserContext(), &req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to create outage",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    outage,
+	})
+}
+
+// GetOutage handles GET /outages/:id
+func (h *OutageHandler) GetOutage(c *fiber.Ctx) error {
+	outage, err := h.service.GetOutage(c.UserContext(), c.Params("id"))
+	if err != nil {
+		if errors.Is(err, repository.ErrOutageNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Outage not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to retrieve outage",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    outage,
+	})
+}
+
+// AttachIncident handles POST /outages/:id/incidents/:incidentKey
+func (h *OutageHandler) AttachIncident(c *fiber.Ctx) error {
+	incident, err := h.service.AttachIncident(c.UserContext(), c.Params("id"), c.Params("incidentKey"))
+	if err != nil {
+		if errors.Is(err, repository.ErrOutageNotFound) || incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to attach incident to outage",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    incident,
+	})
+}
+
+// CloseOutage handles PUT /outages/:id/close
+func (h *OutageHandler) CloseOutage(c *fiber.Ctx) error {
+	var req models.CloseOutageRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return invalidBodyResponse(c, err)
+		}
+	}
+
+	outage, err := h.service.CloseOutage(c.UserContext(), c.Params("id"), &req)
+	if err != nil {
+		if errors.Is(err, repository.ErrOutageNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Outage not found",
+			})
+		}
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Failed to close outage",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    outage,
+	})
+}
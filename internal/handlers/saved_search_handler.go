@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+// SavedSearchHandler handles HTTP requests for saved searches
+type SavedSearchHandler struct {
+	service *services.SavedSearchService
+}
+
+// NewSavedSearchHandler creates a new saved search handler
+func NewSavedSearchHandler(service *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		service: service,
+	}
+}
+
+// savedSearchErrorResponse maps saved search errors to HTTP responses
+func savedSearchErrorResponse(c *fiber.Ctx, err error, action string) error {
+	var invalidErr *services.InvalidValueError
+	if errors.As(err, &invalidErr) {
+		return invalidValueResponse(c, invalidErr)
+	}
+
+	var fieldErr *services.CustomFieldError
+	if errors.As(err, &fieldErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid custom field filter",
+			"details": err.Error(),
+		})
+	}
+
+	var expressionErr *services.FilterExpressionError
+	if errors.As(err, &expressionErr) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid filter",
+			"details": err.Error(),
+		})
+	}
+
+	switch {
+	case errors.Is(err, services.ErrInvalidPage):
+		return invalidPageResponse(c, err)
+	case errors.Is(err, services.ErrSavedSearchNameRequired):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Name is required",
+		})
+	case errors.Is(err, repository.ErrSavedSearchNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Saved search not found",
+		})
+	case errors.Is(err, repository.ErrInvalidSavedSearchID):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid saved search ID",
+			"details": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   "Failed to " + action,
+		"details": err.Error(),
+	})
+}
+
+// CreateSavedSearch handles POST /saved-searches
+func (h *SavedSearchHandler) CreateSavedSearch(c *fiber.Ctx) error {
+	var req models.SavedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	search, err := h.service.CreateSavedSearch(c.UserContext(), &req)
+	if err != nil {
+		return savedSearchErrorResponse(c, err, "create saved search")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"success": true,
+		"data":    search,
+	})
+}
+
+// ListSavedSearches handles GET /saved-searches
+func (h *SavedSearchHandler) ListSavedSearches(c *fiber.Ctx) error {
+	searches, err := h.service.ListSavedSearches(c.UserContext())
+	if err != nil {
+		return savedSearchErrorResponse(c, err, "retrieve saved searches")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    searches,
+	})
+}
+
+// GetSavedSearch handles GET /saved-searches/:id
+func (h *SavedSearchHandler) GetSavedSearch(c *fiber.Ctx) error {
+	search, err := h.service.GetSavedSearch(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return savedSearchErrorResponse(c, err, "retrieve saved search")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    search,
+	})
+}
+
+// UpdateSavedSearch handles PUT /saved-searches/:id
+func (h *SavedSearchHandler) UpdateSavedSearch(c *fiber.Ctx) error {
+	var req models.SavedSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return invalidBodyResponse(c, err)
+	}
+
+	search, err := h.service.UpdateSavedSearch(c.UserContext(), c.Params("id"), &req)
+	if err != nil {
+		return savedSearchErrorResponse(c, err, "update saved search")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    search,
+	})
+}
+
+// DeleteSavedSearch handles DELETE /saved-searches/:id
+func (h *SavedSearchHandler) DeleteSavedSearch(c *fiber.Ctx) error {
+	if err := h.service.DeleteSavedSearch(c.UserContext(), c.Params("id")); err != nil {
+		return savedSearchErrorResponse(c, err, "delete saved search")
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+	})
+}
+
+// ListIncidents handles GET /saved-searches/:id/incidents
+func (h *SavedSearchHandler) ListIncidents(c *fiber.Ctx) error {
+	loc, err := timeZoneFromQuery(c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid time zone",
+			"details": err.Error(),
+		})
+	}
+
+	page, err := pageFromQuery(c)
+	if err != nil {
+		return invalidPageResponse(c, err)
+	}
+
+	incidents, err := h.service.ListIncidents(c.UserContext(), c.Params("id"), page)
+	if err != nil {
+		return savedSearchErrorResponse(c, err, "retrieve incidents")
+	}
+
+	return sendJSONWithETag(c, fiber.Map{
+		"success":    true,
+		"data":       incidentsInLocation(incidents.Items, loc),
+		"pagination": incidents.Pagination,
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+type StatusPageHandler struct {
+	service *services.IncidentService
+}
+
+func NewStatusPageHandler(service *services.IncidentService) *StatusPageHandler {
+	return &StatusPageHandler{
+		service: service,
+	}
+}
+
+// GetStatus handles GET /status/:key, the public read-only view of an incident.
+// Errors are kept generic so the page leaks nothing beyond the sanitized view.
+func (h *StatusPageHandler) GetStatus(c *fiber.Ctx) error {
+	status, err := h.service.GetPublicStatus(c.UserContext(), c.Params("key"))
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidIncidentID) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid incident key",
+			})
+		}
+		if incidentNotFound(err) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Incident not found",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to retrieve incident status",
+		})
+	}
+
+	return sendJSONWithETag(c, fiber.Map{
+		"success": true,
+		"data":    status,
+	})
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // Embed the zone database so ?tz= works on minimal images
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/models"
+)
+
+// timeZoneFromQuery parses the ?tz= IANA zone name, defaulting to UTC
+func timeZoneFromQuery(c *fiber.Ctx) (*time.Location, error) {
+	name := c.Query("tz")
+	if name == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// incidentsInLocation formats every incident's timestamps in loc
+func incidentsInLocation(incidents []models.IncidentSummary, loc *time.Location) []models.IncidentSummary {
+	localized := make([]models.IncidentSummary, len(incidents))
+	for i, incident := range incidents {
+		localized[i] = incident.InLocation(loc)
+	}
+	return localized
+}
@@ -1,27 +1,315 @@
 package kafka
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
 	"github.com/twmb/franz-go/pkg/kgo"
 )
 
+// DeliveryMode selects how ProduceMessage waits for the broker
+type DeliveryMode string
+
+const (
+	// DeliverySync waits for the broker ack up to the produce timeout
+	DeliverySync DeliveryMode = "sync"
+	// DeliveryAsync hands the record to the client and reports failures through a callback
+	DeliveryAsync DeliveryMode = "async"
+)
+
+// Acks selects how many replicas must persist a record before the broker acks it
+type Acks string
+
+const (
+	// AcksAll waits for every in-sync replica, the only setting idempotent writes allow
+	AcksAll Acks = "all"
+	// AcksLeader waits for the partition leader only
+	AcksLeader Acks = "leader"
+	// AcksNone does not wait for the broker at all
+	AcksNone Acks = "none"
+)
+
+// DefaultProduceTimeout is used when no produce timeout is configured
+const DefaultProduceTimeout = 5 * time.Second
+
+// DefaultLinger is how long a partial batch waits before being flushed when no linger is configured
+const DefaultLinger = 10 * time.Millisecond
+
+// ReplayHeader marks records re-emitted by an event replay, so consumers can tell them apart
+const ReplayHeader = "replayed"
+
+// ErrProduceTimeout is returned when the broker does not ack a synchronous produce in time
+var ErrProduceTimeout = errors.New("timed out waiting for kafka ack")
+
+// ProducerOptions configures message delivery
+type ProducerOptions struct {
+	Mode    DeliveryMode
+	Timeout time.Duration
+	// OnAsyncError is called when an asynchronous or batched produce fails; failures are logged when nil
+	OnAsyncError func(event KafkaEvent, err error)
+	// BatchSize buffers up to this many events into one produce call; 0 or 1 disables batching
+	BatchSize int
+	// Linger is how long a partial batch waits before it is flushed
+	Linger time.Duration
+	// Acks is the broker acknowledgement required per record; AcksAll when empty
+	Acks Acks
+	// DisableIdempotence turns off idempotent writes, which are only possible with AcksAll
+	DisableIdempotence bool
+}
+
+// client is the subset of the franz-go client used by the producer
+type client interface {
+	Produce(ctx context.Context, record *kgo.Record, promise func(*kgo.Record, error))
+	ProduceSync(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults
+	Flush(ctx context.Context) error
+	Close()
+}
+
+// pendingRecord is a buffered record and the event it was built from
+type pendingRecord struct {
+	event  KafkaEvent
+	record *kgo.Record
+}
+
 type Producer struct {
-	client *kgo.Client
+	client  client
+	options ProducerOptions
+
+	mu        sync.Mutex
+	pending   []pendingRecord
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
 }
 
-func NewProducer(brokerList []string) (*Producer, error) {
-	client, err := kgo.NewClient(
+func NewProducer(brokerList []string, options ProducerOptions) (*Producer, error) {
+	client, err := kgo.NewClient(clientOptions(brokerList, options)...)
+	if err != nil {
+		return nil, err
+	}
+	return newProducer(client, options), nil
+}
+
+// clientOptions builds the franz-go options for the brokers and delivery
+// guarantees. Idempotent writes are dropped for acks other than all, which the
+// client would otherwise refuse.
+func clientOptions(brokerList []string, options ProducerOptions) []kgo.Opt {
+	opts := []kgo.Opt{
 		kgo.SeedBrokers(brokerList...),
 		kgo.AllowAutoTopicCreation(),
-	)
+	}
+
+	switch options.Acks {
+	case AcksLeader:
+		opts = append(opts, kgo.RequiredAcks(kgo.LeaderAck()))
+	case AcksNone:
+		opts = append(opts, kgo.RequiredAcks(kgo.NoAck()))
+	default:
+		opts = append(opts, kgo.RequiredAcks(kgo.AllISRAcks()))
+	}
+
+	idempotent := !options.DisableIdempotence
+	if idempotent && options.Acks != "" && options.Acks != AcksAll {
+		log.Printf("Idempotent kafka writes need acks=all, disabling them for acks=%s", options.Acks)
+		idempotent = false
+	}
+	if !idempotent {
+		opts = append(opts, kgo.DisableIdempotentWrite())
+	}
+	return opts
+}
+
+// newProducer wraps a client, filling in defaults for unset options
+func newProducer(client client, options ProducerOptions) *Producer {
+	if options.Mode != DeliverySync {
+		options.Mode = DeliveryAsync
+	}
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultProduceTimeout
+	}
+	if options.OnAsyncError == nil {
+		options.OnAsyncError = func(event KafkaEvent, err error) {
+			log.Printf("Error delivering %s event: %v", event.GetEventType(), err)
+		}
+	}
+	p := &Producer{
+		client:  client,
+		options: options,
+	}
+	if options.BatchSize > 1 {
+		if p.options.Linger <= 0 {
+			p.options.Linger = DefaultLinger
+		}
+		p.stop = make(chan struct{})
+		p.done = make(chan struct{})
+		go p.runFlusher()
+	}
+	return p
+}
+
+// batching reports whether events are buffered into batches
+func (p *Producer) batching() bool {
+	return p.options.BatchSize > 1
+}
+
+// ProduceMessage produces the event. Nothing is produced once ctx is done, and a
+// sync produce stops waiting for the ack when ctx is cancelled or its deadline passes.
+func (p *Producer) ProduceMessage(ctx context.Context, event KafkaEvent) error {
+	record, err := newRecord(event)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return &Producer{
-		client: client,
-	}, nil
+	return p.produce(ctx, event, record)
 }
 
-func (p *Producer) ProduceMessage(event KafkaEvent) error {
+// ReplayMessage produces an event again, marked with the replay header
+func (p *Producer) ReplayMessage(ctx context.Context, event KafkaEvent) error {
+	record, err := newRecord(event)
+	if err != nil {
+		return err
+	}
+	record.Headers = append(record.Headers, kgo.RecordHeader{Key: ReplayHeader, Value: []byte("true")})
+	return p.produce(ctx, event, record)
+}
+
+// newRecord encodes the event into a record for its topic, keyed by the event's
+// partition key when it has one
+func newRecord(event KafkaEvent) (*kgo.Record, error) {
+	payload, err := event.GetPayload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.GetEventType(), err)
+	}
+
+	record := &kgo.Record{
+		Topic: event.GetTopic(),
+		Value: payload,
+	}
+	if partitioned, ok := event.(PartitionedEvent); ok && partitioned.GetPartitionKey() != "" {
+		record.Key = []byte(partitioned.GetPartitionKey())
+	}
+	return record, nil
+}
 
+// produce delivers the record according to the delivery mode and batching options.
+// Async and batched records outlive the caller, so only a sync produce is bound to ctx.
+func (p *Producer) produce(ctx context.Context, event KafkaEvent, record *kgo.Record) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("produce %s event aborted: %w", event.GetEventType(), err)
+	}
+
+	// Batched events are acked with the batch; failures go to OnAsyncError
+	if p.batching() {
+		p.enqueue(pendingRecord{event: event, record: record})
+		return nil
+	}
+
+	if p.options.Mode == DeliveryAsync {
+		p.client.Produce(context.Background(), record, func(_ *kgo.Record, err error) {
+			if err != nil {
+				p.options.OnAsyncError(event, err)
+			}
+		})
+		return nil
+	}
+
+	produceCtx, cancel := context.WithTimeout(ctx, p.options.Timeout)
+	defer cancel()
+
+	if err := p.client.ProduceSync(produceCtx, record).FirstErr(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("produce %s event aborted: %w", event.GetEventType(), ctx.Err())
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s", ErrProduceTimeout, p.options.Timeout)
+		}
+		return err
+	}
 	return nil
 }
+
+// enqueue buffers a record, producing the batch once it is full
+func (p *Producer) enqueue(pending pendingRecord) {
+	p.mu.Lock()
+	p.pending = append(p.pending, pending)
+	var full []pendingRecord
+	if len(p.pending) >= p.options.BatchSize {
+		full = p.pending
+		p.pending = nil
+	}
+	p.mu.Unlock()
+
+	if full != nil {
+		p.produceBatch(full)
+	}
+}
+
+// runFlusher flushes partial batches every linger interval until Close
+func (p *Producer) runFlusher() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.options.Linger)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.flush()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// flush produces whatever is buffered
+func (p *Producer) flush() {
+	p.mu.Lock()
+	batch := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	if len(batch) > 0 {
+		p.produceBatch(batch)
+	}
+}
+
+// produceBatch produces the records in one call, reporting each failed record
+func (p *Producer) produceBatch(batch []pendingRecord) {
+	records := make([]*kgo.Record, len(batch))
+	for i, pending := range batch {
+		records[i] = pending.record
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.options.Timeout)
+	defer cancel()
+
+	results := p.client.ProduceSync(ctx, records...)
+	for i, result := range results {
+		if result.Err != nil && i < len(batch) {
+			p.options.OnAsyncError(batch[i].event, result.Err)
+		}
+	}
+}
+
+// Close stops the background flusher, flushes any partial batch and waits until
+// ctx is done for buffered async records to be acked before closing the client.
+// Records still unacked then are failed by the client and reported through
+// OnAsyncError, and the flush error is returned.
+func (p *Producer) Close(ctx context.Context) error {
+	var err error
+	p.closeOnce.Do(func() {
+		if p.batching() {
+			close(p.stop)
+			<-p.done
+			p.flush()
+		}
+		if flushErr := p.client.Flush(ctx); flushErr != nil {
+			err = fmt.Errorf("failed to flush kafka records: %w", flushErr)
+		}
+		p.client.Close()
+	})
+	return err
+}
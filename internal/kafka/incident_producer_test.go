@@ -0,0 +1,436 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"makers.anchor/incident/internal/models"
+)
+
+type stubEvent struct{}
+
+func (stubEvent) GetTopic() string            { return "incidents" }
+func (stubEvent) GetEventType() string        { return "incident.created" }
+func (stubEvent) GetVersion() int             { return 1 }
+func (stubEvent) GetPayload() ([]byte, error) { return []byte(`{}`), nil }
+
+// keyedStubEvent is a stubEvent carrying a partition key
+type keyedStubEvent struct {
+	stubEvent
+	key string
+}
+
+func (e keyedStubEvent) GetPartitionKey() string { return e.key }
+
+// stubClient acks produces with ackErr after ackDelay, recording the size of each
+// sync produce. Like the franz-go client, async records not yet acked when it is
+// closed fail with ErrClientClosed.
+type stubClient struct {
+	ackErr   error
+	ackDelay time.Duration
+
+	mu        sync.Mutex
+	batches   []int
+	records   []*kgo.Record
+	delivered []*kgo.Record // Async records acked without error
+	closed    bool
+	inflight  sync.WaitGroup
+}
+
+func (s *stubClient) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *stubClient) producedBatches() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.batches...)
+}
+
+func (s *stubClient) Produce(ctx context.Context, record *kgo.Record, promise func(*kgo.Record, error)) {
+	s.inflight.Add(1)
+	go func() {
+		defer s.inflight.Done()
+		time.Sleep(s.ackDelay)
+
+		s.mu.Lock()
+		err := s.ackErr
+		if s.closed {
+			err = kgo.ErrClientClosed
+		} else if err == nil {
+			s.delivered = append(s.delivered, record)
+		}
+		s.mu.Unlock()
+		promise(record, err)
+	}()
+}
+
+func (s *stubClient) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *stubClient) deliveredRecords() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.delivered)
+}
+
+func (s *stubClient) ProduceSync(ctx context.Context, records ...*kgo.Record) kgo.ProduceResults {
+	s.mu.Lock()
+	s.batches = append(s.batches, len(records))
+	s.records = append(s.records, records...)
+	s.mu.Unlock()
+
+	var results kgo.ProduceResults
+	for _, record := range records {
+		select {
+		case <-time.After(s.ackDelay):
+			results = append(results, kgo.ProduceResult{Record: record, Err: s.ackErr})
+		case <-ctx.Done():
+			results = append(results, kgo.ProduceResult{Record: record, Err: ctx.Err()})
+		}
+	}
+	return results
+}
+
+func TestProduceMessage_SyncReturnsAckError(t *testing.T) {
+	// Arrange
+	ackErr := errors.New("not leader for partition")
+	producer := newProducer(&stubClient{ackErr: ackErr}, ProducerOptions{Mode: DeliverySync, Timeout: time.Second})
+
+	// Act
+	err := producer.ProduceMessage(context.Background(), stubEvent{})
+
+	// Assert
+	if !errors.Is(err, ackErr) {
+		t.Fatalf("Expected ack error, got %v", err)
+	}
+}
+
+func TestProduceMessage_SyncTimesOut(t *testing.T) {
+	// Arrange
+	producer := newProducer(&stubClient{ackDelay: time.Second}, ProducerOptions{Mode: DeliverySync, Timeout: 10 * time.Millisecond})
+
+	// Act
+	err := producer.ProduceMessage(context.Background(), stubEvent{})
+
+	// Assert
+	if !errors.Is(err, ErrProduceTimeout) {
+		t.Fatalf("Expected ErrProduceTimeout, got %v", err)
+	}
+}
+
+func TestProduceMessage_SyncAbortsWhenContextCancelled(t *testing.T) {
+	// Arrange
+	producer := newProducer(&stubClient{ackDelay: time.Second}, ProducerOptions{Mode: DeliverySync, Timeout: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	// Act
+	start := time.Now()
+	err := producer.ProduceMessage(ctx, stubEvent{})
+	elapsed := time.Since(start)
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed >= 500*time.Millisecond {
+		t.Errorf("Expected the in-flight produce to stop on cancel, took %s", elapsed)
+	}
+}
+
+func TestProduceMessage_CancelledContextProducesNothing(t *testing.T) {
+	// Arrange
+	client := &stubClient{}
+	producer := newProducer(client, ProducerOptions{Mode: DeliverySync, Timeout: time.Second})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	err := producer.ProduceMessage(ctx, stubEvent{})
+
+	// Assert
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if batches := client.producedBatches(); len(batches) != 0 {
+		t.Errorf("Expected nothing produced, got batches %v", batches)
+	}
+}
+
+func TestProduceMessage_AsyncReturnsImmediately(t *testing.T) {
+	// Arrange
+	ackErr := errors.New("broker unavailable")
+	failures := make(chan error, 1)
+	producer := newProducer(&stubClient{ackErr: ackErr, ackDelay: 50 * time.Millisecond}, ProducerOptions{
+		Mode: DeliveryAsync,
+		OnAsyncError: func(event KafkaEvent, err error) {
+			failures <- err
+		},
+	})
+
+	// Act
+	start := time.Now()
+	err := producer.ProduceMessage(context.Background(), stubEvent{})
+	elapsed := time.Since(start)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed >= 50*time.Millisecond {
+		t.Errorf("Expected async produce to return before the ack, took %s", elapsed)
+	}
+	select {
+	case failure := <-failures:
+		if !errors.Is(failure, ackErr) {
+			t.Errorf("Expected callback with ack error, got %v", failure)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected async error callback to be called")
+	}
+}
+
+func TestProduceMessage_BatchesWithinLinger(t *testing.T) {
+	// Arrange
+	client := &stubClient{}
+	producer := newProducer(client, ProducerOptions{BatchSize: 10, Linger: 50 * time.Millisecond})
+	defer producer.Close(context.Background())
+
+	// Act
+	for i := 0; i < 5; i++ {
+		if err := producer.ProduceMessage(context.Background(), stubEvent{}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	// Assert
+	if batches := client.producedBatches(); !reflect.DeepEqual(batches, []int{5}) {
+		t.Errorf("Expected one batch of 5 events, got %v", batches)
+	}
+}
+
+func TestProduceMessage_FlushesFullBatch(t *testing.T) {
+	// Arrange
+	client := &stubClient{}
+	producer := newProducer(client, ProducerOptions{BatchSize: 3, Linger: time.Hour})
+	defer producer.Close(context.Background())
+
+	// Act
+	for i := 0; i < 3; i++ {
+		producer.ProduceMessage(context.Background(), stubEvent{})
+	}
+
+	// Assert
+	if batches := client.producedBatches(); !reflect.DeepEqual(batches, []int{3}) {
+		t.Errorf("Expected the full batch to be produced immediately, got %v", batches)
+	}
+}
+
+func TestClose_FlushesPartialBatch(t *testing.T) {
+	// Arrange
+	client := &stubClient{}
+	producer := newProducer(client, ProducerOptions{BatchSize: 10, Linger: time.Hour})
+	producer.ProduceMessage(context.Background(), stubEvent{})
+	producer.ProduceMessage(context.Background(), stubEvent{})
+
+	// Act
+	producer.Close(context.Background())
+
+	// Assert
+	if batches := client.producedBatches(); !reflect.DeepEqual(batches, []int{2}) {
+		t.Errorf("Expected the partial batch to be flushed on close, got %v", batches)
+	}
+	if !client.closed {
+		t.Error("Expected the client to be closed")
+	}
+}
+
+func TestClose_DeliversPendingAsyncRecords(t *testing.T) {
+	// Arrange
+	client := &stubClient{ackDelay: 50 * time.Millisecond}
+	var failures []error
+	producer := newProducer(client, ProducerOptions{
+		Mode:         DeliveryAsync,
+		OnAsyncError: func(_ KafkaEvent, err error) { failures = append(failures, err) },
+	})
+	if err := producer.ProduceMessage(context.Background(), stubEvent{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	err := producer.Close(context.Background())
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if delivered := client.deliveredRecords(); delivered != 1 {
+		t.Errorf("Expected the pending record to be delivered before close, got %d delivered", delivered)
+	}
+	if len(failures) != 0 {
+		t.Errorf("Expected no delivery failures, got %v", failures)
+	}
+}
+
+func TestClose_ReportsFlushTimeout(t *testing.T) {
+	// Arrange
+	client := &stubClient{ackDelay: time.Second}
+	producer := newProducer(client, ProducerOptions{Mode: DeliveryAsync, OnAsyncError: func(KafkaEvent, error) {}})
+	producer.ProduceMessage(context.Background(), stubEvent{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := producer.Close(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the flush deadline to be reported, got %v", err)
+	}
+	if !client.closed {
+		t.Error("Expected the client to be closed")
+	}
+}
+
+func TestReplayMessage_MarksRecordAsReplayed(t *testing.T) {
+	// Arrange
+	client := &stubClient{}
+	producer := newProducer(client, ProducerOptions{Mode: DeliverySync, Timeout: time.Second})
+
+	// Act
+	if err := producer.ProduceMessage(context.Background(), stubEvent{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := producer.ReplayMessage(context.Background(), stubEvent{}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	if len(client.records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(client.records))
+	}
+	if len(client.records[0].Headers) != 0 {
+		t.Errorf("Expected no headers on a live record, got %v", client.records[0].Headers)
+	}
+	headers := client.records[1].Headers
+	if len(headers) != 1 || headers[0].Key != ReplayHeader || string(headers[0].Value) != "true" {
+		t.Errorf("Expected the replayed header, got %v", headers)
+	}
+}
+
+func TestProduceMessage_KeysRecordByPartitionKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		event       KafkaEvent
+		expectedKey []byte
+	}{
+		{name: "keyed event", event: keyedStubEvent{key: "6650f0c2a1b2c3d4e5f60718"}, expectedKey: []byte("6650f0c2a1b2c3d4e5f60718")},
+		{name: "empty partition key", event: keyedStubEvent{}},
+		{name: "event without a key", event: stubEvent{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			client := &stubClient{}
+			producer := newProducer(client, ProducerOptions{Mode: DeliverySync, Timeout: time.Second})
+
+			// Act
+			err := producer.ProduceMessage(context.Background(), tt.event)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(client.records) != 1 {
+				t.Fatalf("Expected 1 record, got %d", len(client.records))
+			}
+			record := client.records[0]
+			if record.Topic != "incidents" || string(record.Value) != "{}" {
+				t.Errorf("Expected the event payload on its topic, got %s on %s", record.Value, record.Topic)
+			}
+			if !reflect.DeepEqual(record.Key, tt.expectedKey) {
+				t.Errorf("Expected key %q, got %q", tt.expectedKey, record.Key)
+			}
+		})
+	}
+}
+
+func TestProduceMessage_KeysIncidentEventsByIncident(t *testing.T) {
+	// Arrange
+	client := &stubClient{}
+	producer := newProducer(client, ProducerOptions{Mode: DeliverySync, Timeout: time.Second})
+	events := []KafkaEvent{
+		models.IncidentCreated{EventKey: "6650f0c2a1b2c3d4e5f60001", Id: "6650f0c2a1b2c3d4e5f60718"},
+		models.IncidentStatusUpdated{EventKey: "6650f0c2a1b2c3d4e5f60002", Id: "6650f0c2a1b2c3d4e5f60718"},
+	}
+
+	// Act
+	for _, event := range events {
+		if err := producer.ProduceMessage(context.Background(), event); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	// Assert
+	for _, record := range client.records {
+		if string(record.Key) != "6650f0c2a1b2c3d4e5f60718" {
+			t.Errorf("Expected records keyed by the incident, got %q", record.Key)
+		}
+	}
+}
+
+func TestClientOptions_SelectsAcksAndIdempotence(t *testing.T) {
+	tests := []struct {
+		name             string
+		options          ProducerOptions
+		expectedAcks     kgo.Acks
+		expectIdempotent bool
+	}{
+		{name: "defaults to acks all and idempotent", expectedAcks: kgo.AllISRAcks(), expectIdempotent: true},
+		{name: "acks all", options: ProducerOptions{Acks: AcksAll}, expectedAcks: kgo.AllISRAcks(), expectIdempotent: true},
+		{name: "acks all without idempotence", options: ProducerOptions{Acks: AcksAll, DisableIdempotence: true}, expectedAcks: kgo.AllISRAcks()},
+		{name: "acks leader disables idempotence", options: ProducerOptions{Acks: AcksLeader}, expectedAcks: kgo.LeaderAck()},
+		{name: "acks none disables idempotence", options: ProducerOptions{Acks: AcksNone}, expectedAcks: kgo.NoAck()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			client, err := kgo.NewClient(clientOptions([]string{"localhost:9092"}, tt.options)...)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected valid client options, got %v", err)
+			}
+			defer client.Close()
+			if acks := client.OptValue(kgo.RequiredAcks); acks != tt.expectedAcks {
+				t.Errorf("Expected acks %v, got %v", tt.expectedAcks, acks)
+			}
+			if disabled := client.OptValue(kgo.DisableIdempotentWrite); disabled != !tt.expectIdempotent {
+				t.Errorf("Expected idempotent %t, got idempotent writes disabled %v", tt.expectIdempotent, disabled)
+			}
+		})
+	}
+}
@@ -2,6 +2,18 @@ package kafka
 
 type KafkaEvent interface {
 	GetTopic() string
+	GetEventType() string
 	GetVersion() int
 	GetPayload() ([]byte, error)
 }
+
+// TenantEvent is implemented by events carrying the tenant of their incident
+type TenantEvent interface {
+	GetTenantID() string
+}
+
+// PartitionedEvent is implemented by events with a partition key, which becomes
+// the record key. Events of one incident share a key, so Kafka keeps them in order.
+type PartitionedEvent interface {
+	GetPartitionKey() string
+}
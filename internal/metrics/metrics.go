@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// MongoOperationDuration records the latency of MongoDB operations
+var MongoOperationDuration = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "incident",
+		Name:      "mongo_operation_duration_seconds",
+		Help:      "Latency of MongoDB operations by operation and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	},
+	[]string{"operation", "outcome"},
+)
+
+// ObserveMongoOperation records the duration of a MongoDB operation. A missing
+// document is an expected result rather than a failed operation.
+func ObserveMongoOperation(operation string, duration time.Duration, err error) {
+	outcome := OutcomeSuccess
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		outcome = OutcomeFailure
+	}
+	MongoOperationDuration.WithLabelValues(operation, outcome).Observe(duration.Seconds())
+}
+
+// payloadSizeBuckets spans 100B to about 1.6MB, growing fourfold
+var payloadSizeBuckets = prometheus.ExponentialBuckets(100, 4, 8)
+
+// RequestBodySize records the size of request bodies by method and route
+var RequestBodySize = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "incident",
+		Name:      "http_request_body_bytes",
+		Help:      "Size of HTTP request bodies by method and route.",
+		Buckets:   payloadSizeBuckets,
+	},
+	[]string{"method", "route"},
+)
+
+// ResponseBodySize records the size of response bodies by method and route
+var ResponseBodySize = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "incident",
+		Name:      "http_response_body_bytes",
+		Help:      "Size of HTTP response bodies by method and route.",
+		Buckets:   payloadSizeBuckets,
+	},
+	[]string{"method", "route"},
+)
+
+// ObservePayloadSizes records the request and response body sizes of a request.
+// Routes are labelled by their pattern, such as /api/v1/incidents/:id, to keep
+// the label set small.
+func ObservePayloadSizes(method, route string, requestBytes, responseBytes int) {
+	RequestBodySize.WithLabelValues(method, route).Observe(float64(requestBytes))
+	ResponseBodySize.WithLabelValues(method, route).Observe(float64(responseBytes))
+}
+
+// DuplicateRequests counts create requests whose idempotency key matched an
+// existing incident, which was returned instead of creating another
+var DuplicateRequests = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "incident",
+	Name:      "duplicate_requests_total",
+	Help:      "Create requests deduplicated by their idempotency key.",
+})
+
+// IdempotentCreates counts incidents created by requests carrying an idempotency key
+var IdempotentCreates = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "incident",
+	Name:      "idempotent_creates_total",
+	Help:      "Incidents created by requests carrying an idempotency key.",
+})
+
+// FirstResponseTime records the time from an incident's creation to its first note
+var FirstResponseTime = promauto.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Namespace: "incident",
+		Name:      "first_response_seconds",
+		Help:      "Time from incident creation to the first note by severity.",
+		Buckets:   []float64{60, 300, 900, 1800, 3600, 7200, 14400, 43200, 86400},
+	},
+	[]string{"severity"},
+)
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdminToken rejects requests without the admin bearer token. Every request
+// is refused when no token is configured, so admin routes are never left open.
+func RequireAdminToken(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": "Admin API is disabled",
+			})
+		}
+
+		provided, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid admin token",
+			})
+		}
+
+		return c.Next()
+	}
+}
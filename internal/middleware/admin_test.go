@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequireAdminToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		authorization string
+		expected      int
+	}{
+		{name: "valid token", token: "s3cret", authorization: "Bearer s3cret", expected: fiber.StatusOK},
+		{name: "wrong token", token: "s3cret", authorization: "Bearer guess", expected: fiber.StatusUnauthorized},
+		{name: "missing token", token: "s3cret", expected: fiber.StatusUnauthorized},
+		{name: "admin API disabled", authorization: "Bearer ", expected: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			app := fiber.New()
+			app.Post("/admin/action", RequireAdminToken(tt.token), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("POST", "/admin/action", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
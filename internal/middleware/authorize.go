@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/auth"
+)
+
+// RequirePermission rejects callers whose bearer token's roles do not grant the
+// permission. Routes are left open when the policy has no role tokens, so
+// deployments without RBAC are unaffected.
+func RequirePermission(policy *auth.Policy, permission auth.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if !policy.Enabled() {
+			return c.Next()
+		}
+
+		provided, _ := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		roles, ok := policy.Roles(provided)
+		if !ok {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid token",
+			})
+		}
+		if !policy.Allows(roles, permission) {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":      "Insufficient permissions",
+				"permission": permission,
+			})
+		}
+		return c.Next()
+	}
+}
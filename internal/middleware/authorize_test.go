@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/auth"
+)
+
+func TestRequirePermission(t *testing.T) {
+	policy := auth.NewPolicy(map[string][]string{
+		"responder-token": {"responders"},
+		"admin-token":     {"admins"},
+	}, nil)
+
+	tests := []struct {
+		name          string
+		policy        *auth.Policy
+		method        string
+		authorization string
+		expected      int
+	}{
+		{name: "responder denied delete", policy: policy, method: "DELETE", authorization: "Bearer responder-token", expected: fiber.StatusForbidden},
+		{name: "responder allowed note-add", policy: policy, method: "POST", authorization: "Bearer responder-token", expected: fiber.StatusOK},
+		{name: "admin allowed delete", policy: policy, method: "DELETE", authorization: "Bearer admin-token", expected: fiber.StatusOK},
+		{name: "unknown token", policy: policy, method: "POST", authorization: "Bearer guess", expected: fiber.StatusUnauthorized},
+		{name: "open without role tokens", policy: auth.NewPolicy(nil, nil), method: "DELETE", expected: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			app := fiber.New()
+			ok := func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			}
+			app.Delete("/incidents/:id", RequirePermission(tt.policy, auth.PermissionDelete), ok)
+			app.Post("/incidents/:id/notes", RequirePermission(tt.policy, auth.PermissionWriteNotes), ok)
+
+			path := "/incidents/42"
+			if tt.method == "POST" {
+				path += "/notes"
+			}
+			req := httptest.NewRequest(tt.method, path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// LimitConcurrency caps the number of requests handled at once, answering 503 with
+// a Retry-After hint while saturated. A max of zero or less disables the limit.
+func LimitConcurrency(max int, retryAfter time.Duration) fiber.Handler {
+	if max <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	semaphore := make(chan struct{}, max)
+	retryAfterSeconds := strconv.Itoa(int(retryAfter.Round(time.Second).Seconds()))
+
+	return func(c *fiber.Ctx) error {
+		select {
+		case semaphore <- struct{}{}:
+			defer func() { <-semaphore }()
+			return c.Next()
+		default:
+			c.Set(fiber.HeaderRetryAfter, retryAfterSeconds)
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error": "Server is busy, retry later",
+			})
+		}
+	}
+}
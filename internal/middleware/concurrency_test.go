@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestLimitConcurrency_RejectsRequestsBeyondMax(t *testing.T) {
+	// Arrange
+	const max = 2
+	entered := make(chan struct{}, max)
+	release := make(chan struct{})
+
+	app := fiber.New()
+	app.Use(LimitConcurrency(max, 2*time.Second))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		entered <- struct{}{}
+		<-release
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	statuses := make(chan int, max)
+	for i := 0; i < max; i++ {
+		go func() {
+			resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil), -1)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			statuses <- resp.StatusCode
+		}()
+	}
+	for i := 0; i < max; i++ {
+		select {
+		case <-entered:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the first requests to be admitted")
+		}
+	}
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest("GET", "/slow", nil), -1)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", fiber.StatusServiceUnavailable, resp.StatusCode)
+	}
+	if retryAfter := resp.Header.Get(fiber.HeaderRetryAfter); retryAfter != "2" {
+		t.Errorf("Expected Retry-After 2, got %q", retryAfter)
+	}
+
+	close(release)
+	for i := 0; i < max; i++ {
+		if status := <-statuses; status != fiber.StatusOK {
+			t.Errorf("Expected admitted request to succeed, got %d", status)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestTimeout gives each request's user context a deadline, which handlers pass
+// on to Mongo and Kafka so work stops once the request is abandoned. A timeout of
+// zero or less leaves requests without a deadline.
+func RequestTimeout(timeout time.Duration) fiber.Handler {
+	if timeout <= 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
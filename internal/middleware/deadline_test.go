@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/tenant"
+)
+
+func TestRequestTimeout_SetsDeadlineOnUserContext(t *testing.T) {
+	tests := []struct {
+		name           string
+		timeout        time.Duration
+		expectDeadline bool
+	}{
+		{name: "timeout configured", timeout: time.Minute, expectDeadline: true},
+		{name: "timeout disabled", timeout: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			var hasDeadline bool
+			var tenantID string
+			app := fiber.New()
+			app.Use(RequestTimeout(tt.timeout))
+			app.Get("/incidents", ResolveTenant(nil), func(c *fiber.Ctx) error {
+				_, hasDeadline = c.UserContext().Deadline()
+				tenantID = tenant.FromContext(c.UserContext())
+				return c.SendStatus(fiber.StatusOK)
+			})
+			req := httptest.NewRequest("GET", "/incidents", nil)
+			req.Header.Set(TenantHeader, "team-a")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+			}
+			if hasDeadline != tt.expectDeadline {
+				t.Errorf("Expected deadline %t, got %t", tt.expectDeadline, hasDeadline)
+			}
+			if tenantID != "team-a" {
+				t.Errorf("Expected tenant team-a alongside the deadline, got %q", tenantID)
+			}
+		})
+	}
+}
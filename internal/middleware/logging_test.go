@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestRequestLogger_RedactsTokensAndEmails(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	app := fiber.New()
+	app.Use(RequestLogger(&output, RedactionRules{
+		Headers:    []string{"Authorization"},
+		MaskEmails: true,
+		LogBodies:  true,
+	}))
+	app.Post("/incidents", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	body := `{"title":"Login failures","author_email":"alice.smith@example.com","token":"Bearer body-secret"}`
+	req := httptest.NewRequest("POST", "/incidents?watcher=bob@example.com", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer header-secret-token")
+
+	// Act
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	logged := output.String()
+	for _, secret := range []string{"header-secret-token", "body-secret", "alice.smith@", "bob@"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, logged)
+		}
+	}
+	for _, expected := range []string{"Authorization=[REDACTED]", "***@example.com", "201 - POST /incidents"} {
+		if !strings.Contains(logged, expected) {
+			t.Errorf("Expected log line to contain %q, got %s", expected, logged)
+		}
+	}
+}
+
+func TestRequestLogger_EmailMaskingIsConfigurable(t *testing.T) {
+	// Arrange
+	rules := RedactionRules{MaskEmails: false}
+
+	// Act
+	value := rules.redact("watcher=bob@example.com")
+
+	// Assert
+	if value != "watcher=bob@example.com" {
+		t.Errorf("Expected email to be kept when masking is disabled, got %s", value)
+	}
+}
+
+func TestRequestLogger_RedactsEscapedQueryValues(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	app := fiber.New()
+	app.Use(RequestLogger(&output, RedactionRules{MaskEmails: true}))
+	app.Get("/incidents", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	req := httptest.NewRequest("GET", "/incidents?assignee=alice%40example.com&token=Bearer%20query-secret&status=open", nil)
+
+	// Act
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Assert
+	logged := output.String()
+	for _, secret := range []string{"alice", "query-secret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected %q to be redacted, got %s", secret, logged)
+		}
+	}
+	expected := "GET /incidents?assignee=***@example.com&token=Bearer [REDACTED]&status=open"
+	if !strings.Contains(logged, expected) {
+		t.Errorf("Expected log line to contain %q, got %s", expected, logged)
+	}
+}
@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/metrics"
+)
+
+// PayloadSizeMetrics records request and response body sizes per route, to spot
+// oversized documents such as incidents with very large notes arrays
+func PayloadSizeMetrics() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+		metrics.ObservePayloadSizes(c.Method(), c.Route().Path, len(c.Body()), len(c.Response().Body()))
+		return err
+	}
+}
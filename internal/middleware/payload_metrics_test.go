@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"makers.anchor/incident/internal/metrics"
+)
+
+// histogramSamples returns the sample count and sum of a histogram series
+func histogramSamples(t *testing.T, vec *prometheus.HistogramVec, labels ...string) (uint64, float64) {
+	t.Helper()
+	var metric dto.Metric
+	if err := vec.WithLabelValues(labels...).(prometheus.Histogram).Write(&metric); err != nil {
+		t.Fatalf("Expected to read histogram, got %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount(), metric.GetHistogram().GetSampleSum()
+}
+
+func TestPayloadSizeMetrics_ObservesSizes(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(PayloadSizeMetrics())
+	app.Post("/payload-test/:id", func(c *fiber.Ctx) error {
+		return c.SendString("created")
+	})
+	requestsBefore, requestBytesBefore := histogramSamples(t, metrics.RequestBodySize, "POST", "/payload-test/:id")
+	responsesBefore, responseBytesBefore := histogramSamples(t, metrics.ResponseBodySize, "POST", "/payload-test/:id")
+
+	// Act
+	_, err := app.Test(httptest.NewRequest("POST", "/payload-test/42", strings.NewReader(`{"title":"x"}`)))
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	requests, requestBytes := histogramSamples(t, metrics.RequestBodySize, "POST", "/payload-test/:id")
+	responses, responseBytes := histogramSamples(t, metrics.ResponseBodySize, "POST", "/payload-test/:id")
+	if requests != requestsBefore+1 || requestBytes-requestBytesBefore != 13 {
+		t.Errorf("Expected one 13 byte request sample, got %d samples totalling %v bytes", requests-requestsBefore, requestBytes-requestBytesBefore)
+	}
+	if responses != responsesBefore+1 || responseBytes-responseBytesBefore != 7 {
+		t.Errorf("Expected one 7 byte response sample, got %d samples totalling %v bytes", responses-responsesBefore, responseBytes-responseBytesBefore)
+	}
+}
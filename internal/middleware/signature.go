@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/hmac"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+// VerifySignature rejects requests whose signature header does not match the
+// HMAC-SHA256 of the raw body computed with the shared secret. Verification is
+// skipped when no secret is configured.
+func VerifySignature(secret string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if secret == "" {
+			return c.Next()
+		}
+
+		signature := c.Get(webhooks.SignatureHeader)
+		expected := webhooks.Sign(secret, c.Body())
+		if signature == "" || !hmac.Equal([]byte(signature), []byte(expected)) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": "Invalid webhook signature",
+			})
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestVerifySignature(t *testing.T) {
+	const secret = "inbound-secret"
+	body := `{"title":"Alert fired","severity":"high"}`
+
+	tests := []struct {
+		name      string
+		body      string
+		signature string
+		expected  int
+	}{
+		{name: "valid signature", body: body, signature: webhooks.Sign(secret, []byte(body)), expected: fiber.StatusOK},
+		{name: "tampered body", body: strings.Replace(body, "high", "low", 1), signature: webhooks.Sign(secret, []byte(body)), expected: fiber.StatusUnauthorized},
+		{name: "missing signature", body: body, signature: "", expected: fiber.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			app := fiber.New()
+			app.Post("/webhooks/generic", VerifySignature(secret), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			req := httptest.NewRequest("POST", "/webhooks/generic", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.signature != "" {
+				req.Header.Set(webhooks.SignatureHeader, tt.signature)
+			}
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/tenant"
+)
+
+// TenantHeader names the caller's tenant when no tenant tokens are configured
+const TenantHeader = "X-Tenant-ID"
+
+// ResolveTenant scopes the request to the caller's tenant. With tenant tokens
+// configured the tenant is the one the bearer token belongs to; otherwise the
+// X-Tenant-ID header, set by a trusted gateway, is used. Requests without a
+// tenant are refused.
+func ResolveTenant(tokens map[string]string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if len(tokens) > 0 {
+			provided, _ := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			id, ok := tokens[provided]
+			if !ok {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error": "Invalid tenant token",
+				})
+			}
+			c.SetUserContext(tenant.NewContext(c.UserContext(), id))
+			return c.Next()
+		}
+
+		id := strings.TrimSpace(c.Get(TenantHeader))
+		if id == "" {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": TenantHeader + " header is required",
+			})
+		}
+		c.SetUserContext(tenant.NewContext(c.UserContext(), id))
+		return c.Next()
+	}
+}
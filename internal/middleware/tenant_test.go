@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/tenant"
+)
+
+func TestResolveTenant(t *testing.T) {
+	tokens := map[string]string{"token-a": "team-a"}
+
+	tests := []struct {
+		name           string
+		tokens         map[string]string
+		authorization  string
+		header         string
+		expectedStatus int
+		expectedTenant string
+	}{
+		{name: "tenant from token", tokens: tokens, authorization: "Bearer token-a", expectedStatus: fiber.StatusOK, expectedTenant: "team-a"},
+		{name: "header ignored when tokens are configured", tokens: tokens, header: "team-b", expectedStatus: fiber.StatusUnauthorized},
+		{name: "unknown token", tokens: tokens, authorization: "Bearer guess", expectedStatus: fiber.StatusUnauthorized},
+		{name: "tenant from header", header: "team-b", expectedStatus: fiber.StatusOK, expectedTenant: "team-b"},
+		{name: "missing header", expectedStatus: fiber.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			app := fiber.New()
+			app.Get("/incidents", ResolveTenant(tt.tokens), func(c *fiber.Ctx) error {
+				return c.SendString(tenant.FromContext(c.UserContext()))
+			})
+
+			req := httptest.NewRequest("GET", "/incidents", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.header != "" {
+				req.Header.Set(TenantHeader, tt.header)
+			}
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if tt.expectedStatus == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != tt.expectedTenant {
+					t.Errorf("Expected tenant %s, got %s", tt.expectedTenant, body)
+				}
+			}
+		})
+	}
+}
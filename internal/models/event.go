@@ -12,6 +12,7 @@ const (
 type IncidentCreated struct {
 	EventKey      string `json:"event_key"`
 	Id            string `json:"id"`
+	TenantID      string `json:"tenant_id,omitempty"`
 	Title         string `json:"title"`
 	Severity      string `json:"severity"`
 	SourceService string `json:"source_service"`
@@ -22,6 +23,7 @@ type IncidentCreated struct {
 type IncidentStatusUpdated struct {
 	EventKey      string `json:"event_key"`
 	Id            string `json:"id"`
+	TenantID      string `json:"tenant_id,omitempty"`
 	Title         string `json:"title"`
 	Status        string `json:"status"`
 	SourceService string `json:"source_service"`
@@ -32,6 +34,7 @@ type IncidentStatusUpdated struct {
 type IncidentSeverityUpdated struct {
 	EventKey      string `json:"event_key"`
 	Id            string `json:"id"`
+	TenantID      string `json:"tenant_id,omitempty"`
 	Title         string `json:"title"`
 	Severity      string `json:"severity"`
 	SourceService string `json:"source_service"`
@@ -39,9 +42,34 @@ type IncidentSeverityUpdated struct {
 	EventType     string `json:"event_type"`
 }
 
+type IncidentPriorityUpdated struct {
+	EventKey         string `json:"event_key"`
+	Id               string `json:"id"`
+	TenantID         string `json:"tenant_id,omitempty"`
+	Title            string `json:"title"`
+	Priority         string `json:"priority"`
+	PreviousPriority string `json:"previous_priority"`
+	SourceService    string `json:"source_service"`
+	Version          int    `json:"version"`
+	EventType        string `json:"event_type"`
+}
+
+type IncidentEscalatedToCritical struct {
+	EventKey         string `json:"event_key"`
+	Id               string `json:"id"`
+	TenantID         string `json:"tenant_id,omitempty"`
+	Title            string `json:"title"`
+	Severity         string `json:"severity"`
+	PreviousSeverity string `json:"previous_severity"`
+	SourceService    string `json:"source_service"`
+	Version          int    `json:"version"`
+	EventType        string `json:"event_type"`
+}
+
 type IncidentNoteAdded struct {
 	EventKey      string `json:"event_key"`
 	Id            string `json:"id"`
+	TenantID      string `json:"tenant_id,omitempty"`
 	Title         string `json:"title"`
 	Content       string `json:"content"`
 	SourceService string `json:"source_service"`
@@ -61,6 +89,18 @@ func (e IncidentCreated) GetVersion() int {
 	return 1
 }
 
+func (e IncidentCreated) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentCreated) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentCreated) GetTenantID() string {
+	return e.TenantID
+}
+
 func (e IncidentCreated) GetPayload() ([]byte, error) {
 	e.Version = e.GetVersion()
 	e.EventType = e.GetEventType()
@@ -81,6 +121,18 @@ func (e IncidentStatusUpdated) GetVersion() int {
 	return 1
 }
 
+func (e IncidentStatusUpdated) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentStatusUpdated) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentStatusUpdated) GetTenantID() string {
+	return e.TenantID
+}
+
 func (e IncidentStatusUpdated) GetPayload() ([]byte, error) {
 	e.Version = e.GetVersion()
 	e.EventType = e.GetEventType()
@@ -101,6 +153,18 @@ func (e IncidentSeverityUpdated) GetVersion() int {
 	return 1
 }
 
+func (e IncidentSeverityUpdated) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentSeverityUpdated) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentSeverityUpdated) GetTenantID() string {
+	return e.TenantID
+}
+
 func (e IncidentSeverityUpdated) GetPayload() ([]byte, error) {
 	e.Version = e.GetVersion()
 	e.EventType = e.GetEventType()
@@ -108,6 +172,70 @@ func (e IncidentSeverityUpdated) GetPayload() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// Incident Priority Updated
+func (e IncidentPriorityUpdated) GetTopic() string {
+	return EVENT_TOPIC
+}
+
+func (e IncidentPriorityUpdated) GetEventType() string {
+	return "incident.priority.updated"
+}
+
+func (e IncidentPriorityUpdated) GetVersion() int {
+	return 1
+}
+
+func (e IncidentPriorityUpdated) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentPriorityUpdated) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentPriorityUpdated) GetTenantID() string {
+	return e.TenantID
+}
+
+func (e IncidentPriorityUpdated) GetPayload() ([]byte, error) {
+	e.Version = e.GetVersion()
+	e.EventType = e.GetEventType()
+	e.SourceService = SOURCE_SERVICE
+	return json.Marshal(e)
+}
+
+// Incident Escalated To Critical
+func (e IncidentEscalatedToCritical) GetTopic() string {
+	return EVENT_TOPIC
+}
+
+func (e IncidentEscalatedToCritical) GetEventType() string {
+	return "incident.severity.escalated.critical"
+}
+
+func (e IncidentEscalatedToCritical) GetVersion() int {
+	return 1
+}
+
+func (e IncidentEscalatedToCritical) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentEscalatedToCritical) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentEscalatedToCritical) GetTenantID() string {
+	return e.TenantID
+}
+
+func (e IncidentEscalatedToCritical) GetPayload() ([]byte, error) {
+	e.Version = e.GetVersion()
+	e.EventType = e.GetEventType()
+	e.SourceService = SOURCE_SERVICE
+	return json.Marshal(e)
+}
+
 // Incident Note Added
 func (e IncidentNoteAdded) GetTopic() string {
 	return EVENT_TOPIC
@@ -121,9 +249,161 @@ func (e IncidentNoteAdded) GetVersion() int {
 	return 1
 }
 
+func (e IncidentNoteAdded) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentNoteAdded) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentNoteAdded) GetTenantID() string {
+	return e.TenantID
+}
+
 func (e IncidentNoteAdded) GetPayload() ([]byte, error) {
 	e.Version = e.GetVersion()
 	e.EventType = e.GetEventType()
 	e.SourceService = SOURCE_SERVICE
 	return json.Marshal(e)
 }
+
+// IncidentTypedNoteAdded is emitted alongside IncidentNoteAdded for note types
+// configured to get a dedicated event, e.g. incident.notes.resolution.added
+type IncidentTypedNoteAdded struct {
+	EventKey      string `json:"event_key"`
+	Id            string `json:"id"`
+	TenantID      string `json:"tenant_id,omitempty"`
+	Title         string `json:"title"`
+	Content       string `json:"content"`
+	NoteType      string `json:"note_type"`
+	SourceService string `json:"source_service"`
+	Version       int    `json:"version"`
+	EventType     string `json:"event_type"`
+}
+
+// Incident Typed Note Added
+func (e IncidentTypedNoteAdded) GetTopic() string {
+	return EVENT_TOPIC
+}
+
+func (e IncidentTypedNoteAdded) GetEventType() string {
+	return "incident.notes." + e.NoteType + ".added"
+}
+
+func (e IncidentTypedNoteAdded) GetVersion() int {
+	return 1
+}
+
+func (e IncidentTypedNoteAdded) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentTypedNoteAdded) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentTypedNoteAdded) GetTenantID() string {
+	return e.TenantID
+}
+
+func (e IncidentTypedNoteAdded) GetPayload() ([]byte, error) {
+	e.Version = e.GetVersion()
+	e.EventType = e.GetEventType()
+	e.SourceService = SOURCE_SERVICE
+	return json.Marshal(e)
+}
+
+// IncidentStale is emitted once per configured age threshold an unresolved
+// incident crosses, nudging its assignee and watchers without changing severity
+type IncidentStale struct {
+	EventKey      string   `json:"event_key"`
+	Id            string   `json:"id"`
+	TenantID      string   `json:"tenant_id,omitempty"`
+	Title         string   `json:"title"`
+	Severity      string   `json:"severity"`
+	Status        string   `json:"status"`
+	Threshold     string   `json:"threshold"`
+	Age           string   `json:"age"`
+	Recipients    []string `json:"recipients"` // Assignee and watcher emails
+	SourceService string   `json:"source_service"`
+	Version       int      `json:"version"`
+	EventType     string   `json:"event_type"`
+}
+
+// Incident Stale
+func (e IncidentStale) GetTopic() string {
+	return EVENT_TOPIC
+}
+
+func (e IncidentStale) GetEventType() string {
+	return "incident.stale"
+}
+
+func (e IncidentStale) GetVersion() int {
+	return 1
+}
+
+func (e IncidentStale) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentStale) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentStale) GetTenantID() string {
+	return e.TenantID
+}
+
+func (e IncidentStale) GetPayload() ([]byte, error) {
+	e.Version = e.GetVersion()
+	e.EventType = e.GetEventType()
+	e.SourceService = SOURCE_SERVICE
+	return json.Marshal(e)
+}
+
+// IncidentUpdated is emitted when an incident's title, description or assignee
+// is edited, naming the fields that changed
+type IncidentUpdated struct {
+	EventKey      string   `json:"event_key"`
+	Id            string   `json:"id"`
+	TenantID      string   `json:"tenant_id,omitempty"`
+	Title         string   `json:"title"`
+	Fields        []string `json:"fields"`
+	SourceService string   `json:"source_service"`
+	Version       int      `json:"version"`
+	EventType     string   `json:"event_type"`
+}
+
+// Incident Updated
+func (e IncidentUpdated) GetTopic() string {
+	return EVENT_TOPIC
+}
+
+func (e IncidentUpdated) GetEventType() string {
+	return "incident.updated"
+}
+
+func (e IncidentUpdated) GetVersion() int {
+	return 1
+}
+
+func (e IncidentUpdated) GetEventKey() string {
+	return e.EventKey
+}
+
+func (e IncidentUpdated) GetPartitionKey() string {
+	return e.Id
+}
+
+func (e IncidentUpdated) GetTenantID() string {
+	return e.TenantID
+}
+
+func (e IncidentUpdated) GetPayload() ([]byte, error) {
+	e.Version = e.GetVersion()
+	e.EventType = e.GetEventType()
+	e.SourceService = SOURCE_SERVICE
+	return json.Marshal(e)
+}
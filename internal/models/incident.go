@@ -1,8 +1,15 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -16,6 +23,17 @@ const (
 	Critical IncidentSeverity = "critical"
 )
 
+// IncidentPriority is the operational priority used for triage, set
+// independently of the severity, which describes impact
+type IncidentPriority string
+
+const (
+	P1 IncidentPriority = "P1"
+	P2 IncidentPriority = "P2"
+	P3 IncidentPriority = "P3"
+	P4 IncidentPriority = "P4"
+)
+
 // IncidentStatus represents the status of an incident
 type IncidentStatus string
 
@@ -24,6 +42,10 @@ const (
 	InProgress IncidentStatus = "in_progress"
 	Resolved   IncidentStatus = "resolved"
 	Closed     IncidentStatus = "closed"
+
+	// Scheduled incidents are planned ahead, e.g. maintenance, and opened by the
+	// scheduler at ActivateAt. It is not in ValidStatuses, so it cannot be set directly.
+	Scheduled IncidentStatus = "scheduled"
 )
 
 type NoteType string
@@ -35,20 +57,75 @@ const (
 	Communication NoteType = "communication"
 )
 
+// NoteVisibility controls who may see a note
+type NoteVisibility string
+
+const (
+	NoteInternal NoteVisibility = "internal" // Responders only
+	NotePublic   NoteVisibility = "public"   // Shareable with stakeholders
+)
+
 // Incident represents an incident in the command platform
 type Incident struct {
-	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	IncidentKey int                `json:"incident_key" bson:"incident_key"`
-	Title       string             `json:"title" bson:"title" validate:"required,min=3,max=255"`
-	Severity    IncidentSeverity   `json:"severity" bson:"severity" validate:"required,oneof=low medium high critical"`
-	Status      IncidentStatus     `json:"status" bson:"status" validate:"required,oneof=open in_progress resolved closed"`
-	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
-	Notes       []Note             `json:"notes" bson:"notes"`
-	WatchList   []Watcher          `json:"watchlist" bson:"watchlist"`
-	CreatedBy   string             `json:"created_by" bson:"created_by"` // Email of the creator
-	Description string             `json:"description" bson:"description"`
-	Assignee    string             `json:"assignee" bson:"assignee"`
+	ID          primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	IncidentKey int                 `json:"incident_key" bson:"incident_key"`
+	TenantID    string              `json:"tenant_id,omitempty" bson:"tenant_id,omitempty"` // Owning tenant in multi-tenant deployments
+	Title       string              `json:"title" bson:"title" validate:"required,min=3,max=255"`
+	Severity    IncidentSeverity    `json:"severity" bson:"severity" validate:"required,oneof=low medium high critical"`
+	Priority    IncidentPriority    `json:"priority,omitempty" bson:"priority,omitempty"` // Unset on incidents created before priorities
+	Status      IncidentStatus      `json:"status" bson:"status" validate:"required,oneof=open in_progress resolved closed"`
+	CreatedAt   time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" bson:"updated_at"`
+	Notes       []Note              `json:"notes" bson:"notes"`
+	WatchList   []Watcher           `json:"watchlist" bson:"watchlist"`
+	CreatedBy   string              `json:"created_by" bson:"created_by"` // Email of the creator
+	Description string              `json:"description" bson:"description"`
+	Assignee    string              `json:"assignee" bson:"assignee"`
+	OutageID    *primitive.ObjectID `json:"outage_id,omitempty" bson:"outage_id,omitempty"` // Parent outage, if grouped
+	Attachments []Attachment        `json:"attachments,omitempty" bson:"attachments,omitempty"`
+	Tags        []string            `json:"tags,omitempty" bson:"tags,omitempty"`
+	Services    []string            `json:"services,omitempty" bson:"services,omitempty"` // Affected services
+
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" bson:"custom_fields,omitempty"` // Team-specific fields, see CUSTOM_FIELDS
+
+	Draft bool `json:"draft,omitempty" bson:"draft,omitempty"` // Staged: hidden from lists and emits no events until published
+
+	ActivateAt *time.Time `json:"activate_at,omitempty" bson:"activate_at,omitempty"` // When a scheduled incident opens; hidden from lists and emits no events until then
+
+	TitleTruncated bool `json:"title_truncated,omitempty" bson:"title_truncated,omitempty"` // Title was shortened to the maximum length
+
+	ExternalID string `json:"external_id,omitempty" bson:"external_id,omitempty"` // Source-prefixed id of the ticket it was created from, e.g. jira:OPS-12
+
+	IdempotencyKey   string `json:"-" bson:"idempotency_key,omitempty"` // Key of the create request, matched by retries
+	IdempotentReplay bool   `json:"-" bson:"-"`                         // Computed: returned for a retried create instead of creating a new incident
+
+	PossibleDuplicates []PossibleDuplicate `json:"possible_duplicates,omitempty" bson:"-"` // Computed on create: open incidents that look like the same problem, see DUPLICATE_MATCH
+
+	StatusChangedAt *time.Time `json:"status_changed_at,omitempty" bson:"status_changed_at,omitempty"` // When the incident entered its current status (unset on older incidents)
+
+	SeverityChangedAt *time.Time `json:"severity_changed_at,omitempty" bson:"severity_changed_at,omitempty"` // When the severity last changed (unset until it does)
+
+	FirstResponseAt      *time.Time `json:"first_response_at,omitempty" bson:"first_response_at,omitempty"`           // When the first note was added, never changed afterwards
+	FirstResponseSeconds float64    `json:"first_response_seconds,omitempty" bson:"first_response_seconds,omitempty"` // Time to first response, from creation to FirstResponseAt
+
+	AssignmentHistory []AssignmentChange `json:"assignment_history,omitempty" bson:"assignment_history,omitempty"` // Reassignments, oldest first
+
+	Postmortem *Postmortem `json:"postmortem,omitempty" bson:"postmortem,omitempty"` // Blameless review, attached once resolved
+
+	ArchivedAt *time.Time `json:"archived_at,omitempty" bson:"archived_at,omitempty"` // When the closed incident was moved to the archive, see ARCHIVE_CLOSED_AFTER
+
+	StaleNotifiedAfter time.Duration `json:"-" bson:"stale_notified_after,omitempty"`                        // Largest stale threshold already notified
+	StaleNotifiedAt    *time.Time    `json:"stale_notified_at,omitempty" bson:"stale_notified_at,omitempty"` // When the last stale notification was sent
+
+	NeedsAttention bool `json:"needs_attention" bson:"-"` // Computed: open and not updated within the attention threshold
+}
+
+// PossibleDuplicate is an open incident flagged as likely describing the same
+// problem as a newly created one
+type PossibleDuplicate struct {
+	IncidentKey int      `json:"incident_key"`
+	Title       string   `json:"title"`
+	Services    []string `json:"services,omitempty"`
 }
 
 // Note represents a note added to an incident
@@ -58,26 +135,312 @@ type Note struct {
 	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
 	AuthorEmail string             `json:"author_email" bson:"author_email"` // Email of the author
 	Type        NoteType           `json:"type" bson:"type" validate:"required,oneof=update investigation resolution communication"`
+	EditHistory []NoteEdit         `json:"edit_history,omitempty" bson:"edit_history,omitempty"` // Prior versions, oldest first
+	Visibility  NoteVisibility     `json:"visibility" bson:"visibility,omitempty"`               // Unset on older notes, which are internal
+}
+
+// IsPublic reports whether the note may be shown to stakeholders
+func (n Note) IsPublic() bool {
+	return n.Visibility == NotePublic
+}
+
+// NotesWithVisibility returns the notes visible at the given level, or all notes
+// when visibility is empty. Notes without a visibility count as internal.
+func NotesWithVisibility(notes []Note, visibility NoteVisibility) []Note {
+	if visibility == "" {
+		return notes
+	}
+
+	filtered := []Note{}
+	for _, note := range notes {
+		if note.IsPublic() == (visibility == NotePublic) {
+			filtered = append(filtered, note)
+		}
+	}
+	return filtered
+}
+
+// NoteEdit records the content a note had before an edit, who edited it and when
+type NoteEdit struct {
+	PreviousContent string    `json:"previous_content" bson:"previous_content"`
+	EditorEmail     string    `json:"editor_email" bson:"editor_email"`
+	EditedAt        time.Time `json:"edited_at" bson:"edited_at"`
+}
+
+// Edited returns a copy of the note with new content, preserving the current
+// content in its edit history
+func (n Note) Edited(content, editorEmail string, at time.Time) Note {
+	history := make([]NoteEdit, len(n.EditHistory), len(n.EditHistory)+1)
+	copy(history, n.EditHistory)
+	n.EditHistory = append(history, NoteEdit{
+		PreviousContent: n.Content,
+		EditorEmail:     editorEmail,
+		EditedAt:        at,
+	})
+	n.Content = content
+	return n
+}
+
+// MarshalJSON adds the formatted incident key alongside the stored one
+func (i Incident) MarshalJSON() ([]byte, error) {
+	type incident Incident
+	return json.Marshal(struct {
+		incident
+		Key string `json:"key"`
+	}{incident(i), FormatIncidentKey(i.IncidentKey)})
+}
+
+// IncidentSummary is the list representation of an incident, carrying counts
+// in place of the full notes, watch list and attachments
+type IncidentSummary struct {
+	ID           primitive.ObjectID     `json:"id" bson:"_id,omitempty"`
+	IncidentKey  int                    `json:"incident_key" bson:"incident_key"`
+	Title        string                 `json:"title" bson:"title"`
+	Severity     IncidentSeverity       `json:"severity" bson:"severity"`
+	Priority     IncidentPriority       `json:"priority,omitempty" bson:"priority,omitempty"`
+	Status       IncidentStatus         `json:"status" bson:"status"`
+	CreatedAt    time.Time              `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at" bson:"updated_at"`
+	CreatedBy    string                 `json:"created_by" bson:"created_by"`
+	Description  string                 `json:"description" bson:"description"`
+	Assignee     string                 `json:"assignee" bson:"assignee"`
+	OutageID     *primitive.ObjectID    `json:"outage_id,omitempty" bson:"outage_id,omitempty"`
+	Tags         []string               `json:"tags,omitempty" bson:"tags,omitempty"`
+	Services     []string               `json:"services,omitempty" bson:"services,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty" bson:"custom_fields,omitempty"`
+	WatcherCount int                    `json:"watcher_count" bson:"watcher_count"`
+	NoteCount    int                    `json:"note_count" bson:"note_count"`
+	Draft        bool                   `json:"draft,omitempty" bson:"draft,omitempty"`
+
+	NeedsAttention bool `json:"needs_attention" bson:"-"` // Computed: open and not updated within the attention threshold
+}
+
+// keyedSummary is the JSON form of an IncidentSummary, with its formatted key
+type keyedSummary struct {
+	summary
+	Key string `json:"key"`
+}
+
+// summary has the fields of IncidentSummary without its JSON marshalling
+type summary IncidentSummary
+
+func (i IncidentSummary) keyed() keyedSummary {
+	return keyedSummary{summary(i), FormatIncidentKey(i.IncidentKey)}
+}
+
+// MarshalJSON adds the formatted incident key alongside the stored one
+func (i IncidentSummary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.keyed())
+}
+
+// SearchField is an incident field a text search can be scoped to with ?in=
+type SearchField string
+
+const (
+	SearchTitle       SearchField = "title"
+	SearchDescription SearchField = "description"
+	SearchNotes       SearchField = "notes" // Note contents
+)
+
+// IncidentSearchResult is an incident matching a text search, with the relevance
+// score of its title and description. Searches scoped to other fields are not
+// ranked and score 0.
+type IncidentSearchResult struct {
+	IncidentSummary `bson:",inline"`
+	Score           float64 `json:"score" bson:"score"`
+}
+
+// MarshalJSON keeps the score alongside the summary's own JSON form
+func (r IncidentSearchResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		keyedSummary
+		Score float64 `json:"score"`
+	}{r.keyed(), r.Score})
+}
+
+// RelatedIncident is an incident sharing tags or services with another, with the
+// number of tags and services they have in common
+type RelatedIncident struct {
+	IncidentSummary `bson:",inline"`
+	Overlap         int `json:"overlap" bson:"overlap"`
+}
+
+// MarshalJSON keeps the overlap alongside the summary's own JSON form
+func (r RelatedIncident) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		keyedSummary
+		Overlap int `json:"overlap"`
+	}{r.keyed(), r.Overlap})
+}
+
+// NextResponder is who should be paged next for an incident
+type NextResponder struct {
+	Responder string `json:"responder"`
+	Position  int    `json:"position"`  // Index of the responder in the severity's escalation chain
+	Escalated bool   `json:"escalated"` // The ack SLA has passed, moving past the assignee
+}
+
+// InLocation returns a copy of the summary with its timestamps expressed in loc
+func (i IncidentSummary) InLocation(loc *time.Location) IncidentSummary {
+	i.CreatedAt = i.CreatedAt.In(loc)
+	i.UpdatedAt = i.UpdatedAt.In(loc)
+	return i
+}
+
+// SetTenantID records the tenant that owns the incident
+func (i *Incident) SetTenantID(id string) {
+	i.TenantID = id
+}
+
+// InLocation returns a copy of the incident with its timestamps expressed in loc.
+// Stored timestamps stay UTC; this only changes how responses are formatted.
+func (i Incident) InLocation(loc *time.Location) Incident {
+	i.CreatedAt = i.CreatedAt.In(loc)
+	i.UpdatedAt = i.UpdatedAt.In(loc)
+	i.ActivateAt = timeIn(i.ActivateAt, loc)
+	i.StatusChangedAt = timeIn(i.StatusChangedAt, loc)
+	i.SeverityChangedAt = timeIn(i.SeverityChangedAt, loc)
+	i.FirstResponseAt = timeIn(i.FirstResponseAt, loc)
+	i.ArchivedAt = timeIn(i.ArchivedAt, loc)
+	i.StaleNotifiedAt = timeIn(i.StaleNotifiedAt, loc)
+
+	if i.Notes != nil {
+		notes := make([]Note, len(i.Notes))
+		for n, note := range i.Notes {
+			note.CreatedAt = note.CreatedAt.In(loc)
+			if note.EditHistory != nil {
+				edits := make([]NoteEdit, len(note.EditHistory))
+				for e, edit := range note.EditHistory {
+					edit.EditedAt = edit.EditedAt.In(loc)
+					edits[e] = edit
+				}
+				note.EditHistory = edits
+			}
+			notes[n] = note
+		}
+		i.Notes = notes
+	}
+
+	if i.AssignmentHistory != nil {
+		history := make([]AssignmentChange, len(i.AssignmentHistory))
+		for c, change := range i.AssignmentHistory {
+			change.ChangedAt = change.ChangedAt.In(loc)
+			history[c] = change
+		}
+		i.AssignmentHistory = history
+	}
+
+	if i.Attachments != nil {
+		attachments := make([]Attachment, len(i.Attachments))
+		for a, attachment := range i.Attachments {
+			attachment.CreatedAt = attachment.CreatedAt.In(loc)
+			attachment.UploadedAt = timeIn(attachment.UploadedAt, loc)
+			attachments[a] = attachment
+		}
+		i.Attachments = attachments
+	}
+
+	if i.Postmortem != nil {
+		postmortem := *i.Postmortem
+		postmortem.CreatedAt = postmortem.CreatedAt.In(loc)
+		postmortem.UpdatedAt = postmortem.UpdatedAt.In(loc)
+		if postmortem.Timeline != nil {
+			timeline := make([]PostmortemEvent, len(postmortem.Timeline))
+			for e, event := range postmortem.Timeline {
+				event.At = event.At.In(loc)
+				timeline[e] = event
+			}
+			postmortem.Timeline = timeline
+		}
+		i.Postmortem = &postmortem
+	}
+	return i
+}
+
+// timeIn returns a copy of an optional timestamp expressed in loc
+func timeIn(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	local := t.In(loc)
+	return &local
+}
+
+// AttachmentStatus represents the upload state of an attachment
+type AttachmentStatus string
+
+const (
+	AttachmentPending  AttachmentStatus = "pending"
+	AttachmentUploaded AttachmentStatus = "uploaded"
+)
+
+// Attachment represents an evidence file stored in object storage
+type Attachment struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	FileName    string             `json:"file_name" bson:"file_name"`
+	ContentType string             `json:"content_type" bson:"content_type"`
+	ObjectKey   string             `json:"object_key" bson:"object_key"`
+	Status      AttachmentStatus   `json:"status" bson:"status"`
+	UploadedBy  string             `json:"uploaded_by" bson:"uploaded_by"` // Email of the uploader
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UploadedAt  *time.Time         `json:"uploaded_at,omitempty" bson:"uploaded_at,omitempty"`
 }
 
 type Watcher struct {
 	Email string `json:"email" bson:"email" validate:"required,email"`
+	Name  string `json:"name,omitempty" bson:"name,omitempty"`
 }
 
 // CreateIncidentRequest represents the request payload for creating an incident
 type CreateIncidentRequest struct {
 	Title       string           `json:"title" validate:"required,min=3,max=255"`
 	Severity    IncidentSeverity `json:"severity" validate:"required,oneof=low medium high critical"`
+	Priority    IncidentPriority `json:"priority"` // Defaults to the severity's priority, see IncidentSeverity.DefaultPriority
 	Description string           `json:"description"`
 	Notes       []Note           `json:"notes"`
 	AuthorEmail string           `json:"author_email" form:"author_email"` // Email of the creator
 	Assignee    string           `json:"assignee"`
+	Tags        []string         `json:"tags"`
+	Services    []string         `json:"services"` // Affected services
+	Watchers    []Watcher        `json:"watchers"` // Added alongside the author and the severity's default watchers
+
+	CustomFields map[string]interface{} `json:"custom_fields"`
+
+	Draft bool `json:"draft"` // Stage the incident without publishing it
+
+	ActivateAt *time.Time `json:"activate_at"` // Schedule the incident to open at this future time
+
+	ExternalID string `json:"-"` // Set by integrations correlating incidents with their tickets
+
+	IdempotencyKey string `json:"-"` // From the Idempotency-Key header; retries with the same key return the first incident
 }
 
 // UpdateIncidentStatusRequest represents the request payload for updating incident status
 type UpdateIncidentStatusRequest struct {
 	Status      IncidentStatus `json:"status" validate:"required,oneof=open in_progress resolved closed"`
 	AuthorEmail string         `json:"author_email" form:"author_email"` // Email of the creator
+	Note        string         `json:"note,omitempty"`                   // Saved with the status change; required for transitions listed in TRANSITION_NOTES, ignored when the status is unchanged
+}
+
+// StatusChangePreview describes what a status change would do, without applying it
+type StatusChangePreview struct {
+	From         IncidentStatus `json:"from"`
+	To           IncidentStatus `json:"to"`
+	Allowed      bool           `json:"allowed"`                 // Whether the change would be accepted as requested
+	Blockers     []string       `json:"blockers"`                // Why the change would be refused
+	RequiredNote NoteType       `json:"required_note,omitempty"` // Note type the change must carry, see TRANSITION_NOTES
+	SideEffects  []string       `json:"side_effects"`            // What applying the change would also do
+}
+
+// UpdateIncidentRequest represents the request payload for editing an incident's
+// details. Omitted fields are left unchanged; an empty assignee unassigns.
+type UpdateIncidentRequest struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	Assignee    *string `json:"assignee"`
+	AuthorEmail string  `json:"author_email"` // Recorded with an assignee change
+
+	TitleTruncated bool `json:"-"` // Set by the service when the title was shortened to the maximum length
 }
 
 // UpdateIncidentStatusRequest represents the request payload for updating incident status
@@ -86,11 +449,352 @@ type UpdateIncidentSeverityRequest struct {
 	AuthorEmail string           `json:"author_email" form:"author_email"` // Email of the creator
 }
 
+// UpdateIncidentPriorityRequest represents the request payload for updating incident priority
+type UpdateIncidentPriorityRequest struct {
+	Priority    IncidentPriority `json:"priority" validate:"required,oneof=P1 P2 P3 P4"`
+	AuthorEmail string           `json:"author_email" form:"author_email"` // Email of the creator
+}
+
 // AddNoteRequest represents the request payload for adding a note to an incident
 type AddNoteRequest struct {
-	Content     string   `json:"content" validate:"required,min=1,max=1000"`
-	AuthorEmail string   `json:"author_email" form:"author_email"` // Email of the creator
-	Type        NoteType `json:"type" validate:"required,oneof=update investigation resolution communication"`
+	Content     string         `json:"content" validate:"required,min=1,max=1000"`
+	AuthorEmail string         `json:"author_email" form:"author_email"` // Email of the creator
+	Type        NoteType       `json:"type" validate:"required,oneof=update investigation resolution communication"`
+	Visibility  NoteVisibility `json:"visibility"` // Defaults to the configured note visibility
+}
+
+// ImportNoteRequest is one note of a bulk import, optionally backdated
+type ImportNoteRequest struct {
+	AddNoteRequest
+	CreatedAt *time.Time `json:"created_at"` // Kept when set and not in the future, for backfilling history
+}
+
+// EditNoteRequest represents the request payload for editing a note
+type EditNoteRequest struct {
+	Content     string `json:"content" validate:"required,min=1,max=1000"`
+	AuthorEmail string `json:"author_email" form:"author_email"` // Email of the editor
+}
+
+// PresignAttachmentRequest represents the request payload for presigning an attachment upload
+type PresignAttachmentRequest struct {
+	FileName    string `json:"file_name" validate:"required"`
+	ContentType string `json:"content_type"`
+	AuthorEmail string `json:"author_email" form:"author_email"` // Email of the uploader
+}
+
+// PresignedAttachment represents a pending attachment and the URL to upload it to
+type PresignedAttachment struct {
+	UploadURL  string     `json:"upload_url"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	Attachment Attachment `json:"attachment"`
+}
+
+// ListIncidentsParams represents the filters for listing incidents
+type ListIncidentsParams struct {
+	Status   IncidentStatus   // Only incidents in this status
+	Severity IncidentSeverity // Only incidents of this severity
+	Assignee string           // Only incidents assigned to this email
+
+	HasNoteType     NoteType // Only incidents with at least one note of this type
+	MissingNoteType NoteType // Only incidents without any note of this type
+	SortField       string   // Field to sort by
+	SortDirection   int      // 1 ascending, -1 descending
+
+	NeedsAttention bool      // Only open incidents not updated since UpdatedBefore
+	UpdatedBefore  time.Time // Attention cutoff, set by the service from the configured threshold
+
+	CustomFields      map[string]interface{} // Exact matches on custom fields, typed by the service
+	CustomFieldFilter map[string]string      // Raw ?custom.<key>= values from the request
+
+	Drafts bool // List staged drafts instead of published incidents
+
+	Scheduled bool // List scheduled incidents instead of active ones
+
+	SLAStatus  SLAStatus   // Only unresolved incidents in this state against their SLA target
+	SLAWindows []SLAWindow // Creation ranges per severity in that state, set by the service from the targets
+
+	AnyOf [][]FilterCondition // OR groups from ?or=, each matching incidents that meet any of its conditions
+
+	Page PageParams // Page of results, set by the service; a zero PageSize returns every match
+}
+
+// FilterField is an incident field that can be used in an ?or= filter group
+type FilterField string
+
+const (
+	FilterStatus   FilterField = "status"
+	FilterSeverity FilterField = "severity"
+	FilterAssignee FilterField = "assignee"
+	FilterTag      FilterField = "tag"
+	FilterService  FilterField = "service"
+)
+
+// FilterCondition matches incidents whose field equals, or for tags and services
+// contains, the value
+type FilterCondition struct {
+	Field FilterField `json:"field" bson:"field"`
+	Value string      `json:"value" bson:"value"`
+}
+
+// SLAStatus is where an unresolved incident stands against its severity's resolution target
+type SLAStatus string
+
+const (
+	SLAOnTrack  SLAStatus = "on_track"
+	SLAAtRisk   SLAStatus = "at_risk" // Past the at-risk share of the target
+	SLABreached SLAStatus = "breached"
+)
+
+// SLAWindow selects incidents of a severity created after CreatedAfter and at or
+// before CreatedBefore; a zero bound leaves that side open
+type SLAWindow struct {
+	Severity      IncidentSeverity
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// AgeBucket represents the number of incidents within an age range
+type AgeBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// IncidentLoad is the severity-weighted operational load of the open incidents
+type IncidentLoad struct {
+	Load       int                      `json:"load"`
+	OpenCount  int                      `json:"open_count"`
+	BySeverity map[IncidentSeverity]int `json:"by_severity"` // Open incidents per severity
+	Weights    map[IncidentSeverity]int `json:"weights"`
+}
+
+// Page sizes for paginated endpoints
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// PageParams selects a 1-based page of results
+type PageParams struct {
+	Page     int
+	PageSize int
+}
+
+// Skip is the number of results before the page
+func (p PageParams) Skip() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// AssigneeStats counts the open incidents of an assignee; the empty assignee
+// groups unassigned incidents
+type AssigneeStats struct {
+	Assignee      string `json:"assignee" bson:"_id"`
+	OpenCount     int    `json:"open_count" bson:"open_count"`
+	CriticalCount int    `json:"critical_count" bson:"critical_count"`
+}
+
+// AssigneeStatsPage is one page of the open incidents per assignee, busiest first
+type AssigneeStatsPage struct {
+	Items    []AssigneeStats `json:"items"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Total    int             `json:"total"` // Assignees across all pages
+}
+
+// Pagination places a page of incidents within the full list
+type Pagination struct {
+	Page    int  `json:"page"`
+	Limit   int  `json:"limit"`
+	Total   int  `json:"total"`    // Incidents across all pages
+	HasMore bool `json:"has_more"` // Whether later pages hold more incidents
+}
+
+// IncidentPage is one page of the incident list
+type IncidentPage struct {
+	Items      []IncidentSummary
+	Pagination Pagination
+}
+
+// IncidentStats represents the summary statistics for incidents
+type IncidentStats struct {
+	OpenByAge []AgeBucket `json:"open_by_age"`
+}
+
+// NoteTemplate is the structure suggested, and optionally required, for notes of a type
+type NoteTemplate struct {
+	Type     NoteType `json:"type"`
+	Name     string   `json:"name"`
+	Sections []string `json:"sections"` // Headings the note must contain when templates are enforced
+	Body     string   `json:"body"`     // Markdown skeleton with a heading per section
+}
+
+// AssignmentChange records an incident moving from one assignee to another
+type AssignmentChange struct {
+	From      string    `json:"from" bson:"from"`
+	To        string    `json:"to" bson:"to"`
+	ChangedBy string    `json:"changed_by,omitempty" bson:"changed_by,omitempty"`
+	ChangedAt time.Time `json:"changed_at" bson:"changed_at"`
+}
+
+// ActionItemStatus is the progress of a postmortem action item
+type ActionItemStatus string
+
+const (
+	ActionItemOpen       ActionItemStatus = "open"
+	ActionItemInProgress ActionItemStatus = "in_progress"
+	ActionItemDone       ActionItemStatus = "done"
+)
+
+// ValidActionItemStatuses returns all valid action item statuses
+func ValidActionItemStatuses() []ActionItemStatus {
+	return []ActionItemStatus{
+		ActionItemOpen,
+		ActionItemInProgress,
+		ActionItemDone,
+	}
+}
+
+// IsValid checks if the provided action item status is valid
+func (s ActionItemStatus) IsValid() bool {
+	for _, status := range ValidActionItemStatuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Postmortem is the blameless review of a resolved incident
+type Postmortem struct {
+	Summary     string            `json:"summary" bson:"summary"`
+	Timeline    []PostmortemEvent `json:"timeline" bson:"timeline"`
+	RootCause   string            `json:"root_cause" bson:"root_cause"`
+	ActionItems []ActionItem      `json:"action_items" bson:"action_items"`
+	AuthorEmail string            `json:"author_email,omitempty" bson:"author_email,omitempty"`
+	CreatedAt   time.Time         `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" bson:"updated_at"`
+}
+
+// PostmortemEvent is one moment in a postmortem's timeline
+type PostmortemEvent struct {
+	At          time.Time `json:"at" bson:"at"`
+	Description string    `json:"description" bson:"description"`
+}
+
+// ActionItem is follow-up work agreed in a postmortem
+type ActionItem struct {
+	Description string           `json:"description" bson:"description"`
+	Owner       string           `json:"owner" bson:"owner"`
+	Status      ActionItemStatus `json:"status" bson:"status"` // Defaults to open
+}
+
+// PostmortemRequest represents the request payload for attaching a postmortem
+type PostmortemRequest struct {
+	Summary     string            `json:"summary"`
+	Timeline    []PostmortemEvent `json:"timeline"`
+	RootCause   string            `json:"root_cause"`
+	ActionItems []ActionItem      `json:"action_items"`
+	AuthorEmail string            `json:"author_email"`
+}
+
+// BulkReassignRequest reassigns the incidents selected either by key or by filter
+type BulkReassignRequest struct {
+	Keys        []int               `json:"keys"`
+	Filter      *BulkReassignFilter `json:"filter"`
+	Assignee    string              `json:"assignee"`
+	AuthorEmail string              `json:"author_email"`
+}
+
+// BulkReassignFilter selects incidents for bulk reassignment; set fields are combined with AND
+type BulkReassignFilter struct {
+	Assignee string           `json:"assignee"` // Current assignee
+	Status   IncidentStatus   `json:"status"`
+	Severity IncidentSeverity `json:"severity"`
+}
+
+// BulkUpdateResult counts the incidents a bulk operation matched and changed
+type BulkUpdateResult struct {
+	Matched  int64 `json:"matched"`
+	Modified int64 `json:"modified"`
+}
+
+// BackfillResult reports what a backfill of derived fields filled in
+type BackfillResult struct {
+	Scanned        int `json:"scanned"`         // Incidents missing a derived field
+	FirstResponses int `json:"first_responses"` // Incidents given a first response from their notes
+}
+
+// IncidentFacets lists the distinct values in use, for building filter dropdowns
+type IncidentFacets struct {
+	Assignees []string `json:"assignees"`
+	Tags      []string `json:"tags"`
+	Services  []string `json:"services"`
+}
+
+// yearlyKeyFactor separates the year from the sequence in yearly incident keys,
+// so 2024-0001 is stored as 2024000001
+const yearlyKeyFactor = 1000000
+
+// YearlyIncidentKey builds the incident key for a sequence within a year
+func YearlyIncidentKey(year, sequence int) int {
+	return year*yearlyKeyFactor + sequence
+}
+
+// FormatIncidentKey renders an incident key for display, turning a stored yearly
+// key such as 2024000001 back into 2024-0001. Global keys are shown as they are.
+func FormatIncidentKey(key int) string {
+	if key < 1000*yearlyKeyFactor {
+		return strconv.Itoa(key)
+	}
+	return fmt.Sprintf("%d-%04d", key/yearlyKeyFactor, key%yearlyKeyFactor)
+}
+
+// ParseIncidentKey parses a numeric incident key or a yearly key such as 2024-0001
+func ParseIncidentKey(value string) (int, error) {
+	year, sequence, yearly := strings.Cut(value, "-")
+	if !yearly {
+		return strconv.Atoi(value)
+	}
+
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(sequence)
+	if err != nil {
+		return 0, err
+	}
+	if n < 1 || n >= yearlyKeyFactor {
+		return 0, fmt.Errorf("sequence %d out of range", n)
+	}
+	return YearlyIncidentKey(y, n), nil
+}
+
+// TrendInterval is the bucket size used for incident trends
+type TrendInterval string
+
+const (
+	TrendDay   TrendInterval = "day"
+	TrendWeek  TrendInterval = "week"
+	TrendMonth TrendInterval = "month"
+)
+
+// IsValid checks if the provided trend interval is supported
+func (i TrendInterval) IsValid() bool {
+	return i == TrendDay || i == TrendWeek || i == TrendMonth
+}
+
+// TrendParams selects the interval and created_at range [From, To) of a trend
+type TrendParams struct {
+	Interval TrendInterval
+	From     time.Time
+	To       time.Time
+}
+
+// TrendPoint counts the incidents created in one period, by severity
+type TrendPoint struct {
+	Period     time.Time                `json:"period"`
+	BySeverity map[IncidentSeverity]int `json:"by_severity"`
+	Total      int                      `json:"total"`
 }
 
 // ValidSeverities returns a slice of valid severity values
@@ -103,6 +807,16 @@ func ValidSeverities() []IncidentSeverity {
 	}
 }
 
+// ValidPriorities returns a slice of valid priority values, most urgent first
+func ValidPriorities() []IncidentPriority {
+	return []IncidentPriority{
+		P1,
+		P2,
+		P3,
+		P4,
+	}
+}
+
 // ValidStatuses returns a slice of valid status values
 func ValidStatuses() []IncidentStatus {
 	return []IncidentStatus{
@@ -113,6 +827,16 @@ func ValidStatuses() []IncidentStatus {
 	}
 }
 
+// ValidNoteTypes returns a slice of valid note type values
+func ValidNoteTypes() []NoteType {
+	return []NoteType{
+		Update,
+		Investigation,
+		Resolution,
+		Communication,
+	}
+}
+
 // IsValidSeverity checks if the provided severity is valid
 func (s IncidentSeverity) IsValid() bool {
 	for _, severity := range ValidSeverities() {
@@ -123,6 +847,41 @@ func (s IncidentSeverity) IsValid() bool {
 	return false
 }
 
+// Rank returns the severity's position from low (0) to critical, or -1 when invalid
+func (s IncidentSeverity) Rank() int {
+	for i, severity := range ValidSeverities() {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// DefaultPriority returns the priority given to new incidents of this severity
+// when none is supplied: critical is P1 down to low as P4
+func (s IncidentSeverity) DefaultPriority() IncidentPriority {
+	switch s {
+	case Critical:
+		return P1
+	case High:
+		return P2
+	case Medium:
+		return P3
+	default:
+		return P4
+	}
+}
+
+// IsValid checks if the provided priority is valid
+func (p IncidentPriority) IsValid() bool {
+	for _, priority := range ValidPriorities() {
+		if p == priority {
+			return true
+		}
+	}
+	return false
+}
+
 // IsValidStatus checks if the provided status is valid
 func (s IncidentStatus) IsValid() bool {
 	for _, status := range ValidStatuses() {
@@ -132,3 +891,176 @@ func (s IncidentStatus) IsValid() bool {
 	}
 	return false
 }
+
+// IsValid checks if the provided note type is valid
+func (t NoteType) IsValid() bool {
+	for _, noteType := range ValidNoteTypes() {
+		if t == noteType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidSLAStatuses returns a slice of valid SLA status values
+func ValidSLAStatuses() []SLAStatus {
+	return []SLAStatus{
+		SLAOnTrack,
+		SLAAtRisk,
+		SLABreached,
+	}
+}
+
+// IsValid checks if the provided SLA status is valid
+func (s SLAStatus) IsValid() bool {
+	for _, status := range ValidSLAStatuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidFilterFields returns a slice of the fields usable in filter groups
+func ValidFilterFields() []FilterField {
+	return []FilterField{
+		FilterStatus,
+		FilterSeverity,
+		FilterAssignee,
+		FilterTag,
+		FilterService,
+	}
+}
+
+// IsValid checks if the provided filter field is supported
+func (f FilterField) IsValid() bool {
+	for _, field := range ValidFilterFields() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidNoteVisibilities returns a slice of valid note visibility values
+func ValidNoteVisibilities() []NoteVisibility {
+	return []NoteVisibility{
+		NoteInternal,
+		NotePublic,
+	}
+}
+
+// IsValid checks if the provided note visibility is valid
+func (v NoteVisibility) IsValid() bool {
+	for _, visibility := range ValidNoteVisibilities() {
+		if v == visibility {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidSearchFields returns a slice of valid search field values
+func ValidSearchFields() []SearchField {
+	return []SearchField{
+		SearchTitle,
+		SearchDescription,
+		SearchNotes,
+	}
+}
+
+// IsValid checks if the provided search field is valid
+func (f SearchField) IsValid() bool {
+	for _, field := range ValidSearchFields() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// severityAliases maps legacy severity values stored by older schemas to their current value
+var severityAliases = map[string]IncidentSeverity{
+	"minor": Low,
+	"med":   Medium,
+	"major": High,
+	"crit":  Critical,
+}
+
+// statusAliases maps legacy status values stored by older schemas to their current value
+var statusAliases = map[string]IncidentStatus{
+	"new":        Open,
+	"inprogress": InProgress,
+	"fixed":      Resolved,
+	"done":       Closed,
+}
+
+// StoredSeverities lists the normalized stored values that decode to a valid
+// severity, including legacy aliases
+func StoredSeverities() []string {
+	var values []string
+	for _, severity := range ValidSeverities() {
+		values = append(values, string(severity))
+	}
+	aliases := len(values)
+	for alias := range severityAliases {
+		values = append(values, alias)
+	}
+	slices.Sort(values[aliases:])
+	return values
+}
+
+// StoredStatuses lists the normalized stored values that decode to a known
+// status, including legacy aliases and scheduled, which clients cannot set
+func StoredStatuses() []string {
+	values := []string{string(Scheduled)}
+	for _, status := range ValidStatuses() {
+		values = append(values, string(status))
+	}
+	aliases := len(values)
+	for alias := range statusAliases {
+		values = append(values, alias)
+	}
+	slices.Sort(values[aliases:])
+	return values
+}
+
+// UnmarshalBSONValue decodes a stored severity, normalizing case and known legacy aliases.
+// Unknown values are kept as-is so callers can detect them with IsValid.
+func (s *IncidentSeverity) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := decodeEnumString(t, data)
+	*s = IncidentSeverity(value)
+	if alias, ok := severityAliases[value]; ok {
+		*s = alias
+	}
+	return nil
+}
+
+// UnmarshalBSONValue decodes a stored status, normalizing case and known legacy aliases.
+// Unknown values are kept as-is so callers can detect them with IsValid.
+func (s *IncidentStatus) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	value := decodeEnumString(t, data)
+	*s = IncidentStatus(value)
+	if alias, ok := statusAliases[value]; ok {
+		*s = alias
+	}
+	return nil
+}
+
+// decodeEnumString decodes a BSON string into its normalized form (lowercase, '_' separated).
+// Any other type decodes to its extended JSON form, which is never a valid value, so
+// one malformed document is reported by IsValid rather than failing a whole list.
+func decodeEnumString(t bsontype.Type, data []byte) string {
+	raw := bson.RawValue{Type: t, Value: data}
+	if t == bsontype.Null {
+		return ""
+	}
+
+	value, ok := raw.StringValueOK()
+	if !ok {
+		return raw.String()
+	}
+
+	value = strings.ToLower(strings.TrimSpace(value))
+	return strings.NewReplacer("-", "_", " ", "_").Replace(value)
+}
@@ -0,0 +1,381 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestIncident_UnmarshalBSON_NormalizesLegacyValues(t *testing.T) {
+	tests := []struct {
+		name             string
+		storedSeverity   string
+		storedStatus     string
+		expectedSeverity IncidentSeverity
+		expectedStatus   IncidentStatus
+	}{
+		{name: "current values", storedSeverity: "high", storedStatus: "in_progress", expectedSeverity: High, expectedStatus: InProgress},
+		{name: "uppercase values", storedSeverity: "CRITICAL", storedStatus: "Open", expectedSeverity: Critical, expectedStatus: Open},
+		{name: "hyphenated status", storedSeverity: "low", storedStatus: "in-progress", expectedSeverity: Low, expectedStatus: InProgress},
+		{name: "legacy aliases", storedSeverity: "major", storedStatus: "fixed", expectedSeverity: High, expectedStatus: Resolved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			data, err := bson.Marshal(bson.M{"title": "Legacy", "severity": tt.storedSeverity, "status": tt.storedStatus})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Act
+			var incident Incident
+			err = bson.Unmarshal(data, &incident)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if incident.Severity != tt.expectedSeverity {
+				t.Errorf("Expected severity %s, got %s", tt.expectedSeverity, incident.Severity)
+			}
+			if incident.Status != tt.expectedStatus {
+				t.Errorf("Expected status %s, got %s", tt.expectedStatus, incident.Status)
+			}
+		})
+	}
+}
+
+func TestIncident_UnmarshalBSON_KeepsUnknownValuesInvalid(t *testing.T) {
+	// Arrange
+	data, err := bson.Marshal(bson.M{"title": "Legacy", "severity": "sev0", "status": "triaged"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	var incident Incident
+	err = bson.Unmarshal(data, &incident)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if incident.Severity.IsValid() {
+		t.Errorf("Expected severity %s to be invalid", incident.Severity)
+	}
+	if incident.Status.IsValid() {
+		t.Errorf("Expected status %s to be invalid", incident.Status)
+	}
+}
+
+func TestIncident_UnmarshalBSON_NonStringValuesAreInvalid(t *testing.T) {
+	// Arrange
+	data, err := bson.Marshal(bson.M{"title": "Legacy", "severity": 3, "status": bson.A{"open"}})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Act
+	var incident Incident
+	err = bson.Unmarshal(data, &incident)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the document to decode, got %v", err)
+	}
+	if incident.Severity.IsValid() {
+		t.Errorf("Expected severity %s to be invalid", incident.Severity)
+	}
+	if incident.Status.IsValid() {
+		t.Errorf("Expected status %s to be invalid", incident.Status)
+	}
+}
+
+func TestIncident_InLocation_ConvertsToNewYork(t *testing.T) {
+	// Arrange
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Expected America/New_York to load, got %v", err)
+	}
+	stored := time.Date(2025, 1, 15, 17, 30, 0, 0, time.UTC)
+	incident := Incident{
+		CreatedAt: stored,
+		UpdatedAt: stored,
+		Notes:     []Note{{Content: "Investigating", CreatedAt: stored}},
+	}
+
+	// Act
+	localized := incident.InLocation(loc)
+
+	// Assert
+	expected := "2025-01-15T12:30:00-05:00"
+	if got := localized.CreatedAt.Format(time.RFC3339); got != expected {
+		t.Errorf("Expected created_at %s, got %s", expected, got)
+	}
+	if got := localized.Notes[0].CreatedAt.Format(time.RFC3339); got != expected {
+		t.Errorf("Expected note created_at %s, got %s", expected, got)
+	}
+	if !localized.CreatedAt.Equal(stored) {
+		t.Error("Expected the same instant after conversion")
+	}
+	if incident.Notes[0].CreatedAt.Location() != time.UTC {
+		t.Error("Expected the original incident to stay UTC")
+	}
+}
+
+func TestIncident_InLocation_ConvertsEveryTimestamp(t *testing.T) {
+	// Arrange
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("Expected America/New_York to load, got %v", err)
+	}
+	stored := time.Date(2025, 1, 15, 17, 30, 0, 0, time.UTC)
+	at := func() *time.Time {
+		copied := stored
+		return &copied
+	}
+	incident := Incident{
+		ActivateAt:        at(),
+		StatusChangedAt:   at(),
+		SeverityChangedAt: at(),
+		FirstResponseAt:   at(),
+		ArchivedAt:        at(),
+		StaleNotifiedAt:   at(),
+		Notes:             []Note{{CreatedAt: stored, EditHistory: []NoteEdit{{EditedAt: stored}}}},
+		AssignmentHistory: []AssignmentChange{{ChangedAt: stored}},
+		Attachments:       []Attachment{{CreatedAt: stored, UploadedAt: at()}},
+		Postmortem:        &Postmortem{CreatedAt: stored, UpdatedAt: stored, Timeline: []PostmortemEvent{{At: stored}}},
+	}
+
+	// Act
+	localized := incident.InLocation(loc)
+
+	// Assert
+	timestamps := map[string]time.Time{
+		"activate_at":                   *localized.ActivateAt,
+		"status_changed_at":             *localized.StatusChangedAt,
+		"severity_changed_at":           *localized.SeverityChangedAt,
+		"first_response_at":             *localized.FirstResponseAt,
+		"archived_at":                   *localized.ArchivedAt,
+		"stale_notified_at":             *localized.StaleNotifiedAt,
+		"notes.edit_history.edited_at":  localized.Notes[0].EditHistory[0].EditedAt,
+		"assignment_history.changed_at": localized.AssignmentHistory[0].ChangedAt,
+		"attachments.created_at":        localized.Attachments[0].CreatedAt,
+		"attachments.uploaded_at":       *localized.Attachments[0].UploadedAt,
+		"postmortem.created_at":         localized.Postmortem.CreatedAt,
+		"postmortem.updated_at":         localized.Postmortem.UpdatedAt,
+		"postmortem.timeline.at":        localized.Postmortem.Timeline[0].At,
+	}
+	for field, timestamp := range timestamps {
+		if timestamp.Location() != loc {
+			t.Errorf("Expected %s in America/New_York, got %s", field, timestamp.Location())
+		}
+	}
+	if incident.FirstResponseAt.Location() != time.UTC || incident.Notes[0].EditHistory[0].EditedAt.Location() != time.UTC {
+		t.Error("Expected the original incident to stay UTC")
+	}
+}
+
+func TestNote_Edited_KeepsHistory(t *testing.T) {
+	// Arrange
+	note := Note{Content: "Database CPU high", AuthorEmail: "alice@example.com"}
+	first := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	second := first.Add(10 * time.Minute)
+
+	// Act
+	once := note.Edited("Database CPU at 95%", "bob@example.com", first)
+	twice := once.Edited("Database CPU at 95%, failing over", "carol@example.com", second)
+
+	// Assert
+	if twice.Content != "Database CPU at 95%, failing over" {
+		t.Errorf("Expected latest content, got %q", twice.Content)
+	}
+	if len(twice.EditHistory) != 2 {
+		t.Fatalf("Expected 2 history entries, got %d", len(twice.EditHistory))
+	}
+	expected := []NoteEdit{
+		{PreviousContent: "Database CPU high", EditorEmail: "bob@example.com", EditedAt: first},
+		{PreviousContent: "Database CPU at 95%", EditorEmail: "carol@example.com", EditedAt: second},
+	}
+	for i := range expected {
+		if twice.EditHistory[i] != expected[i] {
+			t.Errorf("Expected history entry %d to be %+v, got %+v", i, expected[i], twice.EditHistory[i])
+		}
+	}
+	if len(once.EditHistory) != 1 {
+		t.Errorf("Expected the earlier version to keep 1 history entry, got %d", len(once.EditHistory))
+	}
+}
+
+func TestParseIncidentKey(t *testing.T) {
+	tests := []struct {
+		value     string
+		expected  int
+		expectErr bool
+	}{
+		{value: "42", expected: 42},
+		{value: "2024-0001", expected: 2024000001},
+		{value: "2025-0123", expected: 2025000123},
+		{value: "2025-0000", expectErr: true},
+		{value: "abc", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			// Act
+			key, err := ParseIncidentKey(tt.value)
+
+			// Assert
+			if tt.expectErr {
+				if err == nil {
+					t.Errorf("Expected an error, got key %d", key)
+				}
+				return
+			}
+			if err != nil || key != tt.expected {
+				t.Errorf("Expected %d, got %d (%v)", tt.expected, key, err)
+			}
+		})
+	}
+}
+
+func TestFormatIncidentKey(t *testing.T) {
+	tests := []struct {
+		key      int
+		expected string
+	}{
+		{key: 42, expected: "42"},
+		{key: 2024000001, expected: "2024-0001"},
+		{key: 2025000123, expected: "2025-0123"},
+		{key: 2025012345, expected: "2025-12345"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			// Act
+			formatted := FormatIncidentKey(tt.key)
+
+			// Assert
+			if formatted != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, formatted)
+			}
+			if parsed, err := ParseIncidentKey(formatted); err != nil || parsed != tt.key {
+				t.Errorf("Expected %q to parse back to %d, got %d (%v)", formatted, tt.key, parsed, err)
+			}
+		})
+	}
+}
+
+func TestIncidentJSON_IncludesFormattedKey(t *testing.T) {
+	// Arrange
+	summary := IncidentSummary{IncidentKey: 2024000001, Title: "Checkout failures"}
+	values := map[string]interface{}{
+		"incident":         Incident{IncidentKey: 2024000001, Title: "Checkout failures"},
+		"summary":          summary,
+		"search result":    IncidentSearchResult{IncidentSummary: summary, Score: 1.5},
+		"related incident": RelatedIncident{IncidentSummary: summary, Overlap: 2},
+	}
+
+	for name, value := range values {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			body, err := json.Marshal(value)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			var decoded map[string]interface{}
+			if err := json.Unmarshal(body, &decoded); err != nil {
+				t.Fatalf("Expected valid JSON, got %v", err)
+			}
+			if decoded["key"] != "2024-0001" {
+				t.Errorf("Expected key 2024-0001, got %v", decoded["key"])
+			}
+			if decoded["incident_key"] != 2024000001.0 || decoded["title"] != "Checkout failures" {
+				t.Errorf("Expected the stored fields to be kept, got %s", body)
+			}
+		})
+	}
+
+	t.Run("wrapper fields are kept", func(t *testing.T) {
+		// Act
+		search, _ := json.Marshal(IncidentSearchResult{IncidentSummary: summary, Score: 1.5})
+		related, _ := json.Marshal(RelatedIncident{IncidentSummary: summary, Overlap: 2})
+
+		// Assert
+		var decoded map[string]interface{}
+		if err := json.Unmarshal(search, &decoded); err != nil || decoded["score"] != 1.5 {
+			t.Errorf("Expected score 1.5, got %s", search)
+		}
+		if err := json.Unmarshal(related, &decoded); err != nil || decoded["overlap"] != 2.0 {
+			t.Errorf("Expected overlap 2, got %s", related)
+		}
+	})
+}
+
+func TestIncidentSeverity_DefaultPriority(t *testing.T) {
+	tests := []struct {
+		severity IncidentSeverity
+		expected IncidentPriority
+	}{
+		{severity: Critical, expected: P1},
+		{severity: High, expected: P2},
+		{severity: Medium, expected: P3},
+		{severity: Low, expected: P4},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.severity), func(t *testing.T) {
+			// Act
+			priority := tt.severity.DefaultPriority()
+
+			// Assert
+			if priority != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, priority)
+			}
+			if !priority.IsValid() {
+				t.Errorf("Expected %s to be a valid priority", priority)
+			}
+		})
+	}
+}
+
+func TestNotesWithVisibility(t *testing.T) {
+	// Arrange
+	notes := []Note{
+		{Content: "legacy", Visibility: ""},
+		{Content: "internal", Visibility: NoteInternal},
+		{Content: "public", Visibility: NotePublic},
+	}
+
+	tests := []struct {
+		name       string
+		visibility NoteVisibility
+		expected   []string
+	}{
+		{name: "public excludes internal", visibility: NotePublic, expected: []string{"public"}},
+		{name: "internal includes unset", visibility: NoteInternal, expected: []string{"legacy", "internal"}},
+		{name: "empty returns all", visibility: "", expected: []string{"legacy", "internal", "public"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			filtered := NotesWithVisibility(notes, tt.visibility)
+
+			// Assert
+			if len(filtered) != len(tt.expected) {
+				t.Fatalf("Expected %d notes, got %d", len(tt.expected), len(filtered))
+			}
+			for i, content := range tt.expected {
+				if filtered[i].Content != content {
+					t.Errorf("Expected note %d to be %q, got %q", i, content, filtered[i].Content)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package models
+
+// JiraIssueEvent is the payload Jira sends to issue webhooks
+type JiraIssueEvent struct {
+	WebhookEvent string    `json:"webhookEvent"` // e.g. jira:issue_created, jira:issue_updated
+	Issue        JiraIssue `json:"issue"`
+}
+
+// JiraIssue is the issue a Jira webhook event is about
+type JiraIssue struct {
+	Key    string          `json:"key"`
+	Fields JiraIssueFields `json:"fields"`
+}
+
+// JiraIssueFields holds the issue fields mapped onto incidents
+type JiraIssueFields struct {
+	Summary     string     `json:"summary"`
+	Description string     `json:"description"`
+	Priority    *JiraNamed `json:"priority"`
+	Status      *JiraNamed `json:"status"`
+}
+
+// JiraNamed is a Jira field value identified by name, such as a priority or status
+type JiraNamed struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// OutageStatus represents the status of an outage
+type OutageStatus string
+
+const (
+	OutageOpen   OutageStatus = "open"
+	OutageClosed OutageStatus = "closed"
+)
+
+// Outage represents a parent record grouping incidents that share a root cause
+type Outage struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID    string             `json:"tenant_id,omitempty" bson:"tenant_id,omitempty"` // Owning tenant in multi-tenant deployments
+	Title       string             `json:"title" bson:"title" validate:"required,min=3,max=255"`
+	Description string             `json:"description" bson:"description"`
+	Status      OutageStatus       `json:"status" bson:"status"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// SetTenantID records the tenant that owns the outage
+func (o *Outage) SetTenantID(id string) {
+	o.TenantID = id
+}
+
+// OutageWithIncidents represents an outage together with its child incidents
+type OutageWithIncidents struct {
+	Outage
+	Incidents []Incident `json:"incidents"`
+}
+
+// CreateOutageRequest represents the request payload for creating an outage
+type CreateOutageRequest struct {
+	Title       string `json:"title" validate:"required,min=3,max=255"`
+	Description string `json:"description"`
+}
+
+// CloseOutageRequest represents the request payload for closing an outage
+type CloseOutageRequest struct {
+	CloseIncidents bool   `json:"close_incidents"` // Also close every child incident
+	AuthorEmail    string `json:"author_email" form:"author_email"`
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SearchFilter is a reusable set of incident list filters, mirroring the query
+// parameters of GET /incidents
+type SearchFilter struct {
+	Status          IncidentStatus      `json:"status,omitempty" bson:"status,omitempty"`
+	Severity        IncidentSeverity    `json:"severity,omitempty" bson:"severity,omitempty"`
+	Assignee        string              `json:"assignee,omitempty" bson:"assignee,omitempty"`
+	HasNoteType     NoteType            `json:"has_note_type,omitempty" bson:"has_note_type,omitempty"`
+	MissingNoteType NoteType            `json:"missing_note_type,omitempty" bson:"missing_note_type,omitempty"`
+	NeedsAttention  bool                `json:"needs_attention,omitempty" bson:"needs_attention,omitempty"`
+	Drafts          bool                `json:"drafts,omitempty" bson:"drafts,omitempty"`
+	Scheduled       bool                `json:"scheduled,omitempty" bson:"scheduled,omitempty"`
+	SLA             SLAStatus           `json:"sla,omitempty" bson:"sla,omitempty"`
+	CustomFields    map[string]string   `json:"custom_fields,omitempty" bson:"custom_fields,omitempty"` // Raw values keyed by custom field, as in ?custom.<key>=
+	Or              [][]FilterCondition `json:"or,omitempty" bson:"or,omitempty"`                       // OR groups, one per ?or= parameter
+}
+
+// ListParams converts the filter into incident list parameters
+func (f SearchFilter) ListParams() ListIncidentsParams {
+	return ListIncidentsParams{
+		Status:            f.Status,
+		Severity:          f.Severity,
+		Assignee:          f.Assignee,
+		HasNoteType:       f.HasNoteType,
+		MissingNoteType:   f.MissingNoteType,
+		NeedsAttention:    f.NeedsAttention,
+		Drafts:            f.Drafts,
+		Scheduled:         f.Scheduled,
+		SLAStatus:         f.SLA,
+		CustomFieldFilter: f.CustomFields,
+		AnyOf:             f.Or,
+	}
+}
+
+// SavedSearch is a named filter set that can be run against the incident list
+type SavedSearch struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	TenantID  string             `json:"tenant_id,omitempty" bson:"tenant_id,omitempty"` // Owning tenant in multi-tenant deployments
+	Name      string             `json:"name" bson:"name"`
+	Filter    SearchFilter       `json:"filter" bson:"filter"`
+	CreatedBy string             `json:"created_by,omitempty" bson:"created_by,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// SetTenantID records the tenant that owns the saved search
+func (s *SavedSearch) SetTenantID(id string) {
+	s.TenantID = id
+}
+
+// SavedSearchRequest represents the request payload for creating or replacing a saved search
+type SavedSearchRequest struct {
+	Name        string       `json:"name"`
+	Filter      SearchFilter `json:"filter"`
+	AuthorEmail string       `json:"author_email"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// PublicIncident is the sanitized view of an incident served on the public status
+// page. It deliberately omits internal notes, note authors, watchers, the assignee
+// and the creator.
+type PublicIncident struct {
+	IncidentKey int              `json:"incident_key"`
+	Key         string           `json:"key"` // IncidentKey formatted for display, e.g. 2024-0001
+	Title       string           `json:"title"`
+	Severity    IncidentSeverity `json:"severity"`
+	Status      IncidentStatus   `json:"status"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Notes       []PublicNote     `json:"notes"`
+	Timeline    []TimelineEntry  `json:"timeline"`
+}
+
+// PublicNote is a public note without its author or edit history
+type PublicNote struct {
+	Content   string    `json:"content"`
+	Type      NoteType  `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TimelineEntry is one step in an incident's public timeline
+type TimelineEntry struct {
+	At    time.Time `json:"at"`
+	Event string    `json:"event"`
+}
+
+// NewPublicIncident builds the public view of the incident, keeping only public
+// notes in chronological order
+func NewPublicIncident(incident *Incident) PublicIncident {
+	notes := NotesWithVisibility(incident.Notes, NotePublic)
+	sorted := make([]Note, len(notes))
+	copy(sorted, notes)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].CreatedAt.Before(sorted[b].CreatedAt)
+	})
+
+	public := PublicIncident{
+		IncidentKey: incident.IncidentKey,
+		Key:         FormatIncidentKey(incident.IncidentKey),
+		Title:       incident.Title,
+		Severity:    incident.Severity,
+		Status:      incident.Status,
+		CreatedAt:   incident.CreatedAt,
+		UpdatedAt:   incident.UpdatedAt,
+		Notes:       []PublicNote{},
+		Timeline:    []TimelineEntry{{At: incident.CreatedAt, Event: "Incident reported"}},
+	}
+
+	for _, note := range sorted {
+		public.Notes = append(public.Notes, PublicNote{Content: note.Content, Type: note.Type, CreatedAt: note.CreatedAt})
+		public.Timeline = append(public.Timeline, TimelineEntry{At: note.CreatedAt, Event: "Update posted"})
+	}
+	if incident.StatusChangedAt != nil && incident.Status != Open {
+		public.Timeline = append(public.Timeline, TimelineEntry{At: *incident.StatusChangedAt, Event: "Status changed to " + string(incident.Status)})
+		sort.SliceStable(public.Timeline, func(a, b int) bool {
+			return public.Timeline[a].At.Before(public.Timeline[b].At)
+		})
+	}
+
+	return public
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPublicIncident_OmitsInternalFields(t *testing.T) {
+	// Arrange
+	created := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	changed := created.Add(30 * time.Minute)
+	incident := &Incident{
+		IncidentKey: 42,
+		Title:       "Checkout latency",
+		Severity:    High,
+		Status:      InProgress,
+		CreatedAt:   created,
+		UpdatedAt:   changed,
+		CreatedBy:   "creator@example.com",
+		Assignee:    "oncall@example.com",
+		WatchList:   []Watcher{{Email: "watcher@example.com"}},
+		Notes: []Note{
+			{Content: "Root cause is the payments DB", AuthorEmail: "sre@example.com", Visibility: NoteInternal, CreatedAt: created.Add(10 * time.Minute)},
+			{Content: "Legacy note without visibility", AuthorEmail: "sre@example.com", CreatedAt: created.Add(15 * time.Minute)},
+			{Content: "We are investigating slow checkouts", AuthorEmail: "comms@example.com", Type: Communication, Visibility: NotePublic, CreatedAt: created.Add(20 * time.Minute)},
+		},
+		StatusChangedAt: &changed,
+	}
+
+	// Act
+	public := NewPublicIncident(incident)
+	body, err := json.Marshal(public)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected the public view to marshal, got %v", err)
+	}
+	for _, leaked := range []string{"creator@example.com", "oncall@example.com", "watcher@example.com", "sre@example.com", "comms@example.com", "Root cause", "Legacy note", "assignee", "watchlist", "author_email"} {
+		if strings.Contains(string(body), leaked) {
+			t.Errorf("Expected the public view to omit %q, got %s", leaked, body)
+		}
+	}
+	if len(public.Notes) != 1 || public.Notes[0].Content != "We are investigating slow checkouts" {
+		t.Fatalf("Expected only the public note, got %+v", public.Notes)
+	}
+	if len(public.Timeline) != 3 {
+		t.Fatalf("Expected 3 timeline entries, got %+v", public.Timeline)
+	}
+	if public.Timeline[2].Event != "Status changed to in_progress" {
+		t.Errorf("Expected the status change last, got %q", public.Timeline[2].Event)
+	}
+}
+
+func TestNewPublicIncident_FormatsYearlyKey(t *testing.T) {
+	// Arrange
+	incident := &Incident{IncidentKey: YearlyIncidentKey(2024, 1), Title: "Checkout latency", Status: Open}
+
+	// Act
+	public := NewPublicIncident(incident)
+
+	// Assert
+	if public.Key != "2024-0001" {
+		t.Errorf("Expected key 2024-0001, got %q", public.Key)
+	}
+}
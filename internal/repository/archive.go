@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/models"
+)
+
+// ArchiveCollection is the default collection closed incidents are archived to
+const ArchiveCollection = "incidents_archive"
+
+// WithArchive makes lookups fall back to the archive collection for incidents no
+// longer in the incidents collection. Lists and updates only see the incidents
+// collection, so archived incidents are read-only.
+func (r *IncidentRepository) WithArchive(archive *mongo.Collection) *IncidentRepository {
+	r.archive = &tenantCollection{archive}
+	return r
+}
+
+// FindArchivable returns up to limit closed incidents not updated since
+// updatedBefore, oldest first
+func (r *IncidentRepository) FindArchivable(ctx context.Context, updatedBefore time.Time, limit int64) ([]models.Incident, error) {
+	filter := bson.M{
+		"status":     models.Closed,
+		"updated_at": bson.M{"$lte": updatedBefore},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: 1}}).
+		SetLimit(limit)
+
+	var incidents []models.Incident
+	err := timed("find_archivable", func() error {
+		cursor, err := r.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to find archivable incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode archivable incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// Archive moves the incident to the archive collection. The copy is upserted
+// before the original is deleted, so a move interrupted in between is completed
+// by the next run and the incident is never missing from both collections.
+func (r *IncidentRepository) Archive(ctx context.Context, incident *models.Incident, now time.Time) error {
+	if r.archive == nil {
+		return fmt.Errorf("no archive collection configured")
+	}
+
+	archived := *incident
+	archived.ArchivedAt = &now
+	err := timed("archive", func() error {
+		_, err := r.archive.ReplaceOne(ctx, bson.M{"_id": incident.ID}, &archived, options.Replace().SetUpsert(true))
+		if err != nil {
+			return fmt.Errorf("failed to copy incident to archive: %w", err)
+		}
+		if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": incident.ID}); err != nil {
+			return fmt.Errorf("failed to remove archived incident: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	r.modified(incident)
+	return nil
+}
+
+// findArchived retrieves the single archived incident matching the filter,
+// returning mongo.ErrNoDocuments when there is no archive or no match
+func (r *IncidentRepository) findArchived(ctx context.Context, filter bson.M) (*models.Incident, error) {
+	if r.archive == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	var incident models.Incident
+	err := timed("get_archived", func() error {
+		return r.archive.FindOne(ctx, filter).Decode(&incident)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &incident, nil
+}
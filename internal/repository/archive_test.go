@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+)
+
+func TestArchive_MovesIncidentOutOfTheActiveCollection(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("archived incident is still found by key", func(mt *mtest.T) {
+		// Arrange
+		repo := NewIncidentRepository(mt.DB, IncidentsCollection).WithArchive(mt.DB.Collection(ArchiveCollection))
+		incident := &models.Incident{ID: primitive.NewObjectID(), IncidentKey: 7, Status: models.Closed}
+		now := time.Now()
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "upserted", Value: bson.A{bson.D{{Key: "index", Value: 0}, {Key: "_id", Value: incident.ID}}}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents_archive", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: incident.ID},
+				{Key: "incident_key", Value: 7},
+				{Key: "status", Value: "closed"},
+				{Key: "archived_at", Value: now},
+			}),
+		)
+
+		// Act
+		archiveErr := repo.Archive(context.Background(), incident, now)
+		upsert := mt.GetStartedEvent()
+		remove := mt.GetStartedEvent()
+		found, getErr := repo.GetByIncidentKey(context.Background(), 7)
+
+		// Assert
+		if archiveErr != nil || getErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", archiveErr, getErr)
+		}
+		if got := upsert.Command.Lookup("update").StringValue(); got != ArchiveCollection {
+			t.Errorf("Expected the incident copied to %s, got %s", ArchiveCollection, got)
+		}
+		statement := upsert.Command.Lookup("updates").Array().Index(0).Value().Document()
+		if !statement.Lookup("upsert").Boolean() {
+			t.Errorf("Expected the archive copy to be upserted")
+		}
+		if _, err := statement.LookupErr("u", "archived_at"); err != nil {
+			t.Errorf("Expected the archive copy to carry archived_at, got %s", statement)
+		}
+		if got := remove.Command.Lookup("delete").StringValue(); got != IncidentsCollection {
+			t.Errorf("Expected the incident removed from %s, got %s", IncidentsCollection, got)
+		}
+
+		if found.IncidentKey != 7 || found.ArchivedAt == nil {
+			t.Errorf("Expected archived incident 7, got %+v", found)
+		}
+		mt.GetStartedEvent() // the miss on the incidents collection
+		if got := mt.GetStartedEvent().Command.Lookup("find").StringValue(); got != ArchiveCollection {
+			t.Errorf("Expected the lookup to fall back to %s, got %s", ArchiveCollection, got)
+		}
+	})
+
+	mt.Run("lists only read the incidents collection", func(mt *mtest.T) {
+		// Arrange
+		repo := NewIncidentRepository(mt.DB, IncidentsCollection).WithArchive(mt.DB.Collection(ArchiveCollection))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		incidents, _, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(incidents) != 0 {
+			t.Errorf("Expected no incidents, got %d", len(incidents))
+		}
+		if got := mt.GetStartedEvent().Command.Lookup("aggregate").StringValue(); got != IncidentsCollection {
+			t.Errorf("Expected the list to aggregate %s, got %s", IncidentsCollection, got)
+		}
+		if extra := mt.GetStartedEvent(); extra != nil {
+			t.Errorf("Expected the archive not to be read, got %s on %v", extra.CommandName, extra.Command)
+		}
+	})
+
+	mt.Run("missing incident is not found in either collection", func(mt *mtest.T) {
+		// Arrange
+		repo := NewIncidentRepository(mt.DB, IncidentsCollection).WithArchive(mt.DB.Collection(ArchiveCollection))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents_archive", mtest.FirstBatch),
+		)
+
+		// Act
+		_, err := repo.GetByIncidentKey(context.Background(), 8)
+
+		// Assert
+		if !errors.Is(err, ErrIncidentNotFound) {
+			t.Errorf("Expected incident not found, got %v", err)
+		}
+	})
+}
+
+func TestGetNextIncidentKey_SkipsArchivedKeys(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("archived highest key is not reused", func(mt *mtest.T) {
+		// Arrange
+		repo := NewIncidentRepository(mt.DB, IncidentsCollection).WithArchive(mt.DB.Collection(ArchiveCollection))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "incident_key", Value: 6}}),
+			mtest.CreateCursorResponse(0, "db.incidents_archive", mtest.FirstBatch, bson.D{{Key: "incident_key", Value: 7}}),
+		)
+
+		// Act
+		key, err := repo.GetNextIncidentKey(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if key != 8 {
+			t.Errorf("Expected key 8 after archived incident 7, got %d", key)
+		}
+	})
+
+	mt.Run("empty collections start at 1", func(mt *mtest.T) {
+		// Arrange
+		repo := NewIncidentRepository(mt.DB, IncidentsCollection).WithArchive(mt.DB.Collection(ArchiveCollection))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents_archive", mtest.FirstBatch),
+		)
+
+		// Act
+		key, err := repo.GetNextIncidentKey(context.Background())
+
+		// Assert
+		if err != nil || key != 1 {
+			t.Errorf("Expected key 1, got %d (%v)", key, err)
+		}
+	})
+}
+
+func TestFindArchivable_SelectsLongClosedIncidents(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filters closed incidents by last update", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		cutoff := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		_, err := repo.FindArchivable(context.Background(), cutoff, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		if status := filter.Lookup("status").StringValue(); status != string(models.Closed) {
+			t.Errorf("Expected only closed incidents, got %s", status)
+		}
+		if updatedBefore := filter.Lookup("updated_at", "$lte").Time(); !updatedBefore.Equal(cutoff) {
+			t.Errorf("Expected incidents updated at or before %s, got %s", cutoff, updatedBefore)
+		}
+	})
+}
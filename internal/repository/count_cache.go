@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/tenant"
+)
+
+// CountCache holds list totals by tenant and filter for a TTL, so paging through
+// a list does not recount every page. A nil cache is valid and caches nothing.
+type CountCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]countCacheEntry
+	now     func() time.Time
+}
+
+type countCacheEntry struct {
+	total     int
+	expiresAt time.Time
+}
+
+// NewCountCache creates a cache holding totals for ttl each. It returns nil,
+// disabling caching, when ttl is zero or less.
+func NewCountCache(ttl time.Duration) *CountCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &CountCache{
+		ttl:     ttl,
+		entries: make(map[string]countCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// countKey identifies the incidents a list counts: its tenant and filters, but not
+// the page or sort order, which do not change the total. The attention cutoff and
+// SLA windows are left out too: the service derives them from the clock on every
+// request, so the NeedsAttention and SLAStatus inputs stand in for them
+func countKey(ctx context.Context, params models.ListIncidentsParams) string {
+	params.Page = models.PageParams{}
+	params.SortField, params.SortDirection = "", 0
+	params.UpdatedBefore, params.SLAWindows = time.Time{}, nil
+	return fmt.Sprintf("%s|%+v", tenant.FromContext(ctx), params)
+}
+
+// get returns the cached total for the key unless it has expired
+func (c *CountCache) get(key string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return 0, false
+	}
+	return entry.total, true
+}
+
+// put caches the total for the key, dropping any expired totals so keys that are
+// never read again do not pile up between writes
+func (c *CountCache) put(key string, total int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for existing, entry := range c.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(c.entries, existing)
+		}
+	}
+	c.entries[key] = countCacheEntry{total: total, expiresAt: now.Add(c.ttl)}
+}
+
+// clear drops every cached total
+func (c *CountCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]countCacheEntry)
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/tenant"
+)
+
+func TestGetAllIncidents_CachesTotals(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	page := func(number int) models.ListIncidentsParams {
+		return models.ListIncidentsParams{Status: models.Open, Page: models.PageParams{Page: number, PageSize: 1}}
+	}
+	fullPage := func() bson.D {
+		return bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "high"}, {Key: "status", Value: "open"}}
+	}
+	count := func(n int) bson.D {
+		return bson.D{{Key: "n", Value: n}}
+	}
+
+	mt.Run("total is reused within the TTL and recounted after it", func(mt *mtest.T) {
+		// Arrange
+		now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+		counts := NewCountCache(5 * time.Second)
+		counts.now = func() time.Time { return now }
+		repo := (&IncidentRepository{collection: tenantCollection{mt.Coll}}).WithCountCache(counts)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(4)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(6)),
+		)
+
+		// Act
+		_, first, err := repo.GetAllIncidents(context.Background(), page(1))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, reused, err := repo.GetAllIncidents(context.Background(), page(2))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		commandsWithinTTL := len(mt.GetAllStartedEvents())
+		now = now.Add(5 * time.Second)
+		_, refreshed, err := repo.GetAllIncidents(context.Background(), page(3))
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Assert
+		if first != 4 || reused != 4 {
+			t.Errorf("Expected the total of 4 reused on the next page, got %d and %d", first, reused)
+		}
+		if commandsWithinTTL != 3 {
+			t.Errorf("Expected two pages and a single count within the TTL, got %d commands", commandsWithinTTL)
+		}
+		if refreshed != 6 {
+			t.Errorf("Expected the total recounted after the TTL, got %d", refreshed)
+		}
+	})
+
+	mt.Run("create drops cached totals", func(mt *mtest.T) {
+		// Arrange
+		repo := (&IncidentRepository{collection: tenantCollection{mt.Coll}}).WithCountCache(NewCountCache(time.Minute))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(4)),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(5)),
+		)
+
+		// Act
+		repo.GetAllIncidents(context.Background(), page(1))
+		if _, err := repo.Create(context.Background(), &models.Incident{Title: "Checkout latency", Status: models.Open}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		_, total, err := repo.GetAllIncidents(context.Background(), page(2))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if total != 5 {
+			t.Errorf("Expected the total recounted after a create, got %d", total)
+		}
+	})
+
+	mt.Run("totals are kept per tenant and filter", func(mt *mtest.T) {
+		// Arrange
+		repo := (&IncidentRepository{collection: tenantCollection{mt.Coll}}).WithCountCache(NewCountCache(time.Minute))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(4)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(2)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(9)),
+		)
+		critical := page(1)
+		critical.Severity = models.Critical
+
+		// Act
+		_, all, _ := repo.GetAllIncidents(context.Background(), page(1))
+		_, otherTenant, _ := repo.GetAllIncidents(tenant.NewContext(context.Background(), "team-b"), page(1))
+		_, otherFilter, _ := repo.GetAllIncidents(context.Background(), critical)
+
+		// Assert
+		if all != 4 || otherTenant != 2 || otherFilter != 9 {
+			t.Errorf("Expected separate totals 4, 2 and 9, got %d, %d and %d", all, otherTenant, otherFilter)
+		}
+	})
+	mt.Run("clock-derived cutoffs share a total", func(mt *mtest.T) {
+		// Arrange
+		repo := (&IncidentRepository{collection: tenantCollection{mt.Coll}}).WithCountCache(NewCountCache(time.Minute))
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(3)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, fullPage()),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, count(8)),
+		)
+		requestAt := func(now time.Time) models.ListIncidentsParams {
+			params := page(1)
+			params.NeedsAttention, params.UpdatedBefore = true, now.Add(-time.Hour)
+			params.SLAStatus = models.SLABreached
+			params.SLAWindows = []models.SLAWindow{{Severity: models.Critical, CreatedBefore: now.Add(-4 * time.Hour)}}
+			return params
+		}
+		now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		// Act
+		_, first, _ := repo.GetAllIncidents(context.Background(), requestAt(now))
+		_, second, _ := repo.GetAllIncidents(context.Background(), requestAt(now.Add(time.Second)))
+
+		// Assert
+		if first != 3 || second != 3 {
+			t.Errorf("Expected the total of 3 reused across requests, got %d and %d", first, second)
+		}
+	})
+}
+
+func TestCountCache_PutSweepsExpiredTotals(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	counts := NewCountCache(5 * time.Second)
+	counts.now = func() time.Time { return now }
+	counts.put("team-a|open", 4)
+	counts.put("team-a|critical", 2)
+	now = now.Add(5 * time.Second)
+
+	// Act
+	counts.put("team-a|resolved", 7)
+
+	// Assert
+	if len(counts.entries) != 1 {
+		t.Fatalf("Expected only the fresh total to remain, got %d entries", len(counts.entries))
+	}
+	if total, ok := counts.get("team-a|resolved"); !ok || total != 7 {
+		t.Errorf("Expected the fresh total of 7, got %d (cached %t)", total, ok)
+	}
+}
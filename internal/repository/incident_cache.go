@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// IncidentCache is an in-memory LRU cache of incidents by incident_key, with
+// entries expiring after a TTL. A nil cache is valid and caches nothing.
+type IncidentCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List // Most recently used at the front
+	entries map[int]*list.Element
+	now     func() time.Time
+}
+
+type incidentCacheEntry struct {
+	key       int
+	incident  models.Incident
+	expiresAt time.Time
+}
+
+// NewIncidentCache creates a cache holding up to size incidents for ttl each. It
+// returns nil, disabling caching, when size or ttl is zero or less.
+func NewIncidentCache(size int, ttl time.Duration) *IncidentCache {
+	if size <= 0 || ttl <= 0 {
+		return nil
+	}
+	return &IncidentCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[int]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// get returns a copy of the cached incident, so callers may modify it freely
+func (c *IncidentCache) get(key int) (*models.Incident, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*incidentCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	incident := entry.incident
+	return &incident, true
+}
+
+// put caches a copy of the incident, evicting the least recently used one when full
+func (c *IncidentCache) put(incident *models.Incident) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &incidentCacheEntry{key: incident.IncidentKey, incident: *incident, expiresAt: c.now().Add(c.ttl)}
+	if element, ok := c.entries[incident.IncidentKey]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[incident.IncidentKey] = c.order.PushFront(entry)
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// invalidate drops the incident with the key from the cache
+func (c *IncidentCache) invalidate(key int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// clear drops every cached incident
+func (c *IncidentCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[int]*list.Element)
+}
+
+func (c *IncidentCache) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*incidentCacheEntry).key)
+}
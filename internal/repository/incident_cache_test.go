@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+)
+
+func TestGetByID_ServesRepeatFetchesFromCache(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("second fetch hits the cache and a mutation invalidates it", func(mt *mtest.T) {
+		// Arrange
+		repo := (&IncidentRepository{collection: tenantCollection{mt.Coll}}).WithCache(NewIncidentCache(10, time.Minute))
+		id := primitive.NewObjectID()
+		incident := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "title", Value: "Checkout latency"}}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+		)
+
+		// Act
+		first, err := repo.GetByID(context.Background(), "7")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		first.Title = "Modified by the caller"
+		second, err := repo.GetByID(context.Background(), "7")
+		if err != nil {
+			t.Fatalf("Expected a cached incident, got %v", err)
+		}
+		if _, err := repo.AddNote(context.Background(), id.Hex(), models.Note{Content: "Rolled back"}); err != nil {
+			t.Fatalf("Expected the note to be added, got %v", err)
+		}
+		if _, err := repo.GetByID(context.Background(), "7"); err != nil {
+			t.Fatalf("Expected the incident to be refetched, got %v", err)
+		}
+
+		// Assert
+		if second.Title != "Checkout latency" {
+			t.Errorf("Expected the cached copy to be unaffected by callers, got %q", second.Title)
+		}
+		var commands []string
+		for event := mt.GetStartedEvent(); event != nil; event = mt.GetStartedEvent() {
+			commands = append(commands, event.CommandName)
+		}
+		if len(commands) != 3 || commands[0] != "find" || commands[1] != "findAndModify" || commands[2] != "find" {
+			t.Errorf("Expected find, findAndModify, find, got %v", commands)
+		}
+	})
+}
+
+func TestIncidentCache_EvictsLeastRecentlyUsedAndExpired(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	cache := NewIncidentCache(2, time.Minute)
+	cache.now = func() time.Time { return now }
+	cache.put(&models.Incident{IncidentKey: 1})
+	cache.put(&models.Incident{IncidentKey: 2})
+	cache.get(1)
+
+	// Act
+	cache.put(&models.Incident{IncidentKey: 3})
+
+	// Assert
+	if _, ok := cache.get(2); ok {
+		t.Error("Expected the least recently used incident to be evicted")
+	}
+	if _, ok := cache.get(1); !ok {
+		t.Error("Expected the recently read incident to stay cached")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := cache.get(3); ok {
+		t.Error("Expected the incident to expire after the TTL")
+	}
+}
+
+func TestNewIncidentCache_DisabledWithoutSize(t *testing.T) {
+	// Act
+	cache := NewIncidentCache(0, time.Minute)
+
+	// Assert
+	if cache != nil {
+		t.Fatal("Expected no cache when the size is zero")
+	}
+	cache.put(&models.Incident{IncidentKey: 1})
+	if _, ok := cache.get(1); ok {
+		t.Error("Expected a disabled cache to never hit")
+	}
+}
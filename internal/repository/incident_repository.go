@@ -2,39 +2,90 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/metrics"
 	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/tenant"
 )
 
 const (
-	IncidentsCollection = "incidents"
+	IncidentsCollection = "incidents" // Default incidents collection
+	CountersCollection  = "counters"
 )
 
+// ErrIncidentNotFound is returned when no incident matches the lookup
+var ErrIncidentNotFound = errors.New("incident not found")
+
+// ErrInvalidIncidentID is returned for an identifier that is neither an ObjectID
+// nor an incident key
+var ErrInvalidIncidentID = errors.New("invalid incident ID format")
+
+// ErrAttachmentNotFound is returned when an incident has no attachment with the given ID
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// ErrDuplicateIncident is returned when a create collides with a unique index: another
+// incident was created with the same idempotency key or external id
+var ErrDuplicateIncident = errors.New("incident already exists")
+
+const (
+	idempotencyKeyIndexName = "incident_idempotency_key" // Unique index over idempotency keys
+	externalIDIndexName     = "incident_external_id"     // Unique index over external ticket ids
+)
+
+// incidentAgeBuckets lists the age bucket labels from oldest to newest,
+// matching the order of the boundaries built by ageBucketBoundaries
+var incidentAgeBuckets = []string{">24h", "4-24h", "1-4h", "<1h"}
+
 // IncidentRepository handles incident database operations
 type IncidentRepository struct {
-	collection *mongo.Collection
+	collection tenantCollection
+	counters   *mongo.Collection
+	cache      *IncidentCache    // Optional, caches lookups by incident_key
+	counts     *CountCache       // Optional, caches list totals by filter
+	archive    *tenantCollection // Optional, searched by lookups that miss the collection
 }
 
-// NewIncidentRepository creates a new incident repository
-func NewIncidentRepository(db *mongo.Database) *IncidentRepository {
+// NewIncidentRepository creates a new incident repository on the named collection
+func NewIncidentRepository(db *mongo.Database, collection string) *IncidentRepository {
 	return &IncidentRepository{
-		collection: db.Collection(IncidentsCollection),
+		collection: tenantCollection{db.Collection(collection)},
+		counters:   db.Collection(CountersCollection),
 	}
 }
 
+// WithCache serves lookups by incident_key from the cache, invalidating an
+// incident's entry whenever it is modified through this repository. Share one
+// repository between services so every mutation invalidates the same cache.
+func (r *IncidentRepository) WithCache(cache *IncidentCache) *IncidentRepository {
+	r.cache = cache
+	return r
+}
+
+// WithCountCache reuses list totals from the cache while paging, dropping every
+// cached total whenever an incident is created or modified through this repository.
+// Writes from other instances are only seen once a total expires.
+func (r *IncidentRepository) WithCountCache(counts *CountCache) *IncidentRepository {
+	r.counts = counts
+	return r
+}
+
 // Create creates a new incident
 func (r *IncidentRepository) Create(ctx context.Context, incident *models.Incident) (*models.Incident, error) {
 	// Set timestamps
 	now := time.Now()
 	incident.CreatedAt = now
 	incident.UpdatedAt = now
+	incident.StatusChangedAt = &now
 	incident.ID = primitive.NewObjectID()
 
 	// Initialize empty notes slice if nil
@@ -43,78 +94,180 @@ func (r *IncidentRepository) Create(ctx context.Context, incident *models.Incide
 	}
 
 	// Insert the incident
-	result, err := r.collection.InsertOne(ctx, incident)
+	var result *mongo.InsertOneResult
+	err := timed("create", func() (err error) {
+		result, err = r.collection.InsertOne(ctx, incident)
+		return err
+	})
 	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, fmt.Errorf("%w: %w", ErrDuplicateIncident, err)
+		}
 		return nil, fmt.Errorf("failed to create incident: %w", err)
 	}
 
 	// Set the generated ID
 	incident.ID = result.InsertedID.(primitive.ObjectID)
+	r.counts.clear()
 
 	return incident, nil
 }
 
 // UpdateStatus updates the status of an incident
 func (r *IncidentRepository) UpdateStatus(ctx context.Context, id string, status models.IncidentStatus) (*models.Incident, error) {
+	return r.updateStatus(ctx, id, status, nil)
+}
+
+// UpdateStatusWithNote updates the status of an incident and appends the note in
+// the same update, so neither is saved without the other
+func (r *IncidentRepository) UpdateStatusWithNote(ctx context.Context, id string, status models.IncidentStatus, note models.Note) (*models.Incident, error) {
+	return r.updateStatus(ctx, id, status, &note)
+}
+
+func (r *IncidentRepository) updateStatus(ctx context.Context, id string, status models.IncidentStatus, note *models.Note) (*models.Incident, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid incident ID format: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
 	}
 
+	now := time.Now()
 	update := bson.M{
 		"$set": bson.M{
-			"status":     status,
-			"updated_at": time.Now(),
+			"status":            status,
+			"updated_at":        now,
+			"status_changed_at": now,
 		},
 	}
+	if note != nil {
+		note.ID = primitive.NewObjectID()
+		note.CreatedAt = now
+		update["$push"] = bson.M{"notes": *note}
+	}
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 	var updatedIncident models.Incident
-	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	err = timed("update_status", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("incident not found")
+			return nil, ErrIncidentNotFound
 		}
 		return nil, fmt.Errorf("failed to update incident status: %w", err)
 	}
 
-	return &updatedIncident, nil
+	return r.modified(&updatedIncident), nil
 }
 
 // UpdateSeverity updates the severity of an incident
 func (r *IncidentRepository) UpdateSeverity(ctx context.Context, id string, severity models.IncidentSeverity) (*models.Incident, error) {
 	objectID, err := primitive.ObjectIDFromHex(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid incident ID format: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
 	}
 
+	now := time.Now()
 	update := bson.M{
 		"$set": bson.M{
-			"severity":   severity,
-			"updated_at": time.Now(),
+			"severity":            severity,
+			"updated_at":          now,
+			"severity_changed_at": now,
 		},
 	}
 
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 	var updatedIncident models.Incident
-	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	err = timed("update_severity", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("incident not found")
+			return nil, ErrIncidentNotFound
 		}
 		return nil, fmt.Errorf("failed to update incident severity: %w", err)
 	}
 
-	return &updatedIncident, nil
+	return r.modified(&updatedIncident), nil
+}
+
+// UpdatePriority updates the priority of an incident
+func (r *IncidentRepository) UpdatePriority(ctx context.Context, id string, priority models.IncidentPriority) (*models.Incident, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"priority":   priority,
+			"updated_at": time.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err = timed("update_priority", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to update incident priority: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
+}
+
+// UpdateDetails sets the title, description and assignee present in the request,
+// leaving omitted fields unchanged. An assignee change is appended to the
+// assignment history.
+func (r *IncidentRepository) UpdateDetails(ctx context.Context, incidentID primitive.ObjectID, req models.UpdateIncidentRequest, change *models.AssignmentChange) (*models.Incident, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err := timed("update_details", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": incidentID}, buildDetailsUpdate(req, change, time.Now()), opts).Decode(&updatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
+}
+
+// buildDetailsUpdate $sets only the fields present in the request
+func buildDetailsUpdate(req models.UpdateIncidentRequest, change *models.AssignmentChange, now time.Time) bson.M {
+	set := bson.M{"updated_at": now}
+	if req.Title != nil {
+		set["title"] = *req.Title
+		set["title_truncated"] = req.TitleTruncated
+	}
+	if req.Description != nil {
+		set["description"] = *req.Description
+	}
+	if req.Assignee != nil {
+		set["assignee"] = *req.Assignee
+	}
+
+	update := bson.M{"$set": set}
+	if change != nil {
+		update["$push"] = bson.M{"assignment_history": *change}
+	}
+	return update
 }
 
 // AddNote adds a note to an incident
 func (r *IncidentRepository) AddNote(ctx context.Context, incidentID string, note models.Note) (*models.Incident, error) {
 	objectID, err := primitive.ObjectIDFromHex(incidentID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid incident ID format: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
 	}
 
 	// Set note metadata
@@ -129,29 +282,282 @@ func (r *IncidentRepository) AddNote(ctx context.Context, incidentID string, not
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 	var updatedIncident models.Incident
-	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	err = timed("add_note", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("incident not found")
+			return nil, ErrIncidentNotFound
 		}
 		return nil, fmt.Errorf("failed to add note to incident: %w", err)
 	}
 
-	return &updatedIncident, nil
+	return r.modified(&updatedIncident), nil
+}
+
+// SetPostmortem attaches the postmortem to an incident, replacing any earlier one
+func (r *IncidentRepository) SetPostmortem(ctx context.Context, incidentID string, postmortem models.Postmortem) (*models.Incident, error) {
+	objectID, err := primitive.ObjectIDFromHex(incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"postmortem": postmortem,
+			"updated_at": time.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err = timed("set_postmortem", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to set incident postmortem: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
+}
+
+// AddNotes appends the notes in order with a single update, keeping the creation
+// time of notes that already have one
+func (r *IncidentRepository) AddNotes(ctx context.Context, incidentID string, notes []models.Note) (*models.Incident, error) {
+	objectID, err := primitive.ObjectIDFromHex(incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
+	}
+
+	now := time.Now()
+	for i := range notes {
+		notes[i].ID = primitive.NewObjectID()
+		if notes[i].CreatedAt.IsZero() {
+			notes[i].CreatedAt = now
+		}
+	}
+
+	update := bson.M{
+		"$push": bson.M{"notes": bson.M{"$each": notes}},
+		"$set":  bson.M{"updated_at": now},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err = timed("add_notes", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to add notes to incident: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
+}
+
+// ErrNotDraft is returned when publishing an incident that is not a draft
+var ErrNotDraft = errors.New("incident is not a draft")
+
+// PublishDraft clears the draft flag, making the incident live
+func (r *IncidentRepository) PublishDraft(ctx context.Context, incidentID primitive.ObjectID) (*models.Incident, error) {
+	filter := bson.M{"_id": incidentID, "draft": true}
+	update := bson.M{
+		"$unset": bson.M{"draft": ""},
+		"$set":   bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var publishedIncident models.Incident
+	err := timed("publish_draft", func() error {
+		return r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&publishedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotDraft
+		}
+		return nil, fmt.Errorf("failed to publish incident: %w", err)
+	}
+
+	return r.modified(&publishedIncident), nil
+}
+
+// ErrNoteConflict is returned when a note changed between reading and replacing it
+var ErrNoteConflict = errors.New("note was modified concurrently")
+
+// ReplaceNote replaces a note, provided its content still matches previousContent
+func (r *IncidentRepository) ReplaceNote(ctx context.Context, incidentID primitive.ObjectID, previousContent string, note models.Note) (*models.Incident, error) {
+	filter := bson.M{
+		"_id":   incidentID,
+		"notes": bson.M{"$elemMatch": bson.M{"_id": note.ID, "content": previousContent}},
+	}
+	update := bson.M{
+		"$set": bson.M{"notes.$": note, "updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err := timed("replace_note", func() error {
+		return r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNoteConflict
+		}
+		return nil, fmt.Errorf("failed to replace note: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
 }
 
+// GetByID looks an incident up by an ambiguous identifier. As before explicit
+// lookups existed, anything that parses as an incident_key (numeric or yearly) is
+// looked up by key, even a 24 digit string that is also valid hex; only other 24
+// character hex strings are treated as an ObjectID. Prefer GetByIncidentKey or
+// GetByObjectID when the identifier type is known.
 func (r *IncidentRepository) GetByID(ctx context.Context, id string) (*models.Incident, error) {
-	// Convert string ID to integer
-	incidentKey, err := strconv.Atoi(id)
+	filter, err := incidentIDFilter(id)
 	if err != nil {
-		return nil, fmt.Errorf("invalid incident ID format: %w", err)
+		return nil, err
 	}
+	if incidentKey, ok := filter["incident_key"].(int); ok {
+		return r.GetByIncidentKey(ctx, incidentKey)
+	}
+	return r.findOne(ctx, filter)
+}
+
+// incidentIDFilter builds the lookup filter for an ambiguous identifier, see GetByID
+func incidentIDFilter(id string) (bson.M, error) {
+	// Convert string ID to integer, accepting yearly keys such as 2024-0001
+	incidentKey, err := models.ParseIncidentKey(id)
+	if err == nil {
+		return bson.M{"incident_key": incidentKey}, nil
+	}
+
+	if objectID, hexErr := primitive.ObjectIDFromHex(id); hexErr == nil {
+		return bson.M{"_id": objectID}, nil
+	}
+	return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
+}
+
+// SearchNotes returns the incident's notes whose content or author email contains
+// the query, case-insensitively
+func (r *IncidentRepository) SearchNotes(ctx context.Context, id string, query string) ([]models.Note, error) {
+	filter, err := incidentIDFilter(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		Notes []models.Note `bson:"notes"`
+	}
+	err = timed("search_notes", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildNoteSearchPipeline(filter, query))
+		if err != nil {
+			return fmt.Errorf("failed to search notes: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return fmt.Errorf("failed to decode notes: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, ErrIncidentNotFound
+	}
+
+	if results[0].Notes == nil {
+		return []models.Note{}, nil
+	}
+	return results[0].Notes, nil
+}
+
+// buildNoteSearchPipeline keeps only the matching incident's notes whose content
+// or author email contains the query, treating the query as literal text
+func buildNoteSearchPipeline(filter bson.M, query string) mongo.Pipeline {
+	pattern := regexp.QuoteMeta(query)
+	matches := func(field string) bson.M {
+		return bson.M{"$regexMatch": bson.M{"input": field, "regex": pattern, "options": "i"}}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$project", Value: bson.M{
+			"_id": 0,
+			"notes": bson.M{"$filter": bson.M{
+				"input": bson.M{"$ifNull": bson.A{"$notes", bson.A{}}},
+				"as":    "note",
+				"cond": bson.M{"$or": bson.A{
+					matches("$$note.content"),
+					matches("$$note.author_email"),
+				}},
+			}},
+		}}},
+	}
+}
+
+// GetByIncidentKey retrieves an incident by its numeric incident_key
+func (r *IncidentRepository) GetByIncidentKey(ctx context.Context, incidentKey int) (*models.Incident, error) {
+	if cached, ok := r.cache.get(incidentKey); ok && visibleToTenant(ctx, cached) {
+		return cached, nil
+	}
+
+	incident, err := r.findOne(ctx, bson.M{"incident_key": incidentKey})
+	if err != nil {
+		return nil, err
+	}
+	r.cache.put(incident)
+	return incident, nil
+}
+
+// visibleToTenant reports whether an incident read outside a scoped query belongs
+// to the context's tenant
+func visibleToTenant(ctx context.Context, incident *models.Incident) bool {
+	id := tenant.FromContext(ctx)
+	return id == "" || incident.TenantID == id
+}
+
+// modified drops the incident from the cache after a mutation and returns it
+func (r *IncidentRepository) modified(incident *models.Incident) *models.Incident {
+	r.cache.invalidate(incident.IncidentKey)
+	r.counts.clear()
+	return incident
+}
+
+// GetByObjectID retrieves an incident by its MongoDB ObjectID
+func (r *IncidentRepository) GetByObjectID(ctx context.Context, id primitive.ObjectID) (*models.Incident, error) {
+	return r.findOne(ctx, bson.M{"_id": id})
+}
 
+// findOne retrieves the single incident matching the filter
+func (r *IncidentRepository) findOne(ctx context.Context, filter bson.M) (*models.Incident, error) {
 	var incident models.Incident
-	err = r.collection.FindOne(ctx, bson.M{"incident_key": incidentKey}).Decode(&incident)
+	err := timed("get", func() error {
+		return r.collection.FindOne(ctx, filter).Decode(&incident)
+	})
+	if err == mongo.ErrNoDocuments {
+		// Closed incidents may have been moved to the archive
+		archived, archiveErr := r.findArchived(ctx, filter)
+		if archiveErr == nil {
+			return archived, nil
+		}
+		err = archiveErr
+	}
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("incident not found")
+			return nil, ErrIncidentNotFound
 		}
 		return nil, fmt.Errorf("failed to get incident: %w", err)
 	}
@@ -160,68 +566,1149 @@ func (r *IncidentRepository) GetByID(ctx context.Context, id string) (*models.In
 }
 
 // GetAll retrieves all incidents with optional filtering and pagination
-func (r *IncidentRepository) GetAllIncidents(ctx context.Context) ([]models.Incident, error) {
-	opts := options.Find()
+func (r *IncidentRepository) GetAllIncidents(ctx context.Context, params models.ListIncidentsParams) ([]models.IncidentSummary, int, error) {
+	// Start non-nil so an empty result is returned as [] rather than null
+	incidents := []models.IncidentSummary{}
+	err := timed("list", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildListPipeline(params))
+		if err != nil {
+			return fmt.Errorf("failed to get incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
 
-	// Sort by created_at descending (newest first)
-	opts.SetSort(bson.D{bson.E{Key: "created_at", Value: -1}})
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
 
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	total, err := r.countListed(ctx, params, len(incidents))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get incidents: %w", err)
+		return nil, 0, err
 	}
-	defer cursor.Close(ctx)
+	return incidents, total, nil
+}
 
-	var incidents []models.Incident
-	err = cursor.All(ctx, &incidents)
+// countListed returns the number of incidents matching the list filters. A page
+// that is neither full nor past the end already ends the list, so its total is
+// known without counting. Counted totals are reused from the count cache.
+func (r *IncidentRepository) countListed(ctx context.Context, params models.ListIncidentsParams, fetched int) (int, error) {
+	page := params.Page
+	if page.PageSize == 0 || (fetched > 0 && fetched < page.PageSize) || (fetched == 0 && page.Page <= 1) {
+		return page.Skip() + fetched, nil
+	}
+
+	key := countKey(ctx, params)
+	if total, ok := r.counts.get(key); ok {
+		return total, nil
+	}
+
+	var total int64
+	err := timed("count", func() error {
+		var err error
+		total, err = r.collection.CountDocuments(ctx, visibleIncidentFilter(params))
+		if err != nil {
+			return fmt.Errorf("failed to count incidents: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode incidents: %w", err)
+		return 0, err
 	}
+	r.counts.put(key, int(total))
+	return int(total), nil
+}
 
-	return incidents, nil
+// buildListPipeline filters and sorts incidents, cuts out the requested page and
+// replaces the notes, watch list and attachments with counts so list payloads
+// stay small
+func buildListPipeline(params models.ListIncidentsParams) mongo.Pipeline {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: visibleIncidentFilter(params)}},
+		// Sort by the requested field, newest first unless configured otherwise
+		{{Key: "$sort", Value: buildIncidentSort(params.SortField, params.SortDirection)}},
+	}
+	if params.Page.PageSize > 0 {
+		pipeline = append(pipeline,
+			bson.D{{Key: "$skip", Value: params.Page.Skip()}},
+			bson.D{{Key: "$limit", Value: params.Page.PageSize}},
+		)
+	}
+	return append(pipeline, summaryCountsStage, summaryProjectStage)
 }
 
-// Add add watcher to an incident
-func (r *IncidentRepository) AddWatcherToIncident(ctx context.Context, incidentID string, watcher models.Watcher) (*models.Incident, error) {
-	objectID, err := primitive.ObjectIDFromHex(incidentID)
+// summaryCountsStage adds the watcher and note counts carried by list items
+var summaryCountsStage = bson.D{{Key: "$addFields", Value: bson.M{
+	"watcher_count": arraySize("$watchlist"),
+	"note_count":    arraySize("$notes"),
+}}}
+
+// summaryProjectStage drops the arrays list items replace with counts
+var summaryProjectStage = bson.D{{Key: "$project", Value: bson.M{"watchlist": 0, "notes": 0, "attachments": 0}}}
+
+// arraySize is the size of an array field, treating a missing field as empty
+func arraySize(field string) bson.M {
+	return bson.M{"$size": bson.M{"$ifNull": bson.A{field, bson.A{}}}}
+}
+
+// GetFacets returns the distinct assignees, tags and services of the incidents
+// matching the list filters
+func (r *IncidentRepository) GetFacets(ctx context.Context, params models.ListIncidentsParams) (*models.IncidentFacets, error) {
+	var rows []facetRow
+	err := timed("facets", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildFacetsPipeline(params))
+		if err != nil {
+			return fmt.Errorf("failed to aggregate incident facets: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &rows); err != nil {
+			return fmt.Errorf("failed to decode incident facets: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var row facetRow
+	if len(rows) > 0 {
+		row = rows[0]
+	}
+	return &models.IncidentFacets{
+		Assignees: distinctValues(row.Assignees),
+		Tags:      distinctValues(row.Tags),
+		Services:  distinctValues(row.Services),
+	}, nil
+}
+
+// facetValue is one grouped value produced by the facets pipeline
+type facetValue struct {
+	Value string `bson:"_id"`
+}
+
+// facetRow is the single document produced by the facets pipeline
+type facetRow struct {
+	Assignees []facetValue `bson:"assignees"`
+	Tags      []facetValue `bson:"tags"`
+	Services  []facetValue `bson:"services"`
+}
+
+// buildFacetsPipeline groups the matching incidents by each faceted field
+func buildFacetsPipeline(params models.ListIncidentsParams) mongo.Pipeline {
+	distinct := func(field string, unwind bool) bson.A {
+		stages := bson.A{}
+		if unwind {
+			stages = append(stages, bson.M{"$unwind": "$" + field})
+		}
+		return append(stages, bson.M{"$group": bson.M{"_id": "$" + field}})
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: visibleIncidentFilter(params)}},
+		{{Key: "$facet", Value: bson.M{
+			"assignees": distinct("assignee", false),
+			"tags":      distinct("tags", true),
+			"services":  distinct("services", true),
+		}}},
+	}
+}
+
+// distinctValues returns the non-empty values sorted, without duplicates
+func distinctValues(values []facetValue) []string {
+	seen := make(map[string]bool, len(values))
+	distinct := []string{}
+	for _, value := range values {
+		v := strings.TrimSpace(value.Value)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		distinct = append(distinct, v)
+	}
+	sort.Strings(distinct)
+	return distinct
+}
+
+// GetRelatedIncidents returns other incidents sharing tags or services with the
+// incident, ranked by how many they share
+func (r *IncidentRepository) GetRelatedIncidents(ctx context.Context, incident *models.Incident, limit int) ([]models.RelatedIncident, error) {
+	var related []models.RelatedIncident
+	err := timed("related", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildRelatedPipeline(incident, limit))
+		if err != nil {
+			return fmt.Errorf("failed to get related incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &related); err != nil {
+			return fmt.Errorf("failed to decode related incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if related == nil {
+		return []models.RelatedIncident{}, nil
+	}
+	return related, nil
+}
+
+// buildRelatedPipeline matches other incidents sharing a tag or service, scores
+// them by the number of shared tags and services, and keeps the top results
+func buildRelatedPipeline(incident *models.Incident, limit int) mongo.Pipeline {
+	tags := incident.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	services := incident.Services
+	if services == nil {
+		services = []string{}
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"_id":   bson.M{"$ne": incident.ID},
+			"draft": bson.M{"$ne": true},
+			"$or": bson.A{
+				bson.M{"tags": bson.M{"$in": tags}},
+				bson.M{"services": bson.M{"$in": services}},
+			},
+		}}},
+		{{Key: "$addFields", Value: bson.M{
+			"overlap": bson.M{"$add": bson.A{
+				bson.M{"$size": bson.M{"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{"$tags", bson.A{}}}, tags}}},
+				bson.M{"$size": bson.M{"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{"$services", bson.A{}}}, services}}},
+			}},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "overlap", Value: -1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		summaryCountsStage,
+		summaryProjectStage,
+	}
+}
+
+// AddAttachment records attachment metadata on an incident
+func (r *IncidentRepository) AddAttachment(ctx context.Context, incidentID primitive.ObjectID, attachment models.Attachment) (*models.Incident, error) {
+	update := bson.M{
+		"$push": bson.M{"attachments": attachment},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err := timed("add_attachment", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": incidentID}, update, opts).Decode(&updatedIncident)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("invalid incident ID: %w", err)
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to add attachment to incident: %w", err)
 	}
 
+	return r.modified(&updatedIncident), nil
+}
+
+// ConfirmAttachment marks a pending attachment as uploaded
+func (r *IncidentRepository) ConfirmAttachment(ctx context.Context, incidentID, attachmentID primitive.ObjectID) (*models.Incident, error) {
+	now := time.Now()
 	update := bson.M{
-		"$addToSet": bson.M{"watchlist": watcher},
-		"$set":      bson.M{"updated_at": time.Now()},
+		"$set": bson.M{
+			"attachments.$.status":      models.AttachmentUploaded,
+			"attachments.$.uploaded_at": now,
+			"updated_at":                now,
+		},
 	}
 
+	filter := bson.M{"_id": incidentID, "attachments._id": attachmentID}
 	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
 
 	var updatedIncident models.Incident
-	err = r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&updatedIncident)
+	err := timed("confirm_attachment", func() error {
+		return r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updatedIncident)
+	})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, fmt.Errorf("incident not found")
+			return nil, ErrAttachmentNotFound
 		}
-		return nil, fmt.Errorf("failed to add watcher to incident: %w", err)
+		return nil, fmt.Errorf("failed to confirm attachment: %w", err)
 	}
 
-	return &updatedIncident, nil
+	return r.modified(&updatedIncident), nil
 }
 
-// GetNextIncidentKey gets the next auto-increment ID for incidents
-func (r *IncidentRepository) GetNextIncidentKey(ctx context.Context) (int, error) {
-	// Find the incident with the highest IncidentKey
-	opts := options.FindOne().SetSort(bson.D{bson.E{Key: "incident_key", Value: -1}})
+// SetOutage attaches the incident with the given key to an outage
+func (r *IncidentRepository) SetOutage(ctx context.Context, incidentKey int, outageID primitive.ObjectID) (*models.Incident, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"outage_id":  outageID,
+			"updated_at": time.Now(),
+		},
+	}
 
-	var incident models.Incident
-	err := r.collection.FindOne(ctx, bson.M{}, opts).Decode(&incident)
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedIncident models.Incident
+	err := timed("set_outage", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"incident_key": incidentKey}, update, opts).Decode(&updatedIncident)
+	})
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			// No incidents exist, start from 1
-			return 1, nil
+			return nil, ErrIncidentNotFound
 		}
-		return 0, fmt.Errorf("failed to get max incident key: %w", err)
+		return nil, fmt.Errorf("failed to attach incident to outage: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
+}
+
+// GetByOutageID retrieves the incidents attached to an outage, oldest first
+func (r *IncidentRepository) GetByOutageID(ctx context.Context, outageID primitive.ObjectID) ([]models.Incident, error) {
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "incident_key", Value: 1}})
+
+	incidents := []models.Incident{}
+	err := timed("list_by_outage", func() error {
+		cursor, err := r.collection.Find(ctx, bson.M{"outage_id": outageID}, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get outage incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode outage incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+// buildIncidentSort builds a deterministic sort, using _id as a tiebreaker for equal sort values
+func buildIncidentSort(field string, direction int) bson.D {
+	if field == "" {
+		field = "created_at"
+	}
+	if direction != 1 {
+		direction = -1
+	}
+
+	return bson.D{
+		bson.E{Key: field, Value: direction},
+		bson.E{Key: "_id", Value: direction},
+	}
+}
+
+// buildIncidentFilter builds the query filter for listing incidents
+func buildIncidentFilter(params models.ListIncidentsParams) bson.M {
+	var conditions []bson.M
+
+	if params.Status != "" {
+		conditions = append(conditions, bson.M{"status": params.Status})
+	}
+
+	if params.Severity != "" {
+		conditions = append(conditions, bson.M{"severity": params.Severity})
+	}
+
+	if params.Assignee != "" {
+		conditions = append(conditions, bson.M{"assignee": params.Assignee})
+	}
+
+	if params.HasNoteType != "" {
+		conditions = append(conditions, bson.M{
+			"notes": bson.M{"$elemMatch": bson.M{"type": params.HasNoteType}},
+		})
+	}
+
+	if params.MissingNoteType != "" {
+		conditions = append(conditions, bson.M{
+			"notes": bson.M{"$not": bson.M{"$elemMatch": bson.M{"type": params.MissingNoteType}}},
+		})
+	}
+
+	for key, value := range params.CustomFields {
+		conditions = append(conditions, bson.M{"custom_fields." + key: value})
+	}
+
+	if params.NeedsAttention {
+		conditions = append(conditions, bson.M{
+			"status":     bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+			"updated_at": bson.M{"$lt": params.UpdatedBefore},
+		})
+	}
+
+	if params.SLAStatus != "" {
+		conditions = append(conditions, bson.M{
+			"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+			"$or":    slaWindowFilters(params.SLAWindows),
+		})
+	}
+
+	for _, group := range params.AnyOf {
+		conditions = append(conditions, bson.M{"$or": filterGroupConditions(group)})
+	}
+
+	if len(conditions) == 0 {
+		return bson.M{}
+	}
+	return bson.M{"$and": conditions}
+}
+
+// filterFieldKeys maps filter group fields to the document fields they match
+var filterFieldKeys = map[models.FilterField]string{
+	models.FilterStatus:   "status",
+	models.FilterSeverity: "severity",
+	models.FilterAssignee: "assignee",
+	models.FilterTag:      "tags",
+	models.FilterService:  "services",
+}
+
+// filterGroupConditions builds the $or branches of a filter group, one per condition
+func filterGroupConditions(group []models.FilterCondition) []bson.M {
+	branches := make([]bson.M, 0, len(group))
+	for _, condition := range group {
+		branches = append(branches, bson.M{filterFieldKeys[condition.Field]: condition.Value})
+	}
+	return branches
+}
+
+// slaWindowFilters matches incidents created within any of the windows
+func slaWindowFilters(windows []models.SLAWindow) []bson.M {
+	filters := make([]bson.M, 0, len(windows))
+	for _, window := range windows {
+		createdAt := bson.M{}
+		if !window.CreatedAfter.IsZero() {
+			createdAt["$gt"] = window.CreatedAfter
+		}
+		if !window.CreatedBefore.IsZero() {
+			createdAt["$lte"] = window.CreatedBefore
+		}
+		filters = append(filters, bson.M{"severity": window.Severity, "created_at": createdAt})
+	}
+	return filters
+}
+
+// visibleIncidentFilter limits the list filters to published, active incidents,
+// or to drafts or scheduled incidents when they are asked for. Incidents whose
+// stored severity or status is not a known value are left out before paging, so
+// pages and totals only count incidents that are listed.
+func visibleIncidentFilter(params models.ListIncidentsParams) bson.M {
+	filter := buildIncidentFilter(params)
+	if params.Drafts {
+		filter["draft"] = true
+	} else {
+		filter["draft"] = bson.M{"$ne": true}
+	}
+	if params.Scheduled {
+		filter["status"] = models.Scheduled
+	} else {
+		filter["status"] = bson.M{"$ne": models.Scheduled}
+	}
+	filter["$expr"] = bson.M{"$and": bson.A{
+		storedValueIn("severity", models.StoredSeverities()),
+		storedValueIn("status", models.StoredStatuses()),
+	}}
+	return filter
+}
+
+// storedValueIn matches documents whose field, normalized as enum values are when
+// decoded, is one of the values. Fields that are not strings never match.
+func storedValueIn(field string, values []string) bson.M {
+	replace := func(input interface{}, find string) bson.M {
+		return bson.M{"$replaceAll": bson.D{{Key: "input", Value: input}, {Key: "find", Value: find}, {Key: "replacement", Value: "_"}}}
+	}
+	normalized := replace(replace(bson.M{"$toLower": bson.M{"$trim": bson.M{"input": "$" + field}}}, "-"), " ")
+	return bson.M{"$in": bson.A{
+		bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$type": "$" + field}, "string"}}, normalized, ""}},
+		values,
+	}}
+}
+
+// EnsureExternalIDIndex creates the unique index that keeps a single incident per
+// external ticket, even when two syncs for a new ticket race. Ids are unique per
+// tenant, and incidents without one are not indexed.
+func (r *IncidentRepository) EnsureExternalIDIndex(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "external_id", Value: 1}},
+		Options: options.Index().
+			SetName(externalIDIndexName).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"external_id": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create external id index: %w", err)
+	}
+	return nil
+}
+
+// FindByExternalID returns the incident correlated with an external ticket, or nil when there is none
+func (r *IncidentRepository) FindByExternalID(ctx context.Context, externalID string) (*models.Incident, error) {
+	var incident models.Incident
+	err := timed("find_external", func() error {
+		return r.collection.FindOne(ctx, bson.M{"external_id": externalID}).Decode(&incident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find incident by external id: %w", err)
+	}
+
+	return &incident, nil
+}
+
+// EnsureIdempotencyKeyIndex creates the unique index that stops two concurrent
+// creates with the same idempotency key from both inserting. Keys are unique per
+// tenant, and incidents created without one are not indexed.
+func (r *IncidentRepository) EnsureIdempotencyKeyIndex(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "tenant_id", Value: 1}, {Key: "idempotency_key", Value: 1}},
+		Options: options.Index().
+			SetName(idempotencyKeyIndexName).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"idempotency_key": bson.M{"$exists": true}}),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create idempotency key index: %w", err)
+	}
+	return nil
+}
+
+// FindByIdempotencyKey returns the incident created with the idempotency key, or nil when there is none
+func (r *IncidentRepository) FindByIdempotencyKey(ctx context.Context, key string) (*models.Incident, error) {
+	var incident models.Incident
+	err := timed("find_idempotency_key", func() error {
+		return r.collection.FindOne(ctx, bson.M{"idempotency_key": key}).Decode(&incident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find incident by idempotency key: %w", err)
+	}
+
+	return &incident, nil
+}
+
+// FindOpenIncidentsForServices retrieves the most recent unresolved incidents
+// affecting any of the services, or any unresolved incidents when none are given
+func (r *IncidentRepository) FindOpenIncidentsForServices(ctx context.Context, services []string, limit int64) ([]models.Incident, error) {
+	filter := bson.M{
+		"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+	}
+	if len(services) > 0 {
+		filter["services"] = bson.M{"$in": services}
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "created_at", Value: -1}}).
+		SetLimit(limit)
+
+	var incidents []models.Incident
+	err := timed("find_by_services", func() error {
+		cursor, err := r.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to find incidents by services: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+// FindOpenIncidentsByTitle retrieves unresolved incidents with exactly the given title, newest first
+func (r *IncidentRepository) FindOpenIncidentsByTitle(ctx context.Context, title string) ([]models.Incident, error) {
+	filter := bson.M{
+		"title":  title,
+		"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+	}
+	opts := options.Find().
+		SetSort(bson.D{bson.E{Key: "created_at", Value: -1}}).
+		SetLimit(10)
+
+	var incidents []models.Incident
+	err := timed("find_by_title", func() error {
+		cursor, err := r.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to find incidents by title: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
+
+// Add add watcher to an incident
+func (r *IncidentRepository) AddWatcherToIncident(ctx context.Context, incidentID string, watcher models.Watcher) (*models.Incident, error) {
+	objectID, err := primitive.ObjectIDFromHex(incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIncidentID, err)
+	}
+
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	// Emails appear at most once: update the existing entry in place, otherwise append
+	var updatedIncident models.Incident
+	err = timed("add_watcher", func() error {
+		err := r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": objectID, "watchlist.email": watcher.Email},
+			bson.M{"$set": bson.M{"watchlist.$": watcher, "updated_at": now}},
+			opts,
+		).Decode(&updatedIncident)
+		if err != mongo.ErrNoDocuments {
+			return err
+		}
+
+		return r.collection.FindOneAndUpdate(ctx,
+			bson.M{"_id": objectID, "watchlist.email": bson.M{"$ne": watcher.Email}},
+			bson.M{"$push": bson.M{"watchlist": watcher}, "$set": bson.M{"updated_at": now}},
+			opts,
+		).Decode(&updatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrIncidentNotFound
+		}
+		return nil, fmt.Errorf("failed to add watcher to incident: %w", err)
+	}
+
+	return r.modified(&updatedIncident), nil
+}
+
+// ReassignIncidents assigns every selected incident to the request's assignee in a
+// single UpdateMany, appending each incident's previous assignee to its history
+func (r *IncidentRepository) ReassignIncidents(ctx context.Context, req models.BulkReassignRequest) (*models.BulkUpdateResult, error) {
+	now := time.Now()
+	var result *mongo.UpdateResult
+	err := timed("reassign", func() (err error) {
+		result, err = r.collection.UpdateMany(ctx, buildReassignFilter(req), buildReassignPipeline(req.Assignee, req.AuthorEmail, now))
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign incidents: %w", err)
+	}
+
+	// The matched keys are unknown, so drop every cached incident
+	r.cache.clear()
+	r.counts.clear()
+	return &models.BulkUpdateResult{Matched: result.MatchedCount, Modified: result.ModifiedCount}, nil
+}
+
+// buildReassignFilter selects the incidents to reassign by key or by filter,
+// skipping those already assigned to the assignee
+func buildReassignFilter(req models.BulkReassignRequest) bson.M {
+	filter := bson.M{"assignee": bson.M{"$ne": req.Assignee}}
+	if len(req.Keys) > 0 {
+		filter["incident_key"] = bson.M{"$in": req.Keys}
+		return filter
+	}
+
+	if req.Filter.Assignee != "" {
+		filter["assignee"] = bson.M{"$eq": req.Filter.Assignee, "$ne": req.Assignee}
+	}
+	if req.Filter.Status != "" {
+		filter["status"] = req.Filter.Status
+	}
+	if req.Filter.Severity != "" {
+		filter["severity"] = req.Filter.Severity
+	}
+	return filter
+}
+
+// buildReassignPipeline sets the assignee, recording the previous one. Stages read
+// the document as it was, so $assignee is the assignee being replaced.
+func buildReassignPipeline(assignee, changedBy string, now time.Time) mongo.Pipeline {
+	change := bson.M{"from": "$assignee", "to": assignee, "changed_at": now}
+	if changedBy != "" {
+		change["changed_by"] = changedBy
+	}
+	return mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"assignment_history": bson.M{"$concatArrays": bson.A{
+				bson.M{"$ifNull": bson.A{"$assignment_history", bson.A{}}},
+				bson.A{change},
+			}},
+			"assignee":   assignee,
+			"updated_at": now,
+		}}},
+	}
+}
+
+// GetNextIncidentKey gets the next auto-increment ID for incidents. Keys are
+// unique across tenants and are not reused once archived, so the highest key is
+// looked up over every tenant in both the collection and the archive.
+func (r *IncidentRepository) GetNextIncidentKey(ctx context.Context) (int, error) {
+	highest, err := maxIncidentKey(ctx, r.collection.Collection)
+	if err != nil {
+		return 0, err
+	}
+	if r.archive != nil {
+		archived, err := maxIncidentKey(ctx, r.archive.Collection)
+		if err != nil {
+			return 0, err
+		}
+		highest = max(highest, archived)
+	}
+
+	// Return the next ID, starting from 1 when no incidents exist
+	return highest + 1, nil
+}
+
+// maxIncidentKey returns the highest incident key in the collection, or 0 when it is empty
+func maxIncidentKey(ctx context.Context, collection *mongo.Collection) (int, error) {
+	opts := options.FindOne().SetSort(bson.D{bson.E{Key: "incident_key", Value: -1}})
+
+	var incident models.Incident
+	err := timed("next_key", func() error {
+		return collection.FindOne(ctx, bson.M{}, opts).Decode(&incident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get max incident key: %w", err)
+	}
+	return incident.IncidentKey, nil
+}
+
+// GetNextYearlyIncidentKey allocates the next incident key within the year of now,
+// using a per-year counter so numbering restarts at 1 each year
+func (r *IncidentRepository) GetNextYearlyIncidentKey(ctx context.Context, now time.Time) (int, error) {
+	year := now.UTC().Year()
+	sequence, err := r.NextSequence(ctx, fmt.Sprintf("incident_key_%d", year))
+	if err != nil {
+		return 0, err
+	}
+	return models.YearlyIncidentKey(year, sequence), nil
+}
+
+// GetOpenIncidentAgeBuckets counts unresolved incidents bucketed by their age relative to now
+func (r *IncidentRepository) GetOpenIncidentAgeBuckets(ctx context.Context, now time.Time) ([]models.AgeBucket, error) {
+	boundaries := ageBucketBoundaries(now)
+
+	pipeline := mongo.Pipeline{
+		{bson.E{Key: "$match", Value: bson.M{
+			"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+			"draft":  bson.M{"$ne": true},
+		}}},
+		{bson.E{Key: "$bucket", Value: bson.M{
+			"groupBy":    "$created_at",
+			"boundaries": boundaries,
+			"default":    "other",
+			"output":     bson.M{"count": bson.M{"$sum": 1}},
+		}}},
+	}
+
+	var results []struct {
+		ID    bson.RawValue `bson:"_id"`
+		Count int           `bson:"count"`
+	}
+	err := timed("stats_age_buckets", func() error {
+		cursor, err := r.collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate incident ages: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return fmt.Errorf("failed to decode incident ages: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make([]int, len(incidentAgeBuckets))
+	for _, result := range results {
+		lowerBound, ok := result.ID.DateTimeOK()
+		if !ok {
+			// Documents outside the boundaries (e.g. missing created_at)
+			continue
+		}
+		for i := range incidentAgeBuckets {
+			if primitive.NewDateTimeFromTime(boundaries[i]) == primitive.DateTime(lowerBound) {
+				counts[i] = result.Count
+				break
+			}
+		}
+	}
+
+	// Newest bucket first
+	buckets := make([]models.AgeBucket, 0, len(incidentAgeBuckets))
+	for i := len(incidentAgeBuckets) - 1; i >= 0; i-- {
+		buckets = append(buckets, models.AgeBucket{Label: incidentAgeBuckets[i], Count: counts[i]})
+	}
+
+	return buckets, nil
+}
+
+// CountOpenIncidentsBySeverity counts the open and in-progress incidents per severity
+func (r *IncidentRepository) CountOpenIncidentsBySeverity(ctx context.Context) (map[models.IncidentSeverity]int, error) {
+	pipeline := mongo.Pipeline{
+		{bson.E{Key: "$match", Value: bson.M{
+			"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+			"draft":  bson.M{"$ne": true},
+		}}},
+		{bson.E{Key: "$group", Value: bson.M{
+			"_id":   "$severity",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	var results []struct {
+		Severity models.IncidentSeverity `bson:"_id"`
+		Count    int                     `bson:"count"`
+	}
+	err := timed("stats_open_by_severity", func() error {
+		cursor, err := r.collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return fmt.Errorf("failed to aggregate open incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return fmt.Errorf("failed to decode open incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[models.IncidentSeverity]int)
+	for _, result := range results {
+		counts[result.Severity] += result.Count
+	}
+	return counts, nil
+}
+
+// GetAssigneeStats returns a page of open incident counts grouped by assignee,
+// with the total number of assignees
+func (r *IncidentRepository) GetAssigneeStats(ctx context.Context, page models.PageParams) ([]models.AssigneeStats, int, error) {
+	var results []struct {
+		Items []models.AssigneeStats `bson:"items"`
+		Total []struct {
+			Count int `bson:"count"`
+		} `bson:"total"`
+	}
+	err := timed("stats_by_assignee", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildAssigneeStatsPipeline(page))
+		if err != nil {
+			return fmt.Errorf("failed to aggregate assignee stats: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return fmt.Errorf("failed to decode assignee stats: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if len(results) == 0 {
+		return []models.AssigneeStats{}, 0, nil
+	}
+	total := 0
+	if len(results[0].Total) > 0 {
+		total = results[0].Total[0].Count
+	}
+	if results[0].Items == nil {
+		return []models.AssigneeStats{}, total, nil
+	}
+	return results[0].Items, total, nil
+}
+
+// buildAssigneeStatsPipeline groups the open incidents by assignee, busiest first
+// with ties broken by assignee so pages are stable, then cuts out the page
+func buildAssigneeStatsPipeline(page models.PageParams) mongo.Pipeline {
+	return mongo.Pipeline{
+		{bson.E{Key: "$match", Value: bson.M{
+			"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+			"draft":  bson.M{"$ne": true},
+		}}},
+		{bson.E{Key: "$group", Value: bson.M{
+			"_id":        bson.M{"$ifNull": bson.A{"$assignee", ""}},
+			"open_count": bson.M{"$sum": 1},
+			"critical_count": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$severity", models.Critical}}, 1, 0,
+			}}},
+		}}},
+		{bson.E{Key: "$facet", Value: bson.M{
+			"items": bson.A{
+				bson.M{"$sort": bson.D{{Key: "open_count", Value: -1}, {Key: "_id", Value: 1}}},
+				bson.M{"$skip": page.Skip()},
+				bson.M{"$limit": page.PageSize},
+			},
+			"total": bson.A{bson.M{"$count": "count"}},
+		}}},
+	}
+}
+
+// ageBucketBoundaries returns the ascending created_at boundaries for the age buckets
+func ageBucketBoundaries(now time.Time) []time.Time {
+	now = now.UTC().Truncate(time.Millisecond)
+	return []time.Time{
+		time.Unix(0, 0).UTC(),
+		now.Add(-24 * time.Hour),
+		now.Add(-4 * time.Hour),
+		now.Add(-1 * time.Hour),
+		now.AddDate(100, 0, 0),
+	}
+}
+
+// NextSequence atomically increments the named counter and returns its new value, starting at 1
+func (r *IncidentRepository) NextSequence(ctx context.Context, name string) (int, error) {
+	update := bson.M{"$inc": bson.M{"value": 1}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var counter struct {
+		Value int `bson:"value"`
+	}
+	err := timed("next_sequence", func() error {
+		return r.counters.FindOneAndUpdate(ctx, bson.M{"_id": name}, update, opts).Decode(&counter)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment counter %s: %w", name, err)
+	}
+
+	return counter.Value, nil
+}
+
+// timed runs a collection call and records its latency by operation and outcome
+func timed(operation string, call func() error) error {
+	start := time.Now()
+	err := call()
+	metrics.ObserveMongoOperation(operation, time.Since(start), err)
+	return err
+}
+
+// GetSeverityTrend counts incidents created within the range, grouped by the
+// interval the creation time falls in and by severity
+func (r *IncidentRepository) GetSeverityTrend(ctx context.Context, params models.TrendParams) ([]models.TrendPoint, error) {
+	var rows []trendRow
+	err := timed("stats_trend", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildTrendPipeline(params))
+		if err != nil {
+			return fmt.Errorf("failed to aggregate incident trend: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &rows); err != nil {
+			return fmt.Errorf("failed to decode incident trend: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return foldTrendRows(rows), nil
+}
+
+// trendRow is one (period, severity) group produced by the trend pipeline
+type trendRow struct {
+	ID struct {
+		Period   time.Time               `bson:"period"`
+		Severity models.IncidentSeverity `bson:"severity"`
+	} `bson:"_id"`
+	Count int `bson:"count"`
+}
+
+// buildTrendPipeline groups incidents by truncated creation time and severity
+func buildTrendPipeline(params models.TrendParams) mongo.Pipeline {
+	dateTrunc := bson.M{
+		"date":     "$created_at",
+		"unit":     string(params.Interval),
+		"timezone": "UTC",
+	}
+	if params.Interval == models.TrendWeek {
+		dateTrunc["startOfWeek"] = "monday"
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"created_at": bson.M{"$gte": params.From, "$lt": params.To},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"period":   bson.M{"$dateTrunc": dateTrunc},
+				"severity": "$severity",
+			},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id.period", Value: 1}}}},
+	}
+}
+
+// foldTrendRows merges the per-severity rows into one point per period, oldest first
+func foldTrendRows(rows []trendRow) []models.TrendPoint {
+	points := []models.TrendPoint{}
+	index := make(map[time.Time]int)
+
+	for _, row := range rows {
+		period := row.ID.Period.UTC()
+		i, ok := index[period]
+		if !ok {
+			i = len(points)
+			index[period] = i
+			points = append(points, models.TrendPoint{
+				Period:     period,
+				BySeverity: make(map[models.IncidentSeverity]int),
+			})
+		}
+		points[i].BySeverity[row.ID.Severity] += row.Count
+		points[i].Total += row.Count
+	}
+
+	sort.Slice(points, func(a, b int) bool {
+		return points[a].Period.Before(points[b].Period)
+	})
+	return points
+}
+
+// FindStaleCandidates returns unresolved incidents created at or before
+// createdBefore that have not yet been notified for maxThreshold
+func (r *IncidentRepository) FindStaleCandidates(ctx context.Context, createdBefore time.Time, maxThreshold time.Duration) ([]models.Incident, error) {
+	filter := bson.M{
+		"status":     bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+		"draft":      bson.M{"$ne": true},
+		"created_at": bson.M{"$lte": createdBefore},
+		"$or": []bson.M{
+			{"stale_notified_after": bson.M{"$exists": false}},
+			{"stale_notified_after": bson.M{"$lt": maxThreshold}},
+		},
+	}
+
+	var incidents []models.Incident
+	err := timed("find_stale", func() error {
+		cursor, err := r.collection.Find(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to find stale incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode stale incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// MarkStaleNotified records that the incident was notified for threshold. It only
+// matches incidents not yet notified for threshold, so concurrent runs claim each
+// notification once; claimed is false when another run got there first.
+func (r *IncidentRepository) MarkStaleNotified(ctx context.Context, incident *models.Incident, threshold time.Duration, now time.Time) (claimed bool, err error) {
+	filter := bson.M{
+		"_id": incident.ID,
+		"$or": []bson.M{
+			{"stale_notified_after": bson.M{"$exists": false}},
+			{"stale_notified_after": bson.M{"$lt": threshold}},
+		},
+	}
+	update := bson.M{"$set": bson.M{
+		"stale_notified_after": threshold,
+		"stale_notified_at":    now,
+	}}
+
+	var result *mongo.UpdateResult
+	err = timed("mark_stale", func() (err error) {
+		result, err = r.collection.UpdateOne(ctx, filter, update)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to mark incident stale: %w", err)
+	}
+
+	r.modified(incident)
+	return result.MatchedCount == 1, nil
+}
+
+// FindMissingFirstResponse returns up to limit incidents after the given id that
+// have notes but no recorded first response, in id order for resuming by batch
+func (r *IncidentRepository) FindMissingFirstResponse(ctx context.Context, after primitive.ObjectID, limit int64) ([]models.Incident, error) {
+	filter := bson.M{
+		"_id":               bson.M{"$gt": after},
+		"first_response_at": bson.M{"$exists": false},
+		"notes.0":           bson.M{"$exists": true},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "_id", Value: 1}}).
+		SetLimit(limit)
+
+	var incidents []models.Incident
+	err := timed("find_missing_first_response", func() error {
+		cursor, err := r.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to find incidents missing a first response: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode incidents missing a first response: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// MarkFirstResponse records the incident's first response unless one is already
+// recorded, so concurrent notes cannot move it. It reports whether this call
+// recorded it.
+func (r *IncidentRepository) MarkFirstResponse(ctx context.Context, incident *models.Incident, at time.Time, seconds float64) (claimed bool, err error) {
+	filter := bson.M{
+		"_id":               incident.ID,
+		"first_response_at": bson.M{"$exists": false},
+	}
+	update := bson.M{"$set": bson.M{
+		"first_response_at":      at,
+		"first_response_seconds": seconds,
+	}}
+
+	var result *mongo.UpdateResult
+	err = timed("mark_first_response", func() (err error) {
+		result, err = r.collection.UpdateOne(ctx, filter, update)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to mark first response: %w", err)
 	}
 
-	// Return the next ID
-	return incident.IncidentKey + 1, nil
+	r.modified(incident)
+	return result.MatchedCount == 1, nil
 }
@@ -0,0 +1,1199 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/metrics"
+	"makers.anchor/incident/internal/models"
+)
+
+func TestAgeBucketBoundaries_PlaceIncidentsByAge(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	boundaries := ageBucketBoundaries(now)
+
+	tests := []struct {
+		age      time.Duration
+		expected string
+	}{
+		{age: 30 * time.Minute, expected: "<1h"},
+		{age: 90 * time.Minute, expected: "1-4h"},
+		{age: 2 * time.Hour, expected: "1-4h"},
+		{age: 10 * time.Hour, expected: "4-24h"},
+		{age: 48 * time.Hour, expected: ">24h"},
+	}
+
+	for _, tt := range tests {
+		createdAt := now.Add(-tt.age)
+
+		// Act: $bucket semantics, lower bound inclusive and upper bound exclusive
+		label := ""
+		for i := range incidentAgeBuckets {
+			if !createdAt.Before(boundaries[i]) && createdAt.Before(boundaries[i+1]) {
+				label = incidentAgeBuckets[i]
+			}
+		}
+
+		// Assert
+		if label != tt.expected {
+			t.Errorf("Expected incident aged %s in bucket %s, got %q", tt.age, tt.expected, label)
+		}
+	}
+}
+
+func TestGetOpenIncidentAgeBuckets(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("labels bucket counts newest first", func(mt *mtest.T) {
+		// Arrange
+		now := time.Now()
+		boundaries := ageBucketBoundaries(now)
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: boundaries[0]}, {Key: "count", Value: 4}},
+			bson.D{{Key: "_id", Value: boundaries[2]}, {Key: "count", Value: 2}},
+			bson.D{{Key: "_id", Value: boundaries[3]}, {Key: "count", Value: 1}},
+			bson.D{{Key: "_id", Value: "other"}, {Key: "count", Value: 9}},
+		))
+
+		// Act
+		buckets, err := repo.GetOpenIncidentAgeBuckets(context.Background(), now)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		expected := []models.AgeBucket{
+			{Label: "<1h", Count: 1},
+			{Label: "1-4h", Count: 2},
+			{Label: "4-24h", Count: 0},
+			{Label: ">24h", Count: 4},
+		}
+		if len(buckets) != len(expected) {
+			t.Fatalf("Expected %d buckets, got %d", len(expected), len(buckets))
+		}
+		for i := range expected {
+			if buckets[i] != expected[i] {
+				t.Errorf("Expected bucket %d to be %+v, got %+v", i, expected[i], buckets[i])
+			}
+		}
+	})
+}
+
+func TestGetAllIncidents_SkipsUnknownEnumValuesBeforePaging(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("validity is part of the match and the count", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch,
+				bson.D{{Key: "incident_key", Value: 1}, {Key: "severity", Value: "high"}, {Key: "status", Value: "open"}},
+				bson.D{{Key: "incident_key", Value: 2}, {Key: "severity", Value: "CRIT"}, {Key: "status", Value: "in-progress"}},
+			),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "n", Value: 7}}),
+		)
+
+		// Act
+		incidents, total, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{Page: models.PageParams{Page: 1, PageSize: 2}})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(incidents) != 2 || total != 7 {
+			t.Fatalf("Expected a full page of 2 of 7, got %d of %d", len(incidents), total)
+		}
+		if incidents[1].Severity != models.Critical || incidents[1].Status != models.InProgress {
+			t.Errorf("Expected legacy values to be normalized, got %s and %s", incidents[1].Severity, incidents[1].Status)
+		}
+		for _, event := range mt.GetAllStartedEvents() {
+			pipeline := event.Command.Lookup("pipeline").Array()
+			checks := pipeline.Index(0).Value().Document().Lookup("$match", "$expr", "$and").Array()
+			values, _ := checks.Values()
+			if len(values) != 2 {
+				t.Errorf("Expected %s to check severity and status before paging, got %s", event.CommandName, pipeline)
+			}
+		}
+	})
+}
+
+func TestGetAllIncidents_ListsScheduledIncidents(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("scheduled incidents pass the validity check", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch,
+			bson.D{{Key: "incident_key", Value: 3}, {Key: "severity", Value: "low"}, {Key: "status", Value: "scheduled"}},
+		))
+
+		// Act
+		incidents, total, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{Scheduled: true})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(incidents) != 1 || total != 1 || incidents[0].Status != models.Scheduled {
+			t.Fatalf("Expected the scheduled incident to be listed, got %+v (total %d)", incidents, total)
+		}
+		match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+		if got := match.Lookup("status").StringValue(); got != "scheduled" {
+			t.Errorf("Expected only scheduled incidents, got %q", got)
+		}
+		statusCheck := match.Lookup("$expr", "$and").Array().Index(1).Value().Document()
+		accepted, _ := statusCheck.Lookup("$in").Array().Index(1).Value().Array().Values()
+		found := false
+		for _, value := range accepted {
+			found = found || value.StringValue() == "scheduled"
+		}
+		if !found {
+			t.Errorf("Expected scheduled to be a known status, got %s", statusCheck)
+		}
+	})
+}
+
+func TestStoredValueIn_AcceptsAliasesOnly(t *testing.T) {
+	// Act
+	condition := storedValueIn("severity", models.StoredSeverities())
+
+	// Assert
+	values := condition["$in"].(bson.A)[1].([]string)
+	for _, expected := range []string{"low", "critical", "crit", "major"} {
+		if !slices.Contains(values, expected) {
+			t.Errorf("Expected %q to be accepted, got %v", expected, values)
+		}
+	}
+	if slices.Contains(values, "sev0") {
+		t.Errorf("Expected unknown values to be rejected, got %v", values)
+	}
+}
+
+func TestCreate_RecordsMongoLatency(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("observes a create sample", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		before := mongoSampleCount(t, "create", metrics.OutcomeSuccess)
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// Act
+		_, err := repo.Create(context.Background(), &models.Incident{Title: "Cache miss storm"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if after := mongoSampleCount(t, "create", metrics.OutcomeSuccess); after != before+1 {
+			t.Errorf("Expected %d create samples, got %d", before+1, after)
+		}
+	})
+}
+
+func TestNewIncidentRepository_UsesConfiguredCollection(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("reads and writes hit the named collection", func(mt *mtest.T) {
+		// Arrange
+		repo := NewIncidentRepository(mt.DB, "staging_incidents")
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "db.staging_incidents", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "incident_key", Value: 7},
+			}),
+		)
+
+		// Act
+		_, createErr := repo.Create(context.Background(), &models.Incident{Title: "Cache miss storm"})
+		insert := mt.GetStartedEvent()
+		_, getErr := repo.GetByIncidentKey(context.Background(), 7)
+		find := mt.GetStartedEvent()
+
+		// Assert
+		if createErr != nil || getErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", createErr, getErr)
+		}
+		if got := insert.Command.Lookup("insert").StringValue(); got != "staging_incidents" {
+			t.Errorf("Expected insert into staging_incidents, got %s", got)
+		}
+		if got := find.Command.Lookup("find").StringValue(); got != "staging_incidents" {
+			t.Errorf("Expected find on staging_incidents, got %s", got)
+		}
+	})
+}
+
+// mongoSampleCount returns the number of observations recorded for the operation and outcome
+func mongoSampleCount(t *testing.T, operation, outcome string) uint64 {
+	t.Helper()
+
+	var metric dto.Metric
+	histogram := metrics.MongoOperationDuration.WithLabelValues(operation, outcome).(prometheus.Histogram)
+	if err := histogram.Write(&metric); err != nil {
+		t.Fatalf("Expected no error reading histogram, got %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestNextSequence_ReturnsIncrementedValue(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns the counter value", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{counters: mt.Coll}
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "value", Value: bson.D{{Key: "_id", Value: "oncall_rotation"}, {Key: "value", Value: 3}}},
+		))
+
+		// Act
+		sequence, err := repo.NextSequence(context.Background(), "oncall_rotation")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if sequence != 3 {
+			t.Errorf("Expected sequence 3, got %d", sequence)
+		}
+	})
+}
+
+func TestBuildIncidentFilter_NoteTypes(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   models.ListIncidentsParams
+		expected bson.M
+	}{
+		{
+			name:     "no filters",
+			params:   models.ListIncidentsParams{},
+			expected: bson.M{},
+		},
+		{
+			name:   "has note type",
+			params: models.ListIncidentsParams{HasNoteType: models.Investigation},
+			expected: bson.M{"$and": []bson.M{
+				{"notes": bson.M{"$elemMatch": bson.M{"type": models.Investigation}}},
+			}},
+		},
+		{
+			name:   "has investigation but missing resolution",
+			params: models.ListIncidentsParams{HasNoteType: models.Investigation, MissingNoteType: models.Resolution},
+			expected: bson.M{"$and": []bson.M{
+				{"notes": bson.M{"$elemMatch": bson.M{"type": models.Investigation}}},
+				{"notes": bson.M{"$not": bson.M{"$elemMatch": bson.M{"type": models.Resolution}}}},
+			}},
+		},
+		{
+			name:   "needs attention",
+			params: models.ListIncidentsParams{NeedsAttention: true, UpdatedBefore: time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)},
+			expected: bson.M{"$and": []bson.M{
+				{
+					"status":     bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+					"updated_at": bson.M{"$lt": time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)},
+				},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			filter := buildIncidentFilter(tt.params)
+
+			// Assert
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("Expected filter %v, got %v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+func TestBuildIncidentSort_UsesIDTiebreaker(t *testing.T) {
+	tests := []struct {
+		name      string
+		field     string
+		direction int
+		expected  bson.D
+	}{
+		{
+			name:      "default newest first",
+			field:     "",
+			direction: 0,
+			expected:  bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}},
+		},
+		{
+			name:      "configured ascending field",
+			field:     "incident_key",
+			direction: 1,
+			expected:  bson.D{{Key: "incident_key", Value: 1}, {Key: "_id", Value: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			sort := buildIncidentSort(tt.field, tt.direction)
+
+			// Assert
+			if !reflect.DeepEqual(sort, tt.expected) {
+				t.Errorf("Expected sort %v, got %v", tt.expected, sort)
+			}
+		})
+	}
+}
+
+func TestGetAllIncidents_StableOrderForEqualTimestamps(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("sends the tiebreaker with every query", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		params := models.ListIncidentsParams{SortField: "created_at", SortDirection: -1}
+
+		for i := 0; i < 2; i++ {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+			// Act
+			if _, _, err := repo.GetAllIncidents(context.Background(), params); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Assert
+			pipeline, err := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+			if err != nil {
+				t.Fatalf("Expected aggregation pipeline, got %v", err)
+			}
+			sort := pipeline[1].Document().Lookup("$sort").Document()
+			keys, err := sort.Elements()
+			if err != nil {
+				t.Fatalf("Expected sort document, got %v", err)
+			}
+			if len(keys) != 2 || keys[0].Key() != "created_at" || keys[1].Key() != "_id" {
+				t.Errorf("Expected sort on created_at then _id, got %v", sort)
+			}
+		}
+	})
+}
+
+func TestAddWatcherToIncident_DeduplicatesByEmail(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("same email with a different name updates the single entry", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		id := primitive.NewObjectID()
+		incidentWith := func(name string) bson.D {
+			return bson.D{
+				{Key: "_id", Value: id},
+				{Key: "watchlist", Value: bson.A{bson.D{{Key: "email", Value: "alice@example.com"}, {Key: "name", Value: name}}}},
+			}
+		}
+		mt.AddMockResponses(
+			// First add: no existing entry, so the watcher is appended
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incidentWith("Alice")}),
+			// Second add: the existing entry is updated in place
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incidentWith("Alice Smith")}),
+		)
+
+		// Act
+		if _, err := repo.AddWatcherToIncident(context.Background(), id.Hex(), models.Watcher{Email: "alice@example.com", Name: "Alice"}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.ClearEvents()
+		incident, err := repo.AddWatcherToIncident(context.Background(), id.Hex(), models.Watcher{Email: "alice@example.com", Name: "Alice Smith"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(incident.WatchList) != 1 || incident.WatchList[0].Name != "Alice Smith" {
+			t.Errorf("Expected a single updated watcher, got %+v", incident.WatchList)
+		}
+
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		if _, err := update.LookupErr("$push"); err == nil {
+			t.Error("Expected existing watcher to be updated in place, got $push")
+		}
+		if next := mt.GetStartedEvent(); next != nil {
+			t.Errorf("Expected a single command for an existing watcher, got %s", next.CommandName)
+		}
+	})
+}
+
+func TestGetAllIncidents_ReturnsCountsWithoutArrays(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("list items carry counts", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "incident_key", Value: 12},
+			{Key: "severity", Value: "high"},
+			{Key: "status", Value: "open"},
+			{Key: "watcher_count", Value: 3},
+			{Key: "note_count", Value: 5},
+		}))
+
+		// Act
+		incidents, _, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(incidents) != 1 || incidents[0].WatcherCount != 3 || incidents[0].NoteCount != 5 {
+			t.Fatalf("Expected watcher_count 3 and note_count 5, got %+v", incidents)
+		}
+
+		payload, err := json.Marshal(incidents[0])
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(payload, &fields); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, omitted := range []string{"notes", "watchlist", "attachments"} {
+			if _, ok := fields[omitted]; ok {
+				t.Errorf("Expected list item without %s, got %s", omitted, payload)
+			}
+		}
+
+		pipeline, err := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+		if err != nil {
+			t.Fatalf("Expected aggregation pipeline, got %v", err)
+		}
+		project := pipeline[len(pipeline)-1].Document().Lookup("$project").Document()
+		for _, omitted := range []string{"notes", "watchlist"} {
+			if _, err := project.LookupErr(omitted); err != nil {
+				t.Errorf("Expected %s to be projected out", omitted)
+			}
+		}
+	})
+}
+
+func TestGetAllIncidents_EmptyCollectionEncodesAsArray(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("no incidents", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		incidents, _, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		payload, err := json.Marshal(map[string]any{"data": incidents})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if string(payload) != `{"data":[]}` {
+			t.Errorf("Expected data to be [], got %s", payload)
+		}
+	})
+}
+
+func TestGetAllIncidents_CancelledContextAbortsQuery(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("cancelled request", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		// Act
+		_, _, err := repo.GetAllIncidents(ctx, models.ListIncidentsParams{})
+
+		// Assert
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestGetAllIncidents_Paginates(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+	incident := func(key int) bson.D {
+		return bson.D{{Key: "_id", Value: primitive.NewObjectID()}, {Key: "incident_key", Value: key}, {Key: "severity", Value: "high"}, {Key: "status", Value: "open"}}
+	}
+
+	mt.Run("full page counts every match", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(3), incident(4)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "n", Value: 5}}),
+		)
+
+		// Act
+		incidents, total, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{Page: models.PageParams{Page: 2, PageSize: 2}})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(incidents) != 2 || total != 5 {
+			t.Fatalf("Expected 2 of 5 incidents, got %d of %d", len(incidents), total)
+		}
+		pipeline := mt.GetStartedEvent().Command.Lookup("pipeline").Array()
+		if skip := pipeline.Index(2).Value().Document().Lookup("$skip").AsInt64(); skip != 2 {
+			t.Errorf("Expected to skip 2 incidents, got %d", skip)
+		}
+		if limit := pipeline.Index(3).Value().Document().Lookup("$limit").AsInt64(); limit != 2 {
+			t.Errorf("Expected a limit of 2, got %d", limit)
+		}
+	})
+
+	mt.Run("partial page ends the list without counting", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(5)))
+
+		// Act
+		_, total, err := repo.GetAllIncidents(context.Background(), models.ListIncidentsParams{Page: models.PageParams{Page: 3, PageSize: 2}})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if total != 5 {
+			t.Errorf("Expected a total of 5, got %d", total)
+		}
+		mt.GetStartedEvent() // the page
+		if event := mt.GetStartedEvent(); event != nil {
+			t.Errorf("Expected no count query, got %s", event.CommandName)
+		}
+	})
+}
+
+func TestGetByID_ExplicitAndDetectedLookups(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	objectID := primitive.NewObjectID()
+	stored := bson.D{
+		{Key: "_id", Value: objectID},
+		{Key: "incident_key", Value: 7},
+		{Key: "title", Value: "Queue backlog"},
+	}
+
+	tests := []struct {
+		name          string
+		lookup        func(repo *IncidentRepository) (*models.Incident, error)
+		expectedKey   string
+		expectedValue any
+	}{
+		{
+			name: "by incident key",
+			lookup: func(repo *IncidentRepository) (*models.Incident, error) {
+				return repo.GetByIncidentKey(context.Background(), 7)
+			},
+			expectedKey:   "incident_key",
+			expectedValue: int32(7),
+		},
+		{
+			name: "by object id",
+			lookup: func(repo *IncidentRepository) (*models.Incident, error) {
+				return repo.GetByObjectID(context.Background(), objectID)
+			},
+			expectedKey:   "_id",
+			expectedValue: objectID,
+		},
+		{
+			name: "ambiguous numeric id",
+			lookup: func(repo *IncidentRepository) (*models.Incident, error) {
+				return repo.GetByID(context.Background(), "7")
+			},
+			expectedKey:   "incident_key",
+			expectedValue: int32(7),
+		},
+		{
+			name: "ambiguous hex id",
+			lookup: func(repo *IncidentRepository) (*models.Incident, error) {
+				return repo.GetByID(context.Background(), objectID.Hex())
+			},
+			expectedKey:   "_id",
+			expectedValue: objectID,
+		},
+		{
+			name: "ambiguous 24 digit id resolves by key first",
+			lookup: func(repo *IncidentRepository) (*models.Incident, error) {
+				return repo.GetByID(context.Background(), "000000000000000000000007")
+			},
+			expectedKey:   "incident_key",
+			expectedValue: int32(7),
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, stored))
+
+			// Act
+			incident, err := tt.lookup(repo)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if incident.ID != objectID || incident.IncidentKey != 7 {
+				t.Errorf("Expected incident 7 (%s), got %d (%s)", objectID.Hex(), incident.IncidentKey, incident.ID.Hex())
+			}
+
+			filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+			value, err := filter.LookupErr(tt.expectedKey)
+			if err != nil {
+				t.Fatalf("Expected filter on %s, got %v", tt.expectedKey, filter)
+			}
+			var decoded any
+			switch tt.expectedValue.(type) {
+			case primitive.ObjectID:
+				decoded = value.ObjectID()
+			default:
+				decoded = value.Int32()
+			}
+			if decoded != tt.expectedValue {
+				t.Errorf("Expected %s %v, got %v", tt.expectedKey, tt.expectedValue, decoded)
+			}
+		})
+	}
+}
+
+func TestBuildNoteSearchPipeline_MatchesContentAndAuthor(t *testing.T) {
+	// Act
+	pipeline := buildNoteSearchPipeline(bson.M{"incident_key": 7}, "db.primary")
+
+	// Assert
+	project := pipeline[1][0].Value.(bson.M)
+	filter := project["notes"].(bson.M)["$filter"].(bson.M)
+	conditions := filter["cond"].(bson.M)["$or"].(bson.A)
+
+	fields := map[string]bool{}
+	for _, condition := range conditions {
+		regexMatch := condition.(bson.M)["$regexMatch"].(bson.M)
+		fields[regexMatch["input"].(string)] = true
+		if regexMatch["regex"] != `db\.primary` {
+			t.Errorf("Expected the query to be matched literally, got %v", regexMatch["regex"])
+		}
+		if regexMatch["options"] != "i" {
+			t.Errorf("Expected case-insensitive matching, got %v", regexMatch["options"])
+		}
+	}
+	if !fields["$$note.content"] || !fields["$$note.author_email"] {
+		t.Errorf("Expected content and author email to be searched, got %v", fields)
+	}
+}
+
+func TestSearchNotes_ReturnsMatchingNotes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name     string
+		query    string
+		matching bson.A
+		expected string
+	}{
+		{
+			name:     "content match",
+			query:    "FAILOVER",
+			matching: bson.A{bson.D{{Key: "content", Value: "Started failover"}, {Key: "author_email", Value: "bob@example.com"}}},
+			expected: "Started failover",
+		},
+		{
+			name:     "author match",
+			query:    "alice@",
+			matching: bson.A{bson.D{{Key: "content", Value: "Paged DBA"}, {Key: "author_email", Value: "alice@example.com"}}},
+			expected: "Paged DBA",
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "notes", Value: tt.matching}}))
+
+			// Act
+			notes, err := repo.SearchNotes(context.Background(), "7", tt.query)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(notes) != 1 || notes[0].Content != tt.expected {
+				t.Errorf("Expected note %q, got %+v", tt.expected, notes)
+			}
+		})
+	}
+
+	mt.Run("unknown incident", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		_, err := repo.SearchNotes(context.Background(), "99", "failover")
+
+		// Assert
+		if !errors.Is(err, ErrIncidentNotFound) {
+			t.Errorf("Expected incident not found, got %v", err)
+		}
+	})
+}
+
+func TestGetSeverityTrend_BucketsAcrossTwoDays(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("folds severity groups into daily points", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		day1 := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+		day2 := day1.AddDate(0, 0, 1)
+		group := func(period time.Time, severity string, count int) bson.D {
+			return bson.D{
+				{Key: "_id", Value: bson.D{{Key: "period", Value: period}, {Key: "severity", Value: severity}}},
+				{Key: "count", Value: count},
+			}
+		}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch,
+			group(day1, "high", 2),
+			group(day2, "critical", 1),
+			group(day1, "low", 1),
+			group(day2, "high", 3),
+		))
+
+		// Act
+		trend, err := repo.GetSeverityTrend(context.Background(), models.TrendParams{
+			Interval: models.TrendDay,
+			From:     day1,
+			To:       day2.AddDate(0, 0, 1),
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := []models.TrendPoint{
+			{Period: day1, BySeverity: map[models.IncidentSeverity]int{models.High: 2, models.Low: 1}, Total: 3},
+			{Period: day2, BySeverity: map[models.IncidentSeverity]int{models.Critical: 1, models.High: 3}, Total: 4},
+		}
+		if !reflect.DeepEqual(trend, expected) {
+			t.Errorf("Expected trend %+v, got %+v", expected, trend)
+		}
+
+		pipeline, err := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+		if err != nil {
+			t.Fatalf("Expected aggregation pipeline, got %v", err)
+		}
+		unit := pipeline[1].Document().Lookup("$group", "_id", "period", "$dateTrunc", "unit").StringValue()
+		if unit != "day" {
+			t.Errorf("Expected truncation by day, got %s", unit)
+		}
+	})
+}
+
+func TestGetNextYearlyIncidentKey_ResetsEachYear(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("new year starts a new counter", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{counters: mt.Coll}
+		lastDay := time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)
+		firstDay := time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{{Key: "_id", Value: "incident_key_2024"}, {Key: "value", Value: 57}}}),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: bson.D{{Key: "_id", Value: "incident_key_2025"}, {Key: "value", Value: 1}}}),
+		)
+
+		// Act
+		lastKey, err := repo.GetNextYearlyIncidentKey(context.Background(), lastDay)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		lastCounter := mt.GetStartedEvent().Command.Lookup("query", "_id").StringValue()
+		firstKey, err := repo.GetNextYearlyIncidentKey(context.Background(), firstDay)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		firstCounter := mt.GetStartedEvent().Command.Lookup("query", "_id").StringValue()
+
+		// Assert
+		if lastKey != 2024000057 {
+			t.Errorf("Expected key 2024000057, got %d", lastKey)
+		}
+		if firstKey != 2025000001 {
+			t.Errorf("Expected key 2025000001, got %d", firstKey)
+		}
+		if lastCounter != "incident_key_2024" || firstCounter != "incident_key_2025" {
+			t.Errorf("Expected per-year counters, got %s and %s", lastCounter, firstCounter)
+		}
+	})
+}
+
+func TestGetRelatedIncidents_RanksByOverlap(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("two shared tags rank above one", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		incident := &models.Incident{ID: primitive.NewObjectID(), Tags: []string{"database", "eu-west"}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch,
+			bson.D{{Key: "incident_key", Value: 2}, {Key: "tags", Value: bson.A{"database", "eu-west"}}, {Key: "overlap", Value: 2}},
+			bson.D{{Key: "incident_key", Value: 3}, {Key: "tags", Value: bson.A{"database"}}, {Key: "overlap", Value: 1}},
+		))
+
+		// Act
+		related, err := repo.GetRelatedIncidents(context.Background(), incident, 10)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(related) != 2 || related[0].IncidentKey != 2 || related[0].Overlap != 2 || related[1].Overlap != 1 {
+			t.Fatalf("Expected incident 2 (overlap 2) then 3 (overlap 1), got %+v", related)
+		}
+
+		pipeline, err := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+		if err != nil {
+			t.Fatalf("Expected aggregation pipeline, got %v", err)
+		}
+		excluded := pipeline[0].Document().Lookup("$match", "_id", "$ne").ObjectID()
+		if excluded != incident.ID {
+			t.Errorf("Expected the incident itself to be excluded, got %s", excluded.Hex())
+		}
+		sortKeys, _ := pipeline[2].Document().Lookup("$sort").Document().Elements()
+		if sortKeys[0].Key() != "overlap" || sortKeys[0].Value().Int32() != -1 {
+			t.Errorf("Expected ranking by overlap descending, got %v", sortKeys)
+		}
+	})
+}
+
+func TestBuildIncidentFilter_CustomField(t *testing.T) {
+	// Arrange
+	params := models.ListIncidentsParams{CustomFields: map[string]interface{}{"jira_ticket": "OPS-12"}}
+
+	// Act
+	filter := buildIncidentFilter(params)
+
+	// Assert
+	expected := bson.M{"$and": []bson.M{{"custom_fields.jira_ticket": "OPS-12"}}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected filter %v, got %v", expected, filter)
+	}
+}
+
+func TestBuildIncidentFilter_OrGroups(t *testing.T) {
+	openOrCritical := []models.FilterCondition{{Field: models.FilterStatus, Value: "open"}, {Field: models.FilterSeverity, Value: "critical"}}
+
+	tests := []struct {
+		name     string
+		params   models.ListIncidentsParams
+		expected bson.M
+	}{
+		{
+			name:   "group matches the union of its conditions",
+			params: models.ListIncidentsParams{AnyOf: [][]models.FilterCondition{openOrCritical}},
+			expected: bson.M{"$and": []bson.M{
+				{"$or": []bson.M{{"status": "open"}, {"severity": "critical"}}},
+			}},
+		},
+		{
+			name: "groups AND with each other and the other filters",
+			params: models.ListIncidentsParams{
+				HasNoteType: models.Update,
+				AnyOf: [][]models.FilterCondition{
+					openOrCritical,
+					{{Field: models.FilterTag, Value: "db"}, {Field: models.FilterService, Value: "payments"}},
+				},
+			},
+			expected: bson.M{"$and": []bson.M{
+				{"notes": bson.M{"$elemMatch": bson.M{"type": models.Update}}},
+				{"$or": []bson.M{{"status": "open"}, {"severity": "critical"}}},
+				{"$or": []bson.M{{"tags": "db"}, {"services": "payments"}}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			filter := buildIncidentFilter(tt.params)
+
+			// Assert
+			if !reflect.DeepEqual(filter, tt.expected) {
+				t.Errorf("Expected filter %v, got %v", tt.expected, filter)
+			}
+		})
+	}
+}
+
+func TestBuildIncidentFilter_StatusSeverityAssignee(t *testing.T) {
+	// Arrange
+	params := models.ListIncidentsParams{Status: models.InProgress, Severity: models.High, Assignee: "alice@example.com"}
+
+	// Act
+	filter := buildIncidentFilter(params)
+
+	// Assert
+	expected := bson.M{"$and": []bson.M{
+		{"status": models.InProgress},
+		{"severity": models.High},
+		{"assignee": "alice@example.com"},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected filter %v, got %v", expected, filter)
+	}
+}
+
+func TestBuildIncidentFilter_SLACombinesWithOtherFilters(t *testing.T) {
+	// Arrange
+	cutoff := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	params := models.ListIncidentsParams{
+		HasNoteType: models.Update,
+		SLAStatus:   models.SLABreached,
+		SLAWindows:  []models.SLAWindow{{Severity: models.Critical, CreatedBefore: cutoff}},
+	}
+
+	// Act
+	filter := buildIncidentFilter(params)
+
+	// Assert
+	expected := bson.M{"$and": []bson.M{
+		{"notes": bson.M{"$elemMatch": bson.M{"type": models.Update}}},
+		{
+			"status": bson.M{"$in": []models.IncidentStatus{models.Open, models.InProgress}},
+			"$or":    []bson.M{{"severity": models.Critical, "created_at": bson.M{"$lte": cutoff}}},
+		},
+	}}
+	if !reflect.DeepEqual(filter, expected) {
+		t.Errorf("Expected filter %v, got %v", expected, filter)
+	}
+}
+
+func TestGetFacets_ReturnsDistinctValues(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("duplicates and empty values are dropped", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		values := func(vs ...string) bson.A {
+			docs := bson.A{}
+			for _, v := range vs {
+				docs = append(docs, bson.D{{Key: "_id", Value: v}})
+			}
+			return docs
+		}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "assignees", Value: values("bob@example.com", "", "alice@example.com", "bob@example.com")},
+			{Key: "tags", Value: values("eu-west", "database", "database")},
+			{Key: "services", Value: values("checkout")},
+		}))
+
+		// Act
+		facets, err := repo.GetFacets(context.Background(), models.ListIncidentsParams{NeedsAttention: true})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := &models.IncidentFacets{
+			Assignees: []string{"alice@example.com", "bob@example.com"},
+			Tags:      []string{"database", "eu-west"},
+			Services:  []string{"checkout"},
+		}
+		if !reflect.DeepEqual(facets, expected) {
+			t.Errorf("Expected facets %+v, got %+v", expected, facets)
+		}
+
+		pipeline, err := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Values()
+		if err != nil {
+			t.Fatalf("Expected aggregation pipeline, got %v", err)
+		}
+		if _, err := pipeline[0].Document().LookupErr("$match", "$and"); err != nil {
+			t.Errorf("Expected facets to be scoped by the list filters, got %v", pipeline[0])
+		}
+	})
+}
+
+func TestVisibleIncidentFilter_HidesDrafts(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   models.ListIncidentsParams
+		expected interface{}
+	}{
+		{name: "default lists published incidents", params: models.ListIncidentsParams{}, expected: bson.M{"$ne": true}},
+		{name: "drafts lists only drafts", params: models.ListIncidentsParams{Drafts: true}, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			filter := visibleIncidentFilter(tt.params)
+
+			// Assert
+			if !reflect.DeepEqual(filter["draft"], tt.expected) {
+				t.Errorf("Expected draft condition %v, got %v", tt.expected, filter["draft"])
+			}
+		})
+	}
+}
+
+func TestVisibleIncidentFilter_HidesScheduled(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   models.ListIncidentsParams
+		expected interface{}
+	}{
+		{name: "default lists active incidents", params: models.ListIncidentsParams{}, expected: bson.M{"$ne": models.Scheduled}},
+		{name: "scheduled lists only scheduled incidents", params: models.ListIncidentsParams{Scheduled: true}, expected: models.Scheduled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			filter := visibleIncidentFilter(tt.params)
+
+			// Assert
+			if !reflect.DeepEqual(filter["status"], tt.expected) {
+				t.Errorf("Expected status condition %v, got %v", tt.expected, filter["status"])
+			}
+		})
+	}
+}
+
+func TestGetAssigneeStats_GroupsAndPaginates(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("second page", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "items", Value: bson.A{
+				bson.D{{Key: "_id", Value: "carol@example.com"}, {Key: "open_count", Value: 2}, {Key: "critical_count", Value: 1}},
+				bson.D{{Key: "_id", Value: ""}, {Key: "open_count", Value: 1}, {Key: "critical_count", Value: 0}},
+			}},
+			{Key: "total", Value: bson.A{bson.D{{Key: "count", Value: 5}}}},
+		}))
+
+		// Act
+		items, total, err := repo.GetAssigneeStats(context.Background(), models.PageParams{Page: 2, PageSize: 2})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if total != 5 || len(items) != 2 {
+			t.Fatalf("Expected 2 of 5 assignees, got %d of %d", len(items), total)
+		}
+		if items[0].Assignee != "carol@example.com" || items[0].OpenCount != 2 || items[0].CriticalCount != 1 {
+			t.Errorf("Expected carol with 2 open incidents, got %+v", items[0])
+		}
+		if items[1].Assignee != "" {
+			t.Errorf("Expected unassigned incidents grouped under an empty assignee, got %+v", items[1])
+		}
+
+		pipeline := mt.GetStartedEvent().Command.Lookup("pipeline").Array()
+		group := pipeline.Index(1).Value().Document().Lookup("$group").Document()
+		if _, err := group.LookupErr("open_count"); err != nil {
+			t.Errorf("Expected incidents grouped by assignee, got %v", group)
+		}
+		page := pipeline.Index(2).Value().Document().Lookup("$facet", "items").Array()
+		if skip := page.Index(1).Value().Document().Lookup("$skip").AsInt64(); skip != 2 {
+			t.Errorf("Expected to skip 2 assignees, got %d", skip)
+		}
+		if limit := page.Index(2).Value().Document().Lookup("$limit").AsInt64(); limit != 2 {
+			t.Errorf("Expected a limit of 2, got %d", limit)
+		}
+	})
+
+	mt.Run("no open incidents", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "items", Value: bson.A{}},
+			{Key: "total", Value: bson.A{}},
+		}))
+
+		// Act
+		items, total, err := repo.GetAssigneeStats(context.Background(), models.PageParams{Page: 1, PageSize: 20})
+
+		// Assert
+		if err != nil || total != 0 || items == nil || len(items) != 0 {
+			t.Errorf("Expected an empty page, got %v, %d, %v", items, total, err)
+		}
+	})
+}
+
+func TestEnsureUniqueIndexes_PartialPerTenant(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name   string
+		ensure func(*IncidentRepository) error
+		field  string
+	}{
+		{
+			name:   "idempotency key",
+			ensure: func(repo *IncidentRepository) error { return repo.EnsureIdempotencyKeyIndex(context.Background()) },
+			field:  "idempotency_key",
+		},
+		{
+			name:   "external id",
+			ensure: func(repo *IncidentRepository) error { return repo.EnsureExternalIDIndex(context.Background()) },
+			field:  "external_id",
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+			mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+			// Act
+			err := tt.ensure(repo)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			index := mt.GetStartedEvent().Command.Lookup("indexes").Array().Index(0).Value().Document()
+			if unique, ok := index.Lookup("unique").BooleanOK(); !ok || !unique {
+				t.Errorf("Expected a unique index, got %s", index)
+			}
+			keys, _ := index.Lookup("key").Document().Elements()
+			if len(keys) != 2 || keys[0].Key() != "tenant_id" || keys[1].Key() != tt.field {
+				t.Errorf("Expected keys tenant_id and %s, got %s", tt.field, index.Lookup("key"))
+			}
+			if _, err := index.LookupErr("partialFilterExpression", tt.field, "$exists"); err != nil {
+				t.Errorf("Expected only incidents with a %s to be indexed, got %s", tt.field, index)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/models"
+)
+
+const (
+	OutagesCollection = "outages"
+)
+
+// ErrOutageNotFound is returned when no outage matches the lookup
+var ErrOutageNotFound = errors.New("outage not found")
+
+// OutageRepository handles outage database operations
+type OutageRepository struct {
+	collection tenantCollection
+}
+
+// NewOutageRepository creates a new outage repository
+func NewOutageRepository(db *mongo.Database) *OutageRepository {
+	return &OutageRepository{
+		collection: tenantCollection{db.Collection(OutagesCollection)},
+	}
+}
+
+// Create creates a new outage
+func (r *OutageRepository) Create(ctx context.Context, outage *models.Outage) (*models.Outage, error) {
+	now := time.Now()
+	outage.ID = primitive.NewObjectID()
+	outage.CreatedAt = now
+	outage.UpdatedAt = now
+
+	err := timed("outage_create", func() error {
+		_, err := r.collection.InsertOne(ctx, outage)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outage: %w", err)
+	}
+
+	return outage, nil
+}
+
+// GetByID retrieves an outage by its ObjectID
+func (r *OutageRepository) GetByID(ctx context.Context, id string) (*models.Outage, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid outage ID format: %w", err)
+	}
+
+	var outage models.Outage
+	err = timed("outage_get", func() error {
+		return r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&outage)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrOutageNotFound
+		}
+		return nil, fmt.Errorf("failed to get outage: %w", err)
+	}
+
+	return &outage, nil
+}
+
+// UpdateStatus updates the status of an outage
+func (r *OutageRepository) UpdateStatus(ctx context.Context, id primitive.ObjectID, status models.OutageStatus) (*models.Outage, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"status":     status,
+			"updated_at": time.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updatedOutage models.Outage
+	err := timed("outage_update_status", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts).Decode(&updatedOutage)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrOutageNotFound
+		}
+		return nil, fmt.Errorf("failed to update outage status: %w", err)
+	}
+
+	return &updatedOutage, nil
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/models"
+)
+
+const (
+	SavedSearchesCollection = "saved_searches"
+)
+
+// ErrSavedSearchNotFound is returned when no saved search matches the lookup
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// ErrInvalidSavedSearchID is returned when a saved search ID is not a valid ObjectID
+var ErrInvalidSavedSearchID = errors.New("invalid saved search ID format")
+
+// SavedSearchRepository handles saved search database operations
+type SavedSearchRepository struct {
+	collection tenantCollection
+}
+
+// NewSavedSearchRepository creates a new saved search repository
+func NewSavedSearchRepository(db *mongo.Database) *SavedSearchRepository {
+	return &SavedSearchRepository{
+		collection: tenantCollection{db.Collection(SavedSearchesCollection)},
+	}
+}
+
+// Create creates a new saved search
+func (r *SavedSearchRepository) Create(ctx context.Context, search *models.SavedSearch) (*models.SavedSearch, error) {
+	now := time.Now()
+	search.ID = primitive.NewObjectID()
+	search.CreatedAt = now
+	search.UpdatedAt = now
+
+	err := timed("saved_search_create", func() error {
+		_, err := r.collection.InsertOne(ctx, search)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create saved search: %w", err)
+	}
+
+	return search, nil
+}
+
+// GetByID retrieves a saved search by its ObjectID
+func (r *SavedSearchRepository) GetByID(ctx context.Context, id string) (*models.SavedSearch, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSavedSearchID, err)
+	}
+
+	var search models.SavedSearch
+	err = timed("saved_search_get", func() error {
+		return r.collection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&search)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSavedSearchNotFound
+		}
+		return nil, fmt.Errorf("failed to get saved search: %w", err)
+	}
+
+	return &search, nil
+}
+
+// List retrieves every saved search, ordered by name
+func (r *SavedSearchRepository) List(ctx context.Context) ([]models.SavedSearch, error) {
+	searches := []models.SavedSearch{}
+	err := timed("saved_search_list", func() error {
+		cursor, err := r.collection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+		return cursor.All(ctx, &searches)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// Update replaces the name and filter of a saved search
+func (r *SavedSearchRepository) Update(ctx context.Context, id string, name string, filter models.SearchFilter) (*models.SavedSearch, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSavedSearchID, err)
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"name":       name,
+			"filter":     filter,
+			"updated_at": time.Now(),
+		},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var search models.SavedSearch
+	err = timed("saved_search_update", func() error {
+		return r.collection.FindOneAndUpdate(ctx, bson.M{"_id": objectID}, update, opts).Decode(&search)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrSavedSearchNotFound
+		}
+		return nil, fmt.Errorf("failed to update saved search: %w", err)
+	}
+
+	return &search, nil
+}
+
+// Delete removes a saved search
+func (r *SavedSearchRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedSearchID, err)
+	}
+
+	var result *mongo.DeleteResult
+	err = timed("saved_search_delete", func() (err error) {
+		result, err = r.collection.DeleteOne(ctx, bson.M{"_id": objectID})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrSavedSearchNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrNotScheduled is returned when activating an incident that is no longer
+// scheduled or not yet due
+var ErrNotScheduled = errors.New("incident is not due for activation")
+
+// FindDueScheduled returns the scheduled incidents whose activation time is at
+// or before now, earliest first
+func (r *IncidentRepository) FindDueScheduled(ctx context.Context, now time.Time) ([]models.Incident, error) {
+	filter := bson.M{
+		"status":      models.Scheduled,
+		"activate_at": bson.M{"$lte": now},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "activate_at", Value: 1}})
+
+	var incidents []models.Incident
+	err := timed("find_due_scheduled", func() error {
+		cursor, err := r.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return fmt.Errorf("failed to find scheduled incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &incidents); err != nil {
+			return fmt.Errorf("failed to decode scheduled incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return incidents, nil
+}
+
+// ActivateScheduled opens a scheduled incident that is due at now. It only
+// matches incidents still scheduled, so concurrent runs activate each incident
+// once; the others get ErrNotScheduled.
+func (r *IncidentRepository) ActivateScheduled(ctx context.Context, incidentID primitive.ObjectID, now time.Time) (*models.Incident, error) {
+	filter := bson.M{
+		"_id":         incidentID,
+		"status":      models.Scheduled,
+		"activate_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{
+		"status":            models.Open,
+		"status_changed_at": now,
+		"updated_at":        now,
+	}}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var activatedIncident models.Incident
+	err := timed("activate_scheduled", func() error {
+		return r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&activatedIncident)
+	})
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, ErrNotScheduled
+		}
+		return nil, fmt.Errorf("failed to activate incident: %w", err)
+	}
+
+	return r.modified(&activatedIncident), nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/models"
+)
+
+// textIndexName names the text index over incident titles and descriptions
+const textIndexName = "incident_text"
+
+// textIndexFields are the search fields the text index covers
+var textIndexFields = []models.SearchField{models.SearchTitle, models.SearchDescription}
+
+// searchFieldPaths maps search fields to the document paths they match
+var searchFieldPaths = map[models.SearchField]string{
+	models.SearchTitle:       "title",
+	models.SearchDescription: "description",
+	models.SearchNotes:       "notes.content",
+}
+
+// EnsureTextIndex creates the text index SearchIncidents relies on. Creating an
+// index that already exists with the same keys is a no-op.
+func (r *IncidentRepository) EnsureTextIndex(ctx context.Context) error {
+	_, err := r.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "title", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().SetName(textIndexName),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create text index: %w", err)
+	}
+	return nil
+}
+
+// SearchIncidents returns the published, active incidents whose fields match the
+// query. No fields searches the title and description.
+func (r *IncidentRepository) SearchIncidents(ctx context.Context, query string, fields []models.SearchField, limit int) ([]models.IncidentSearchResult, error) {
+	var results []models.IncidentSearchResult
+	err := timed("search", func() error {
+		cursor, err := r.collection.Aggregate(ctx, buildSearchPipeline(query, fields, limit))
+		if err != nil {
+			return fmt.Errorf("failed to search incidents: %w", err)
+		}
+		defer cursor.Close(ctx)
+
+		if err := cursor.All(ctx, &results); err != nil {
+			return fmt.Errorf("failed to decode incidents: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if results == nil {
+		return []models.IncidentSearchResult{}, nil
+	}
+	return results, nil
+}
+
+// buildSearchPipeline matches the query against the fields. A search over exactly
+// the indexed fields uses the text index and ranks matches by text score, with the
+// $text match as the first stage. A text index always covers all of its fields, so
+// any other scope falls back to regexes and returns the newest matches first.
+func buildSearchPipeline(query string, fields []models.SearchField, limit int) mongo.Pipeline {
+	filter := visibleIncidentFilter(models.ListIncidentsParams{})
+
+	if !coversTextIndex(fields) {
+		filter["$and"] = fieldMatches(query, fields)
+		return mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+			{{Key: "$limit", Value: limit}},
+			summaryCountsStage,
+			summaryProjectStage,
+		}
+	}
+
+	filter["$text"] = bson.M{"$search": query}
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "score", Value: -1}, {Key: "_id", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		summaryCountsStage,
+		summaryProjectStage,
+	}
+}
+
+// coversTextIndex reports whether the fields are exactly those of the text index,
+// no fields standing for all of them
+func coversTextIndex(fields []models.SearchField) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	if len(fields) != len(textIndexFields) {
+		return false
+	}
+	for _, field := range textIndexFields {
+		if !slices.Contains(fields, field) {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldMatches requires every query word to appear, case-insensitively, in at
+// least one of the fields
+func fieldMatches(query string, fields []models.SearchField) bson.A {
+	var matches bson.A
+	for _, word := range strings.Fields(query) {
+		pattern := primitive.Regex{Pattern: regexp.QuoteMeta(word), Options: "i"}
+		anyField := bson.A{}
+		for _, field := range fields {
+			anyField = append(anyField, bson.M{searchFieldPaths[field]: pattern})
+		}
+		matches = append(matches, bson.M{"$or": anyField})
+	}
+	return matches
+}
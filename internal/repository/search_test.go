@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/tenant"
+)
+
+func TestSearchIncidents_RanksByTextScore(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns scored matches", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "incident_key", Value: 12},
+			{Key: "title", Value: "Checkout latency"},
+			{Key: "severity", Value: "high"},
+			{Key: "status", Value: "open"},
+			{Key: "score", Value: 1.5},
+		}))
+
+		// Act
+		results, err := repo.SearchIncidents(context.Background(), "checkout", nil, 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(results) != 1 || results[0].IncidentKey != 12 || results[0].Score != 1.5 {
+			t.Fatalf("Expected incident 12 with score 1.5, got %+v", results)
+		}
+		pipeline := mt.GetStartedEvent().Command.Lookup("pipeline").Array()
+		search := pipeline.Index(0).Value().Document().Lookup("$match", "$text", "$search").StringValue()
+		if search != "checkout" {
+			t.Errorf("Expected a leading $text match on checkout, got %q", search)
+		}
+		sort := pipeline.Index(2).Value().Document().Lookup("$sort").Document()
+		if keys, _ := sort.Elements(); len(keys) == 0 || keys[0].Key() != "score" {
+			t.Errorf("Expected results sorted by score, got %v", sort)
+		}
+	})
+
+	mt.Run("tenant scope keeps the text match first", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		results, err := repo.SearchIncidents(tenant.NewContext(context.Background(), "team-a"), "checkout", nil, 50)
+
+		// Assert
+		if err != nil || results == nil {
+			t.Fatalf("Expected an empty result, got %v and %v", results, err)
+		}
+		match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match", "$and").Array()
+		if _, err := match.Index(0).Value().Document().LookupErr("$text"); err != nil {
+			t.Errorf("Expected the $text match in the first stage, got %v", match)
+		}
+		if got := match.Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected the first stage scoped to team-a, got %q", got)
+		}
+	})
+}
+
+func TestSearchIncidents_FieldScoping(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name         string
+		fields       []models.SearchField
+		matchingKey  int
+		expectedPath string
+		excludedPath string
+	}{
+		{name: "title only", fields: []models.SearchField{models.SearchTitle}, matchingKey: 3, expectedPath: "title", excludedPath: "notes.content"},
+		{name: "notes only", fields: []models.SearchField{models.SearchNotes}, matchingKey: 8, expectedPath: "notes.content", excludedPath: "title"},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "incident_key", Value: tt.matchingKey},
+			}))
+
+			// Act
+			results, err := repo.SearchIncidents(context.Background(), "Checkout timeout", tt.fields, 50)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if len(results) != 1 || results[0].IncidentKey != tt.matchingKey {
+				t.Fatalf("Expected incident %d, got %+v", tt.matchingKey, results)
+			}
+			match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+			if _, err := match.LookupErr("$text"); err == nil {
+				t.Errorf("Expected a scoped search not to use the text index, got %v", match)
+			}
+			words, _ := match.Lookup("$and").Array().Values()
+			if len(words) != 2 {
+				t.Fatalf("Expected a condition per query word, got %v", words)
+			}
+			for _, word := range words {
+				fields := word.Document().Lookup("$or").Array()
+				pattern, options := fields.Index(0).Value().Document().Lookup(tt.expectedPath).Regex()
+				if options != "i" || (pattern != "Checkout" && pattern != "timeout") {
+					t.Errorf("Expected a case-insensitive match on %s, got /%s/%s", tt.expectedPath, pattern, options)
+				}
+				if _, err := fields.Index(0).Value().Document().LookupErr(tt.excludedPath); err == nil {
+					t.Errorf("Expected %s not to be searched", tt.excludedPath)
+				}
+			}
+		})
+	}
+
+	mt.Run("title and description use the text index", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		_, err := repo.SearchIncidents(context.Background(), "checkout", []models.SearchField{models.SearchDescription, models.SearchTitle}, 50)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		match := mt.GetStartedEvent().Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+		if _, err := match.LookupErr("$text"); err != nil {
+			t.Errorf("Expected a $text match, got %v", match)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"makers.anchor/incident/internal/tenant"
+)
+
+// tenantCollection scopes every query to the tenant of the request context and
+// stamps it on created documents. Unscoped contexts, as used by background jobs
+// and single-tenant deployments, see every document.
+type tenantCollection struct {
+	*mongo.Collection
+}
+
+// tenantOwned is a document stamped with the tenant that creates it
+type tenantOwned interface {
+	SetTenantID(id string)
+}
+
+// scope restricts the filter to the context's tenant
+func scope(ctx context.Context, filter interface{}) interface{} {
+	id := tenant.FromContext(ctx)
+	if id == "" {
+		return filter
+	}
+	return bson.M{"$and": bson.A{filter, bson.M{"tenant_id": id}}}
+}
+
+func (c tenantCollection) InsertOne(ctx context.Context, document tenantOwned, opts ...*options.InsertOneOptions) (*mongo.InsertOneResult, error) {
+	if id := tenant.FromContext(ctx); id != "" {
+		document.SetTenantID(id)
+	}
+	return c.Collection.InsertOne(ctx, document, opts...)
+}
+
+func (c tenantCollection) FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult {
+	return c.Collection.FindOne(ctx, scope(ctx, filter), opts...)
+}
+
+func (c tenantCollection) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return c.Collection.Find(ctx, scope(ctx, filter), opts...)
+}
+
+func (c tenantCollection) FindOneAndUpdate(ctx context.Context, filter, update interface{}, opts ...*options.FindOneAndUpdateOptions) *mongo.SingleResult {
+	return c.Collection.FindOneAndUpdate(ctx, scope(ctx, filter), update, opts...)
+}
+
+func (c tenantCollection) UpdateOne(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return c.Collection.UpdateOne(ctx, scope(ctx, filter), update, opts...)
+}
+
+func (c tenantCollection) UpdateMany(ctx context.Context, filter, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	return c.Collection.UpdateMany(ctx, scope(ctx, filter), update, opts...)
+}
+
+func (c tenantCollection) ReplaceOne(ctx context.Context, filter, replacement interface{}, opts ...*options.ReplaceOptions) (*mongo.UpdateResult, error) {
+	return c.Collection.ReplaceOne(ctx, scope(ctx, filter), replacement, opts...)
+}
+
+func (c tenantCollection) DeleteOne(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	return c.Collection.DeleteOne(ctx, scope(ctx, filter), opts...)
+}
+
+func (c tenantCollection) CountDocuments(ctx context.Context, filter interface{}, opts ...*options.CountOptions) (int64, error) {
+	return c.Collection.CountDocuments(ctx, scope(ctx, filter), opts...)
+}
+
+// Aggregate starts the pipeline with a $match on the context's tenant. A leading
+// $text match must remain the first stage, so the tenant is added to it instead.
+func (c tenantCollection) Aggregate(ctx context.Context, pipeline mongo.Pipeline, opts ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	if id := tenant.FromContext(ctx); id != "" {
+		if match, ok := leadingTextMatch(pipeline); ok {
+			pipeline = append(mongo.Pipeline{{{Key: "$match", Value: scope(ctx, match)}}}, pipeline[1:]...)
+		} else {
+			pipeline = append(mongo.Pipeline{{{Key: "$match", Value: bson.M{"tenant_id": id}}}}, pipeline...)
+		}
+	}
+	return c.Collection.Aggregate(ctx, pipeline, opts...)
+}
+
+// leadingTextMatch returns the filter of the pipeline's first stage when it is a
+// $match on a $text search
+func leadingTextMatch(pipeline mongo.Pipeline) (bson.M, bool) {
+	if len(pipeline) == 0 || len(pipeline[0]) != 1 || pipeline[0][0].Key != "$match" {
+		return nil, false
+	}
+	filter, ok := pipeline[0][0].Value.(bson.M)
+	if !ok {
+		return nil, false
+	}
+	_, isText := filter["$text"]
+	return filter, isText
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/tenant"
+)
+
+func TestTenantCollection_ScopesQueriesToTenant(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("find and aggregate carry the tenant", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		ctx := tenant.NewContext(context.Background(), "team-a")
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+		)
+
+		// Act
+		_, getErr := repo.GetByIncidentKey(ctx, 7)
+		find := mt.GetStartedEvent()
+		_, _, listErr := repo.GetAllIncidents(ctx, models.ListIncidentsParams{})
+		aggregate := mt.GetStartedEvent()
+
+		// Assert
+		if getErr == nil || listErr != nil {
+			t.Fatalf("Expected not found and no list error, got %v and %v", getErr, listErr)
+		}
+		filter := find.Command.Lookup("filter").Document().Lookup("$and").Array()
+		if got := filter.Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected find scoped to team-a, got %q", got)
+		}
+		match := aggregate.Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match").Document()
+		if got := match.Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected pipeline to start with a team-a match, got %v", match)
+		}
+	})
+
+	mt.Run("unscoped contexts see every tenant", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		repo.GetByIncidentKey(context.Background(), 7)
+
+		// Assert
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		if _, err := filter.LookupErr("$and"); err == nil {
+			t.Errorf("Expected an unscoped filter, got %v", filter)
+		}
+	})
+}
+
+func TestTenantCollection_CreateStampsTenant(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("insert carries the tenant", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		ctx := tenant.NewContext(context.Background(), "team-a")
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		// Act
+		incident, err := repo.Create(ctx, &models.Incident{Title: "Cache miss storm", TenantID: "team-b"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if incident.TenantID != "team-a" {
+			t.Errorf("Expected tenant team-a, got %q", incident.TenantID)
+		}
+		document := mt.GetStartedEvent().Command.Lookup("documents").Array().Index(0).Value().Document()
+		if got := document.Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected stored tenant team-a, got %q", got)
+		}
+	})
+}
+
+func TestGetByIncidentKey_CacheHonoursTenant(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("another tenant's cached incident is not returned", func(mt *mtest.T) {
+		// Arrange
+		repo := (&IncidentRepository{collection: tenantCollection{mt.Coll}}).WithCache(NewIncidentCache(10, time.Minute))
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "incident_key", Value: 7},
+			{Key: "tenant_id", Value: "team-b"},
+		}))
+		if _, err := repo.GetByIncidentKey(tenant.NewContext(context.Background(), "team-b"), 7); err != nil {
+			t.Fatalf("Expected team-b to load its incident, got %v", err)
+		}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+
+		// Act
+		incident, err := repo.GetByIncidentKey(tenant.NewContext(context.Background(), "team-a"), 7)
+
+		// Assert
+		if err == nil {
+			t.Errorf("Expected team-a not to see team-b's incident, got %+v", incident)
+		}
+	})
+}
+
+func TestGetNextIncidentKey_UniqueAcrossTenants(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("highest key is taken over every tenant", func(mt *mtest.T) {
+		// Arrange
+		repo := &IncidentRepository{collection: tenantCollection{mt.Coll}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "incident_key", Value: 41},
+			{Key: "tenant_id", Value: "team-b"},
+		}))
+
+		// Act
+		key, err := repo.GetNextIncidentKey(tenant.NewContext(context.Background(), "team-a"))
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if key != 42 {
+			t.Errorf("Expected key 42 after team-b's 41, got %d", key)
+		}
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		if _, err := filter.LookupErr("$and"); err == nil {
+			t.Errorf("Expected the key lookup to be unscoped, got %v", filter)
+		}
+	})
+}
+
+func TestSavedSearchRepository_ScopedToTenant(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("create stamps the tenant and list only asks for it", func(mt *mtest.T) {
+		// Arrange
+		repo := &SavedSearchRepository{collection: tenantCollection{mt.Coll}}
+		ctx := tenant.NewContext(context.Background(), "team-a")
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "db.saved_searches", mtest.FirstBatch),
+		)
+
+		// Act
+		search, createErr := repo.Create(ctx, &models.SavedSearch{Name: "Open criticals", TenantID: "team-b"})
+		insert := mt.GetStartedEvent()
+		_, listErr := repo.List(ctx)
+		find := mt.GetStartedEvent()
+
+		// Assert
+		if createErr != nil || listErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", createErr, listErr)
+		}
+		if search.TenantID != "team-a" {
+			t.Errorf("Expected tenant team-a, got %q", search.TenantID)
+		}
+		document := insert.Command.Lookup("documents").Array().Index(0).Value().Document()
+		if got := document.Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected stored tenant team-a, got %q", got)
+		}
+		filter := find.Command.Lookup("filter").Document().Lookup("$and").Array()
+		if got := filter.Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected list scoped to team-a, got %q", got)
+		}
+	})
+
+	mt.Run("another tenant's search cannot be changed", func(mt *mtest.T) {
+		// Arrange
+		repo := &SavedSearchRepository{collection: tenantCollection{mt.Coll}}
+		ctx := tenant.NewContext(context.Background(), "team-a")
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}),
+		)
+		id := primitive.NewObjectID().Hex()
+
+		// Act
+		_, updateErr := repo.Update(ctx, id, "Renamed", models.SearchFilter{})
+		update := mt.GetStartedEvent()
+		deleteErr := repo.Delete(ctx, id)
+		remove := mt.GetStartedEvent()
+
+		// Assert
+		if !errors.Is(updateErr, ErrSavedSearchNotFound) || !errors.Is(deleteErr, ErrSavedSearchNotFound) {
+			t.Fatalf("Expected not found for team-b's search, got %v and %v", updateErr, deleteErr)
+		}
+		if got := update.Command.Lookup("query", "$and").Array().Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected update scoped to team-a, got %q", got)
+		}
+		if got := remove.Command.Lookup("deletes").Array().Index(0).Value().Document().Lookup("q", "$and").Array().Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected delete scoped to team-a, got %q", got)
+		}
+	})
+}
+
+func TestOutageRepository_ScopedToTenant(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("another tenant's outage is not found", func(mt *mtest.T) {
+		// Arrange
+		repo := &OutageRepository{collection: tenantCollection{mt.Coll}}
+		ctx := tenant.NewContext(context.Background(), "team-a")
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "db.outages", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: nil}),
+		)
+
+		// Act
+		outage, createErr := repo.Create(ctx, &models.Outage{Title: "Region down"})
+		insert := mt.GetStartedEvent()
+		_, getErr := repo.GetByID(ctx, primitive.NewObjectID().Hex())
+		find := mt.GetStartedEvent()
+		_, updateErr := repo.UpdateStatus(ctx, primitive.NewObjectID(), models.OutageClosed)
+		update := mt.GetStartedEvent()
+
+		// Assert
+		if createErr != nil {
+			t.Fatalf("Expected no error, got %v", createErr)
+		}
+		if got := insert.Command.Lookup("documents").Array().Index(0).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" || outage.TenantID != "team-a" {
+			t.Errorf("Expected outage stamped with team-a, got %q", got)
+		}
+		if !errors.Is(getErr, ErrOutageNotFound) || !errors.Is(updateErr, ErrOutageNotFound) {
+			t.Fatalf("Expected not found for team-b's outage, got %v and %v", getErr, updateErr)
+		}
+		if got := find.Command.Lookup("filter", "$and").Array().Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected lookup scoped to team-a, got %q", got)
+		}
+		if got := update.Command.Lookup("query", "$and").Array().Index(1).Value().Document().Lookup("tenant_id").StringValue(); got != "team-a" {
+			t.Errorf("Expected update scoped to team-a, got %q", got)
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/handlers"
+	"makers.anchor/incident/internal/middleware"
+	"makers.anchor/incident/internal/services"
+)
+
+func SetupAdminRoutes(api fiber.Router, incidentService *services.IncidentService, cfg *config.Config) {
+	adminHandler := handlers.NewAdminHandler(incidentService)
+
+	// Admin routes, protected by the admin bearer token
+	admin := api.Group("/admin", middleware.RequireAdminToken(cfg.AdminToken))
+	admin.Post("/incidents/:id/replay", adminHandler.ReplayIncidentEvents)
+	admin.Post("/backfill", adminHandler.BackfillDerivedFields)
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/auth"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/handlers"
+	"makers.anchor/incident/internal/middleware"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+	"makers.anchor/incident/internal/storage"
+)
+
+func SetupAttachmentRoutes(api fiber.Router, incidentRepo *repository.IncidentRepository, policy *auth.Policy, cfg *config.Config) {
+	if cfg.AttachmentStorageEndpoint == "" {
+		log.Printf("Attachment storage not configured, attachment routes disabled")
+		return
+	}
+
+	attachmentStorage, err := storage.NewS3Storage(
+		cfg.AttachmentStorageEndpoint,
+		cfg.AttachmentStorageBucket,
+		cfg.AttachmentStorageRegion,
+		cfg.AttachmentStorageAccessKey,
+		cfg.AttachmentStorageSecretKey,
+	)
+	if err != nil {
+		log.Fatalf("Failed to configure attachment storage: %v", err)
+	}
+
+	// Initialize service and handler
+	attachmentService := services.NewAttachmentService(incidentRepo, attachmentStorage, cfg.AttachmentUploadExpiry)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+
+	// Attachment routes
+	attachments := api.Group("/incidents/:id/attachments", middleware.RequirePermission(policy, auth.PermissionWriteIncidents))
+	attachments.Post("/presign", attachmentHandler.PresignUpload)
+	attachments.Post("/:attachmentId/confirm", attachmentHandler.ConfirmUpload)
+}
@@ -0,0 +1,49 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"makers.anchor/incident/internal/webhooks"
+)
+
+// Background tracks the work SetupRoutes starts outside request handling, so
+// shutdown can wait for it once the server no longer takes requests
+type Background struct {
+	jobs     sync.WaitGroup
+	webhooks *webhooks.Dispatcher
+}
+
+// run starts a job, tracked until it returns
+func (b *Background) run(job func()) {
+	b.jobs.Add(1)
+	go func() {
+		defer b.jobs.Done()
+		job()
+	}()
+}
+
+// WaitForJobs waits for the periodic jobs, which stop once the context passed to
+// SetupRoutes is cancelled and their current run finishes. It gives up when ctx
+// ends first, returning the context's error.
+func (b *Background) WaitForJobs(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		b.jobs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background jobs still running: %w", ctx.Err())
+	}
+}
+
+// CloseWebhooks waits for in-flight webhook deliveries, cancelling their retries
+// when ctx ends first
+func (b *Background) CloseWebhooks(ctx context.Context) error {
+	return b.webhooks.Close(ctx)
+}
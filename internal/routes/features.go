@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"log"
+
+	"makers.anchor/incident/internal/config"
+)
+
+// Features that gate optional endpoint groups, enabled through FEATURES
+const (
+	FeatureOutages = "outages"
+	FeatureExport  = "export"
+)
+
+// whenFeatureEnabled registers routes only when the feature is enabled, so requests
+// to a disabled feature fall through to the default 404
+func whenFeatureEnabled(cfg *config.Config, feature string, register func()) {
+	if !cfg.FeatureEnabled(feature) {
+		log.Printf("Feature %s disabled, skipping its routes", feature)
+		return
+	}
+	register()
+}
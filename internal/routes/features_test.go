@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/config"
+)
+
+func TestWhenFeatureEnabled_DisabledFeatureReturns404(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	cfg := &config.Config{Features: []string{"clone"}}
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+
+	whenFeatureEnabled(cfg, "merge", func() { app.Post("/merge", ok) })
+	whenFeatureEnabled(cfg, "clone", func() { app.Post("/clone", ok) })
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{path: "/merge", expected: fiber.StatusNotFound},
+		{path: "/clone", expected: fiber.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// Act
+			resp, err := app.Test(httptest.NewRequest("POST", tt.path, nil))
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != tt.expected {
+				t.Errorf("Expected status %d, got %d", tt.expected, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package routes
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/version"
+)
 
 func SetupHealthRoutes(app *fiber.App) {
 	app.Get("/", func(c *fiber.Ctx) error {
@@ -13,4 +16,8 @@ func SetupHealthRoutes(app *fiber.App) {
 			"service": "notification-service",
 		})
 	})
+
+	app.Get("/version", func(c *fiber.Ctx) error {
+		return c.JSON(version.Get())
+	})
 }
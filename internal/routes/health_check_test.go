@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/version"
+)
+
+func TestVersionRoute_ReturnsBuildInfo(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	SetupHealthRoutes(app)
+
+	originalVersion, originalCommit, originalBuildTime := version.Version, version.Commit, version.BuildTime
+	defer func() {
+		version.Version, version.Commit, version.BuildTime = originalVersion, originalCommit, originalBuildTime
+	}()
+
+	tests := []struct {
+		name     string
+		inject   func()
+		expected version.Info
+	}{
+		{
+			name:     "defaults",
+			inject:   func() {},
+			expected: version.Info{Version: "dev", Commit: "dev", BuildTime: "dev"},
+		},
+		{
+			name: "injected",
+			inject: func() {
+				version.Version = "1.4.2"
+				version.Commit = "a1b2c3d"
+				version.BuildTime = "2025-01-01T00:00:00Z"
+			},
+			expected: version.Info{Version: "1.4.2", Commit: "a1b2c3d", BuildTime: "2025-01-01T00:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.inject()
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest("GET", "/version", nil))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			defer resp.Body.Close()
+
+			// Assert
+			if resp.StatusCode != fiber.StatusOK {
+				t.Fatalf("Expected status %d, got %d", fiber.StatusOK, resp.StatusCode)
+			}
+
+			var body map[string]string
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("Expected JSON body, got %v", err)
+			}
+
+			if len(body) != 3 {
+				t.Errorf("Expected 3 fields, got %d: %v", len(body), body)
+			}
+			if body["version"] != tt.expected.Version {
+				t.Errorf("Expected version %s, got %s", tt.expected.Version, body["version"])
+			}
+			if body["commit"] != tt.expected.Commit {
+				t.Errorf("Expected commit %s, got %s", tt.expected.Commit, body["commit"])
+			}
+			if body["build_time"] != tt.expected.BuildTime {
+				t.Errorf("Expected build_time %s, got %s", tt.expected.BuildTime, body["build_time"])
+			}
+		})
+	}
+}
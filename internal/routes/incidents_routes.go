@@ -1,27 +1,71 @@
 package routes
 
 import (
+	"log"
+
 	"github.com/gofiber/fiber/v2"
-	"makers.anchor/incident/internal/database"
+	"makers.anchor/incident/internal/auth"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/export"
 	"makers.anchor/incident/internal/handlers"
 	"makers.anchor/incident/internal/kafka"
+	"makers.anchor/incident/internal/middleware"
 	"makers.anchor/incident/internal/repository"
 	"makers.anchor/incident/internal/services"
+	"makers.anchor/incident/internal/webhooks"
 )
 
-func SetupIncidentRoutes(api fiber.Router, db *database.DB, producer *kafka.Producer) {
-	// Initialize repository, service and handler
-	incidentRepo := repository.NewIncidentRepository(db.Database)
-	incidentService := services.NewIncidentService(incidentRepo, producer)
-	incidentHandler := handlers.NewIncidentHandler(incidentService)
+func SetupIncidentRoutes(api fiber.Router, incidentRepo *repository.IncidentRepository, producer *kafka.Producer, webhookDispatcher *webhooks.Dispatcher, policy *auth.Policy, cfg *config.Config) *services.IncidentService {
+	// Initialize service and handler
+	eventBus := eventbus.New(eventbus.DefaultBufferSize)
+	incidentService := services.NewIncidentService(incidentRepo, producer, eventBus, webhookDispatcher, cfg)
+	renderer, err := export.NewRenderer(cfg.ExportFormat)
+	if err != nil {
+		log.Printf("%v, falling back to %s", err, export.FormatMarkdown)
+		renderer = export.MarkdownRenderer{}
+	}
+	incidentHandler := handlers.NewIncidentHandler(incidentService, renderer)
 
-	// Incident routes
+	// Incident routes, each mutation requiring the permission its route declares
+	writeIncidents := middleware.RequirePermission(policy, auth.PermissionWriteIncidents)
+	writeNotes := middleware.RequirePermission(policy, auth.PermissionWriteNotes)
 	incidents := api.Group("/incidents")
 	incidents.Get("/", incidentHandler.GetAllIncidents)
-	incidents.Post("/", incidentHandler.CreateIncident)
+	incidents.Post("/", writeIncidents, incidentHandler.CreateIncident)
+	incidents.Post("/bulk/assignee", writeIncidents, incidentHandler.ReassignIncidents)
+	incidents.Get("/stats", incidentHandler.GetIncidentStats)
+	incidents.Get("/stats/by-assignee", incidentHandler.GetAssigneeStats)
+	incidents.Get("/load", incidentHandler.GetIncidentLoad)
+	incidents.Get("/facets", incidentHandler.GetIncidentFacets)
+	incidents.Get("/search", incidentHandler.SearchIncidents)
+	incidents.Get("/metrics/trend", incidentHandler.GetSeverityTrend)
+	incidents.Get("/stream", incidentHandler.StreamIncidentEvents)
+	incidents.Get("/key/:key", incidentHandler.GetIncidentByKey)
+	incidents.Get("/id/:objectId", incidentHandler.GetIncidentByObjectID)
 	incidents.Get("/:id", incidentHandler.GetIncidentByID)
-	incidents.Put("/:id/status", incidentHandler.UpdateIncidentStatus)
-	incidents.Put("/:id/severity", incidentHandler.UpdateIncidentSeverity)
-	incidents.Post("/:id/notes", incidentHandler.AddNoteToIncident)
-	incidents.Post("/:id/watchlist", incidentHandler.AddWatcherToIncident)
+	incidents.Put("/:id", writeIncidents, incidentHandler.UpdateIncident)
+	incidents.Get("/:id/related", incidentHandler.GetRelatedIncidents)
+	incidents.Get("/:id/next-responder", incidentHandler.GetNextResponder)
+	whenFeatureEnabled(cfg, FeatureExport, func() {
+		incidents.Get("/:id/export", incidentHandler.ExportIncident)
+	})
+	incidents.Post("/:id/publish", writeIncidents, incidentHandler.PublishIncident)
+	incidents.Put("/:id/status", writeIncidents, incidentHandler.UpdateIncidentStatus)
+	incidents.Post("/:id/status/preview", incidentHandler.PreviewStatusChange)
+	incidents.Put("/:id/severity", middleware.RequirePermission(policy, auth.PermissionChangeSeverity), incidentHandler.UpdateIncidentSeverity)
+	incidents.Put("/:id/priority", writeIncidents, incidentHandler.UpdateIncidentPriority)
+	incidents.Get("/:id/notes", incidentHandler.ListNotes)
+	incidents.Post("/:id/notes", writeNotes, incidentHandler.AddNoteToIncident)
+	incidents.Post("/:id/notes/bulk", writeNotes, incidentHandler.ImportNotes)
+	incidents.Get("/:id/notes/search", incidentHandler.SearchNotes)
+	incidents.Put("/:id/notes/:noteId", writeNotes, incidentHandler.EditNote)
+	incidents.Post("/:id/watchlist", writeIncidents, incidentHandler.AddWatcherToIncident)
+	incidents.Get("/:id/postmortem", incidentHandler.GetPostmortem)
+	incidents.Post("/:id/postmortem", writeIncidents, incidentHandler.SavePostmortem)
+
+	// Note template routes
+	api.Get("/note-templates", incidentHandler.GetNoteTemplates)
+
+	return incidentService
 }
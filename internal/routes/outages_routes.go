@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/auth"
+	"makers.anchor/incident/internal/database"
+	"makers.anchor/incident/internal/handlers"
+	"makers.anchor/incident/internal/middleware"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+func SetupOutageRoutes(api fiber.Router, db *database.DB, incidentRepo *repository.IncidentRepository, incidentService *services.IncidentService, policy *auth.Policy) {
+	// Initialize repository, service and handler
+	outageRepo := repository.NewOutageRepository(db.Database)
+	outageService := services.NewOutageService(outageRepo, incidentRepo, incidentService)
+	outageHandler := handlers.NewOutageHandler(outageService)
+
+	// Outage routes
+	writeIncidents := middleware.RequirePermission(policy, auth.PermissionWriteIncidents)
+	outages := api.Group("/outages")
+	outages.Post("/", writeIncidents, outageHandler.CreateOutage)
+	outages.Get("/:id", outageHandler.GetOutage)
+	outages.Post("/:id/incidents/:incidentKey", writeIncidents, outageHandler.AttachIncident)
+	outages.Put("/:id/close", writeIncidents, outageHandler.CloseOutage)
+}
@@ -1,18 +1,112 @@
 package routes
 
 import (
+	"context"
+	"log"
+	"time"
+
 	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/auth"
+	"makers.anchor/incident/internal/config"
 	"makers.anchor/incident/internal/database"
 	"makers.anchor/incident/internal/kafka"
+	"makers.anchor/incident/internal/middleware"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
 )
 
-func SetupRoutes(app *fiber.App, db *database.DB, producer *kafka.Producer) {
+// SetupRoutes registers every route and starts the periodic jobs, which run until
+// ctx is cancelled. The returned Background lets shutdown wait for them.
+func SetupRoutes(ctx context.Context, app *fiber.App, db *database.DB, producer *kafka.Producer, cfg *config.Config) *Background {
 	// API group
 	api := app.Group("/api/v1")
 
 	// Health routes
 	SetupHealthRoutes(app)
 
+	// Metrics routes
+	SetupMetricsRoutes(app)
+
+	// Tenant scoping, registered ahead of the routes it guards. Inbound webhooks
+	// create incidents, so they are scoped like the API. The status page is scoped
+	// the same way, so with tenant tokens it needs the tenant's token rather than
+	// trusting a client-supplied header.
+	if cfg.MultiTenant {
+		tenantScope := middleware.ResolveTenant(cfg.TenantTokens)
+		for _, prefix := range []string{"/incidents", "/outages", "/saved-searches", "/status", "/webhooks"} {
+			api.Use(prefix, tenantScope)
+		}
+	}
+
+	// Role-based authorization, which routes apply per the permission they require
+	policy := auth.NewPolicy(cfg.RoleTokens, cfg.RolePermissions)
+
+	// Incidents are shared by every service so mutations invalidate a single cache
+	incidentRepo := repository.NewIncidentRepository(db.Database, cfg.IncidentsCollection).
+		WithCache(repository.NewIncidentCache(cfg.IncidentCacheSize, cfg.IncidentCacheTTL)).
+		WithCountCache(repository.NewCountCache(cfg.ListCountCacheTTL)).
+		WithArchive(db.Database.Collection(cfg.ArchiveCollection))
+
+	// Text index backing GET /incidents/search
+	indexCtx, cancelIndex := context.WithTimeout(ctx, 10*time.Second)
+	if err := incidentRepo.EnsureTextIndex(indexCtx); err != nil {
+		log.Printf("Incident search is unavailable: %v", err)
+	}
+	// Unique index that lets a create lose a race with its own retry safely
+	if err := incidentRepo.EnsureIdempotencyKeyIndex(indexCtx); err != nil {
+		log.Printf("Concurrent retries of an idempotent create may both insert: %v", err)
+	}
+	// Unique index keeping one incident per Jira issue
+	if err := incidentRepo.EnsureExternalIDIndex(indexCtx); err != nil {
+		log.Printf("Concurrent syncs of a new external ticket may both insert: %v", err)
+	}
+	cancelIndex()
+
+	// Outbound webhooks, drained on shutdown
+	background := &Background{
+		webhooks: webhooks.NewDispatcher(cfg.Webhooks, cfg.WebhookSecret).
+			WithLimits(cfg.WebhookConcurrency, cfg.WebhookMaxAttempts),
+	}
+
 	// Notification routes
-	SetupIncidentRoutes(api, db, producer)
+	incidentService := SetupIncidentRoutes(api, incidentRepo, producer, background.webhooks, policy, cfg)
+
+	// Stale incident notifications
+	if len(cfg.StaleThresholds) > 0 && cfg.StaleCheckInterval > 0 {
+		background.run(func() { incidentService.RunStaleNotifier(ctx, cfg.StaleCheckInterval) })
+	}
+
+	// Opening of scheduled incidents
+	if cfg.ScheduleCheckInterval > 0 {
+		background.run(func() { incidentService.RunScheduler(ctx, cfg.ScheduleCheckInterval) })
+	} else {
+		log.Printf("Scheduler disabled by SCHEDULE_CHECK_INTERVAL=%s, scheduled incidents will not open", cfg.ScheduleCheckInterval)
+	}
+
+	// Archival of closed incidents
+	if cfg.ArchiveClosedAfter > 0 && cfg.ArchiveCheckInterval > 0 {
+		background.run(func() { incidentService.RunArchiver(ctx, cfg.ArchiveCheckInterval) })
+	}
+
+	// Outage routes
+	whenFeatureEnabled(cfg, FeatureOutages, func() {
+		SetupOutageRoutes(api, db, incidentRepo, incidentService, policy)
+	})
+
+	// Saved search routes
+	SetupSavedSearchRoutes(api, db, incidentService, policy)
+
+	// Inbound webhook routes
+	SetupWebhookRoutes(api, incidentService, cfg)
+
+	// Public status page routes
+	SetupStatusPageRoutes(api, incidentService, cfg)
+
+	// Admin routes
+	SetupAdminRoutes(api, incidentService, cfg)
+
+	// Attachment routes
+	SetupAttachmentRoutes(api, incidentRepo, policy, cfg)
+
+	return background
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/auth"
+	"makers.anchor/incident/internal/database"
+	"makers.anchor/incident/internal/handlers"
+	"makers.anchor/incident/internal/middleware"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/services"
+)
+
+func SetupSavedSearchRoutes(api fiber.Router, db *database.DB, incidentService *services.IncidentService, policy *auth.Policy) {
+	// Initialize repository, service and handler
+	savedSearchRepo := repository.NewSavedSearchRepository(db.Database)
+	savedSearchService := services.NewSavedSearchService(savedSearchRepo, incidentService)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+
+	// Saved search routes
+	// Saved searches are shared across the tenant, so changing one needs the same
+	// permission as deleting it
+	manageSearches := middleware.RequirePermission(policy, auth.PermissionDelete)
+	searches := api.Group("/saved-searches")
+	searches.Post("/", manageSearches, savedSearchHandler.CreateSavedSearch)
+	searches.Get("/", savedSearchHandler.ListSavedSearches)
+	searches.Get("/:id", savedSearchHandler.GetSavedSearch)
+	searches.Put("/:id", manageSearches, savedSearchHandler.UpdateSavedSearch)
+	searches.Delete("/:id", manageSearches, savedSearchHandler.DeleteSavedSearch)
+	searches.Get("/:id/incidents", savedSearchHandler.ListIncidents)
+}
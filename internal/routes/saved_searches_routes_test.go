@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/auth"
+	"makers.anchor/incident/internal/database"
+)
+
+func TestSetupSavedSearchRoutes_ChangesNeedDeletePermission(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("responders cannot create, update or delete", func(mt *mtest.T) {
+		// Arrange
+		app := fiber.New()
+		policy := auth.NewPolicy(map[string][]string{"responder-token": {"responders"}}, nil)
+		SetupSavedSearchRoutes(app, &database.DB{Database: mt.DB}, nil, policy)
+		requests := []struct {
+			method string
+			path   string
+		}{
+			{method: "POST", path: "/saved-searches/"},
+			{method: "PUT", path: "/saved-searches/665f1c2ab1d4e8a9c0f3b7d1"},
+			{method: "DELETE", path: "/saved-searches/665f1c2ab1d4e8a9c0f3b7d1"},
+		}
+
+		for _, tt := range requests {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer responder-token")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.StatusCode != fiber.StatusForbidden {
+				t.Errorf("Expected %s %s to be forbidden, got %d", tt.method, tt.path, resp.StatusCode)
+			}
+		}
+	})
+}
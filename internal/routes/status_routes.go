@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/handlers"
+	"makers.anchor/incident/internal/services"
+)
+
+func SetupStatusPageRoutes(api fiber.Router, incidentService *services.IncidentService, cfg *config.Config) {
+	statusHandler := handlers.NewStatusPageHandler(incidentService)
+
+	// Public status page routes, unauthenticated and rate limited per client IP
+	status := api.Group("/status", limiter.New(limiter.Config{
+		Max:        cfg.StatusPageRateLimit,
+		Expiration: time.Minute,
+		LimitReached: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error": "Too many requests, retry later",
+			})
+		},
+	}))
+	status.Get("/:key", statusHandler.GetStatus)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/handlers"
+	"makers.anchor/incident/internal/middleware"
+	"makers.anchor/incident/internal/services"
+)
+
+func SetupWebhookRoutes(api fiber.Router, incidentService *services.IncidentService, cfg *config.Config) {
+	jiraHandler := handlers.NewJiraWebhookHandler(incidentService)
+
+	// Inbound webhook routes, signed with the shared inbound secret
+	webhooks := api.Group("/webhooks", middleware.VerifySignature(cfg.InboundWebhookSecret))
+	webhooks.Post("/jira", jiraHandler.SyncIssue)
+}
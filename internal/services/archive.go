@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"makers.anchor/incident/internal/config"
+)
+
+// archiveBatchSize caps how many incidents one archival run moves
+const archiveBatchSize = 500
+
+// ArchiveClosedIncidents moves closed incidents not updated within
+// ARCHIVE_CLOSED_AFTER to the archive collection, returning how many were moved.
+// Archived incidents drop out of lists but are still found by ID or key.
+func (s *IncidentService) ArchiveClosedIncidents(ctx context.Context) (int, error) {
+	if s.cfg.ArchiveClosedAfter <= 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	candidates, err := s.repo.FindArchivable(ctx, now.Add(-s.cfg.ArchiveClosedAfter), archiveBatchSize)
+	if err != nil {
+		log.Printf("Error finding incidents to archive: %v", err)
+		return 0, err
+	}
+
+	archived := 0
+	for i := range candidates {
+		if err := s.repo.Archive(ctx, &candidates[i], now); err != nil {
+			log.Printf("Error archiving incident %d: %v", candidates[i].IncidentKey, err)
+			continue
+		}
+		archived++
+	}
+
+	if archived > 0 {
+		config.Infof("Archived %d closed incidents", archived)
+	}
+	return archived, nil
+}
+
+// RunArchiver archives closed incidents every interval until ctx is done.
+// A run already started finishes even if ctx is cancelled meanwhile.
+func (s *IncidentService) RunArchiver(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.ArchiveClosedIncidents(context.WithoutCancel(ctx))
+		}
+	}
+}
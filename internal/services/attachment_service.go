@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/storage"
+)
+
+// AttachmentService handles business logic for incident attachments
+type AttachmentService struct {
+	repo    *repository.IncidentRepository
+	storage storage.AttachmentStorage
+	expiry  time.Duration
+}
+
+// NewAttachmentService creates a new attachment service
+func NewAttachmentService(repo *repository.IncidentRepository, storage storage.AttachmentStorage, expiry time.Duration) *AttachmentService {
+	return &AttachmentService{
+		repo:    repo,
+		storage: storage,
+		expiry:  expiry,
+	}
+}
+
+// PresignUpload records a pending attachment on the incident and returns a URL to upload it to
+func (s *AttachmentService) PresignUpload(ctx context.Context, incidentID string, req *models.PresignAttachmentRequest) (*models.PresignedAttachment, error) {
+	fileName := path.Base(strings.TrimSpace(req.FileName))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, fmt.Errorf("file name is required")
+	}
+
+	incident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	attachment := models.Attachment{
+		ID:          primitive.NewObjectID(),
+		FileName:    fileName,
+		ContentType: req.ContentType,
+		Status:      models.AttachmentPending,
+		UploadedBy:  req.AuthorEmail,
+		CreatedAt:   now,
+	}
+	attachment.ObjectKey = fmt.Sprintf("incidents/%d/%s/%s", incident.IncidentKey, attachment.ID.Hex(), fileName)
+
+	uploadURL, err := s.storage.PresignUpload(ctx, attachment.ObjectKey, s.expiry)
+	if err != nil {
+		log.Printf("Error presigning attachment upload: %v", err)
+		return nil, fmt.Errorf("failed to presign upload: %w", err)
+	}
+
+	if _, err := s.repo.AddAttachment(ctx, incident.ID, attachment); err != nil {
+		log.Printf("Error recording attachment: %v", err)
+		return nil, err
+	}
+
+	config.Infof("Presigned attachment upload: Incident=%d, Attachment=%s", incident.IncidentKey, attachment.ID.Hex())
+
+	return &models.PresignedAttachment{
+		UploadURL:  uploadURL,
+		ExpiresAt:  now.Add(s.expiry),
+		Attachment: attachment,
+	}, nil
+}
+
+// ConfirmUpload marks a pending attachment as uploaded
+func (s *AttachmentService) ConfirmUpload(ctx context.Context, incidentID, attachmentID string) (*models.Incident, error) {
+	attachmentObjectID, err := primitive.ObjectIDFromHex(attachmentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment ID format: %w", err)
+	}
+
+	incident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedIncident, err := s.repo.ConfirmAttachment(ctx, incident.ID, attachmentObjectID)
+	if err != nil {
+		log.Printf("Error confirming attachment: %v", err)
+		return nil, err
+	}
+
+	config.Infof("Confirmed attachment upload: Incident=%d, Attachment=%s", incident.IncidentKey, attachmentID)
+
+	return updatedIncident, nil
+}
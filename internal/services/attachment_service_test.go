@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+)
+
+// MockAttachmentStorage records presign requests for testing
+type MockAttachmentStorage struct {
+	objectKeys []string
+}
+
+func (m *MockAttachmentStorage) PresignUpload(ctx context.Context, objectKey string, expiry time.Duration) (string, error) {
+	m.objectKeys = append(m.objectKeys, objectKey)
+	return "https://storage.example.com/" + objectKey + "?signature=abc", nil
+}
+
+func TestAttachmentService_PresignUpload(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns a URL and records pending metadata", func(mt *mtest.T) {
+		// Arrange
+		mockStorage := &MockAttachmentStorage{}
+		service := NewAttachmentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), mockStorage, 15*time.Minute)
+		incident := models.Incident{ID: primitive.NewObjectID(), IncidentKey: 42, Title: "API errors", Severity: models.High, Status: models.Open}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, toDoc(t, incident)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: toDoc(t, incident)}),
+		)
+
+		// Act
+		presigned, err := service.PresignUpload(context.Background(), "42", &models.PresignAttachmentRequest{
+			FileName:    "heap-dump.hprof",
+			ContentType: "application/octet-stream",
+			AuthorEmail: "john.doe@example.com",
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(mockStorage.objectKeys) != 1 {
+			t.Fatalf("Expected 1 presign request, got %d", len(mockStorage.objectKeys))
+		}
+		if presigned.UploadURL != "https://storage.example.com/"+mockStorage.objectKeys[0]+"?signature=abc" {
+			t.Errorf("Expected presigned URL for %s, got %s", mockStorage.objectKeys[0], presigned.UploadURL)
+		}
+		if presigned.Attachment.Status != models.AttachmentPending {
+			t.Errorf("Expected status %s, got %s", models.AttachmentPending, presigned.Attachment.Status)
+		}
+
+		update := mt.GetStartedEvent()
+		for update != nil && update.CommandName != "findAndModify" {
+			update = mt.GetStartedEvent()
+		}
+		if update == nil {
+			t.Fatal("Expected attachment metadata to be recorded")
+		}
+		pushed := update.Command.Lookup("update", "$push", "attachments")
+		if name := pushed.Document().Lookup("file_name").StringValue(); name != "heap-dump.hprof" {
+			t.Errorf("Expected recorded file name heap-dump.hprof, got %s", name)
+		}
+		if status := pushed.Document().Lookup("status").StringValue(); status != string(models.AttachmentPending) {
+			t.Errorf("Expected recorded status %s, got %s", models.AttachmentPending, status)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// backfillBatchSize is the number of incidents a backfill reads at a time
+const backfillBatchSize = 100
+
+// BackfillDerivedFields fills in derived fields missing from incidents stored
+// before those fields existed. An incident with notes but no first response gets
+// one from its earliest note. Fields that are already set are left alone, so it is
+// safe to run again. Incidents are read in batches, logging progress after each.
+func (s *IncidentService) BackfillDerivedFields(ctx context.Context) (*models.BackfillResult, error) {
+	result := &models.BackfillResult{}
+	after := primitive.NilObjectID
+	for {
+		batch, err := s.repo.FindMissingFirstResponse(ctx, after, backfillBatchSize)
+		if err != nil {
+			return result, fmt.Errorf("failed to find incidents to backfill: %w", err)
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for i := range batch {
+			filled, err := s.backfillFirstResponse(ctx, &batch[i])
+			if err != nil {
+				return result, err
+			}
+			if filled {
+				result.FirstResponses++
+			}
+		}
+		result.Scanned += len(batch)
+		after = batch[len(batch)-1].ID
+		config.Infof("Backfill: scanned %d incidents, filled %d first responses", result.Scanned, result.FirstResponses)
+	}
+
+	config.Infof("Backfill complete: scanned %d incidents, filled %d first responses", result.Scanned, result.FirstResponses)
+	return result, nil
+}
+
+// backfillFirstResponse records the incident's first response at its earliest
+// note. Unlike recordFirstResponse it observes no metric, as the response is not new.
+func (s *IncidentService) backfillFirstResponse(ctx context.Context, incident *models.Incident) (bool, error) {
+	if incident.FirstResponseAt != nil || len(incident.Notes) == 0 {
+		return false, nil
+	}
+
+	at := earliestNoteTime(incident.Notes)
+	seconds := max(at.Sub(incident.CreatedAt).Seconds(), 0)
+	claimed, err := s.repo.MarkFirstResponse(ctx, incident, at, seconds)
+	if err != nil {
+		return false, fmt.Errorf("failed to backfill first response for incident %d: %w", incident.IncidentKey, err)
+	}
+	return claimed, nil
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestBackfillDerivedFields_FillsFirstResponseFromNotes(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("incident with notes gets its earliest note as first response", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		createdAt := time.Now().Add(-2 * time.Hour).UTC().Truncate(time.Millisecond)
+		firstNote := createdAt.Add(10 * time.Minute)
+		incident := bson.D{
+			{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "open"}, {Key: "created_at", Value: createdAt},
+			{Key: "notes", Value: bson.A{
+				bson.D{{Key: "content", Value: "Rolled back"}, {Key: "created_at", Value: firstNote.Add(30 * time.Minute)}},
+				bson.D{{Key: "content", Value: "Looking into it"}, {Key: "created_at", Value: firstNote}},
+			}},
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+		)
+
+		// Act
+		result, err := service.BackfillDerivedFields(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Scanned != 1 || result.FirstResponses != 1 {
+			t.Fatalf("Expected 1 scanned and 1 filled, got %+v", result)
+		}
+		mt.GetStartedEvent() // the batch lookup
+		mark := mt.GetStartedEvent()
+		if mark == nil || mark.CommandName != "update" {
+			t.Fatalf("Expected the first response to be backfilled, got %+v", mark)
+		}
+		statement := mark.Command.Lookup("updates").Array().Index(0).Value().Document()
+		if _, err := statement.LookupErr("q", "first_response_at", "$exists"); err != nil {
+			t.Errorf("Expected the update to skip incidents with a first response, got %s", statement)
+		}
+		at, ok := statement.Lookup("u", "$set", "first_response_at").TimeOK()
+		if !ok || !at.Equal(firstNote) {
+			t.Errorf("Expected first_response_at %s, got %s", firstNote, statement.Lookup("u", "$set", "first_response_at"))
+		}
+		seconds, ok := statement.Lookup("u", "$set", "first_response_seconds").DoubleOK()
+		if !ok || seconds != (10*time.Minute).Seconds() {
+			t.Errorf("Expected first_response_seconds 600, got %s", statement.Lookup("u", "$set", "first_response_seconds"))
+		}
+		next := mt.GetStartedEvent()
+		if next == nil || next.CommandName != "find" {
+			t.Fatalf("Expected a lookup for the next batch, got %+v", next)
+		}
+		if after, ok := next.Command.Lookup("filter", "_id", "$gt").ObjectIDOK(); !ok || after != id {
+			t.Errorf("Expected the next batch to start after %s, got %s", id.Hex(), next.Command.Lookup("filter", "_id"))
+		}
+	})
+}
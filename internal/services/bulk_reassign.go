@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrInvalidBulkSelection is returned when a bulk request selects incidents by
+// both keys and filter, by neither, or by an empty filter
+var ErrInvalidBulkSelection = errors.New("select incidents by either keys or a non-empty filter")
+
+// ErrInvalidAssignee is returned when an assignee is not a valid email address
+var ErrInvalidAssignee = errors.New("invalid assignee")
+
+// ReassignIncidents assigns the incidents selected by key or by filter to a new
+// assignee, recording the previous assignee in each incident's history
+func (s *IncidentService) ReassignIncidents(ctx context.Context, req *models.BulkReassignRequest) (*models.BulkUpdateResult, error) {
+	req.Assignee = strings.TrimSpace(req.Assignee)
+	if err := s.validateEmail(req.Assignee); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAssignee, err)
+	}
+	if err := validateBulkSelection(req); err != nil {
+		return nil, err
+	}
+
+	result, err := s.repo.ReassignIncidents(ctx, *req)
+	if err != nil {
+		log.Printf("Error reassigning incidents: %v", err)
+		return nil, err
+	}
+
+	config.Infof("Reassigned incidents to %s: matched=%d, modified=%d", req.Assignee, result.Matched, result.Modified)
+	return result, nil
+}
+
+// validateBulkSelection checks the request selects incidents one way, with valid filter values
+func validateBulkSelection(req *models.BulkReassignRequest) error {
+	if (len(req.Keys) > 0) == (req.Filter != nil) {
+		return ErrInvalidBulkSelection
+	}
+	if req.Filter == nil {
+		return nil
+	}
+
+	filter := req.Filter
+	filter.Assignee = strings.TrimSpace(filter.Assignee)
+	if filter.Assignee == "" && filter.Status == "" && filter.Severity == "" {
+		return ErrInvalidBulkSelection
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return InvalidStatus(filter.Status)
+	}
+	if filter.Severity != "" && !filter.Severity.IsValid() {
+		return InvalidSeverity(filter.Severity)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestReassignIncidents_MovesEveryIncidentFromOneAssigneeToAnother(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filter by current assignee", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{})
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 3}, bson.E{Key: "nModified", Value: 3}))
+
+		// Act
+		result, err := service.ReassignIncidents(context.Background(), &models.BulkReassignRequest{
+			Filter:      &models.BulkReassignFilter{Assignee: "x@example.com"},
+			Assignee:    "y@example.com",
+			AuthorEmail: "lead@example.com",
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if result.Matched != 3 || result.Modified != 3 {
+			t.Errorf("Expected 3 incidents reassigned, got %+v", result)
+		}
+
+		update := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		if multi := update.Lookup("multi").Boolean(); !multi {
+			t.Error("Expected a multi-document update")
+		}
+		assignee := update.Lookup("q", "assignee").Document()
+		if assignee.Lookup("$eq").StringValue() != "x@example.com" || assignee.Lookup("$ne").StringValue() != "y@example.com" {
+			t.Errorf("Expected incidents assigned to x and not y, got %v", assignee)
+		}
+		set := update.Lookup("u").Array().Index(0).Value().Document().Lookup("$set").Document()
+		if set.Lookup("assignee").StringValue() != "y@example.com" {
+			t.Errorf("Expected the new assignee y, got %v", set.Lookup("assignee"))
+		}
+		change := set.Lookup("assignment_history", "$concatArrays").Array().Index(1).Value().Array().Index(0).Value().Document()
+		if change.Lookup("from").StringValue() != "$assignee" || change.Lookup("changed_by").StringValue() != "lead@example.com" {
+			t.Errorf("Expected the previous assignee to be recorded, got %v", change)
+		}
+	})
+}
+
+func TestValidateBulkSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      models.BulkReassignRequest
+		expected error
+	}{
+		{name: "keys", req: models.BulkReassignRequest{Keys: []int{1, 2}}},
+		{name: "filter", req: models.BulkReassignRequest{Filter: &models.BulkReassignFilter{Status: models.Open}}},
+		{name: "neither", req: models.BulkReassignRequest{}, expected: ErrInvalidBulkSelection},
+		{name: "both", req: models.BulkReassignRequest{Keys: []int{1}, Filter: &models.BulkReassignFilter{Assignee: "x@example.com"}}, expected: ErrInvalidBulkSelection},
+		{name: "empty filter", req: models.BulkReassignRequest{Filter: &models.BulkReassignFilter{}}, expected: ErrInvalidBulkSelection},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateBulkSelection(&tt.req)
+
+			// Assert
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
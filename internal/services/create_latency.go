@@ -0,0 +1,24 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// createTimings breaks down how long the steps of an incident create took
+type createTimings struct {
+	KeyAllocation time.Duration // Scanning for or incrementing the next incident key
+	Insert        time.Duration
+	Produce       time.Duration // Kafka, in-process subscribers and webhooks
+	Total         time.Duration
+}
+
+// slowCreateWarning returns the warning logged when a create exceeded the latency
+// budget, and whether it did. A budget of zero or less disables the warning.
+func slowCreateWarning(incidentKey int, timings createTimings, budget time.Duration) (string, bool) {
+	if budget <= 0 || timings.Total <= budget {
+		return "", false
+	}
+	return fmt.Sprintf("Slow incident create: key=%d total=%s budget=%s key_allocation=%s insert=%s produce=%s",
+		incidentKey, timings.Total, budget, timings.KeyAllocation, timings.Insert, timings.Produce), true
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSlowCreateWarning(t *testing.T) {
+	timings := func(total time.Duration) createTimings {
+		return createTimings{
+			KeyAllocation: 300 * time.Millisecond,
+			Insert:        120 * time.Millisecond,
+			Produce:       40 * time.Millisecond,
+			Total:         total,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		timings  createTimings
+		budget   time.Duration
+		expected bool
+	}{
+		{name: "past budget", timings: timings(600 * time.Millisecond), budget: 500 * time.Millisecond, expected: true},
+		{name: "below budget", timings: timings(400 * time.Millisecond), budget: 500 * time.Millisecond, expected: false},
+		{name: "exactly at budget", timings: timings(500 * time.Millisecond), budget: 500 * time.Millisecond, expected: false},
+		{name: "disabled", timings: timings(10 * time.Second), budget: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			warning, slow := slowCreateWarning(42, tt.timings, tt.budget)
+
+			// Assert
+			if slow != tt.expected {
+				t.Fatalf("Expected slow=%t, got %t", tt.expected, slow)
+			}
+			if !slow {
+				if warning != "" {
+					t.Errorf("Expected no warning, got %q", warning)
+				}
+				return
+			}
+			for _, part := range []string{"key=42", "key_allocation=300ms", "insert=120ms", "produce=40ms", "total=600ms"} {
+				if !strings.Contains(warning, part) {
+					t.Errorf("Expected warning to contain %q, got %q", part, warning)
+				}
+			}
+		})
+	}
+}
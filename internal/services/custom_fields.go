@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Custom field types a schema may declare
+const (
+	CustomFieldString = "string"
+	CustomFieldNumber = "number"
+	CustomFieldBool   = "bool"
+)
+
+// CustomFieldError is returned when custom fields do not match the configured schema
+type CustomFieldError struct {
+	Key    string
+	Reason string
+}
+
+func (e *CustomFieldError) Error() string {
+	return fmt.Sprintf("invalid custom field %s: %s", e.Key, e.Reason)
+}
+
+// validateCustomFields checks every field is declared in the schema with a value of
+// the declared type. Keys are checked in order so errors are deterministic.
+func validateCustomFields(fields map[string]interface{}, schema map[string]string) error {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldType, ok := schema[key]
+		if !ok {
+			return &CustomFieldError{Key: key, Reason: "unknown field"}
+		}
+
+		var matches bool
+		switch fields[key].(type) {
+		case string:
+			matches = fieldType == CustomFieldString
+		case float64, int, int32, int64:
+			matches = fieldType == CustomFieldNumber
+		case bool:
+			matches = fieldType == CustomFieldBool
+		}
+		if !matches {
+			return &CustomFieldError{Key: key, Reason: fmt.Sprintf("expected %s", fieldType)}
+		}
+	}
+	return nil
+}
+
+// parseCustomFieldFilters converts ?custom.<key>=value query values into typed
+// values according to the schema
+func parseCustomFieldFilters(query map[string]string, schema map[string]string) (map[string]interface{}, error) {
+	filters := make(map[string]interface{}, len(query))
+	for key, raw := range query {
+		fieldType, ok := schema[key]
+		if !ok {
+			return nil, &CustomFieldError{Key: key, Reason: "unknown field"}
+		}
+
+		switch fieldType {
+		case CustomFieldNumber:
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return nil, &CustomFieldError{Key: key, Reason: "expected number"}
+			}
+			filters[key] = number
+		case CustomFieldBool:
+			enabled, err := strconv.ParseBool(raw)
+			if err != nil {
+				return nil, &CustomFieldError{Key: key, Reason: "expected bool"}
+			}
+			filters[key] = enabled
+		default:
+			filters[key] = raw
+		}
+	}
+	return filters, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+var testCustomFieldSchema = map[string]string{
+	"customer_id":  CustomFieldString,
+	"jira_ticket":  CustomFieldString,
+	"impact_score": CustomFieldNumber,
+}
+
+func TestValidateCustomFields(t *testing.T) {
+	tests := []struct {
+		name      string
+		fields    map[string]interface{}
+		expectKey string
+	}{
+		{name: "valid fields", fields: map[string]interface{}{"jira_ticket": "OPS-12", "impact_score": float64(3)}},
+		{name: "unknown key", fields: map[string]interface{}{"jira_ticket": "OPS-12", "region": "eu"}, expectKey: "region"},
+		{name: "type mismatch", fields: map[string]interface{}{"impact_score": "high"}, expectKey: "impact_score"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := validateCustomFields(tt.fields, testCustomFieldSchema)
+
+			// Assert
+			if tt.expectKey == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			var fieldErr *CustomFieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected CustomFieldError, got %v", err)
+			}
+			if fieldErr.Key != tt.expectKey {
+				t.Errorf("Expected error on %s, got %s", tt.expectKey, fieldErr.Key)
+			}
+		})
+	}
+}
+
+func TestParseCustomFieldFilters(t *testing.T) {
+	// Act
+	filters, err := parseCustomFieldFilters(map[string]string{"jira_ticket": "OPS-12", "impact_score": "3"}, testCustomFieldSchema)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if filters["jira_ticket"] != "OPS-12" {
+		t.Errorf("Expected jira_ticket OPS-12, got %v", filters["jira_ticket"])
+	}
+	if filters["impact_score"] != float64(3) {
+		t.Errorf("Expected impact_score 3, got %v", filters["impact_score"])
+	}
+
+	if _, err := parseCustomFieldFilters(map[string]string{"region": "eu"}, testCustomFieldSchema); err == nil {
+		t.Error("Expected an error filtering on an unknown field")
+	}
+}
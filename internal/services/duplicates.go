@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// duplicateCandidateLimit caps the open incidents compared against a new title
+const duplicateCandidateLimit = 100
+
+// findPossibleDuplicates returns the open incidents whose normalized title matches
+// and, when DUPLICATE_MATCH is title_and_service, that share an affected service
+func (s *IncidentService) findPossibleDuplicates(ctx context.Context, title string, services []string) ([]models.PossibleDuplicate, error) {
+	withService := s.cfg.DuplicateMatch == "title_and_service"
+	if s.cfg.DuplicateMatch != "title" && !withService {
+		return nil, nil
+	}
+	if withService && len(services) == 0 {
+		return nil, nil
+	}
+
+	var scope []string
+	if withService {
+		scope = services
+	}
+	candidates, err := s.repo.FindOpenIncidentsForServices(ctx, scope, duplicateCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+	return matchPossibleDuplicates(candidates, title, scope, s.cfg.DuplicateNormalization), nil
+}
+
+// matchPossibleDuplicates picks the candidates whose title normalizes to the same
+// value and, when services are given, that affect at least one of them
+func matchPossibleDuplicates(candidates []models.Incident, title string, services []string, steps []string) []models.PossibleDuplicate {
+	normalized := normalizeTitle(title, steps)
+	var duplicates []models.PossibleDuplicate
+	for _, candidate := range candidates {
+		if normalizeTitle(candidate.Title, steps) != normalized {
+			continue
+		}
+		if len(services) > 0 && !slices.ContainsFunc(candidate.Services, func(service string) bool {
+			return slices.Contains(services, service)
+		}) {
+			continue
+		}
+		duplicates = append(duplicates, models.PossibleDuplicate{
+			IncidentKey: candidate.IncidentKey,
+			Title:       candidate.Title,
+			Services:    candidate.Services,
+		})
+	}
+	return duplicates
+}
+
+// normalizeTitle applies the normalization steps: lowercase folds case,
+// punctuation drops punctuation and symbols, whitespace collapses runs of spaces
+func normalizeTitle(title string, steps []string) string {
+	for _, step := range steps {
+		switch step {
+		case "lowercase":
+			title = strings.ToLower(title)
+		case "punctuation":
+			title = strings.Map(func(r rune) rune {
+				if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+					return ' '
+				}
+				return r
+			}, title)
+		case "whitespace":
+			title = strings.Join(strings.Fields(title), " ")
+		}
+	}
+	return strings.TrimSpace(title)
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+
+	"makers.anchor/incident/internal/models"
+)
+
+func TestMatchPossibleDuplicates(t *testing.T) {
+	steps := []string{"lowercase", "punctuation", "whitespace"}
+	candidates := []models.Incident{
+		{IncidentKey: 1, Title: "DB down!", Services: []string{"payments"}},
+		{IncidentKey: 2, Title: "DB down!", Services: []string{"search"}},
+		{IncidentKey: 3, Title: "DB slow", Services: []string{"payments"}},
+	}
+
+	tests := []struct {
+		name         string
+		title        string
+		services     []string
+		steps        []string
+		expectedKeys []int
+	}{
+		{name: "normalized title on the same service", title: "db down", services: []string{"payments"}, steps: steps, expectedKeys: []int{1}},
+		{name: "title only matches every service", title: "db down", steps: steps, expectedKeys: []int{1, 2}},
+		{name: "different service is not flagged", title: "db down", services: []string{"checkout"}, steps: steps},
+		{name: "without normalization titles must match exactly", title: "db down", services: []string{"payments"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			duplicates := matchPossibleDuplicates(candidates, tt.title, tt.services, tt.steps)
+
+			// Assert
+			if len(duplicates) != len(tt.expectedKeys) {
+				t.Fatalf("Expected %d possible duplicates, got %+v", len(tt.expectedKeys), duplicates)
+			}
+			for i, key := range tt.expectedKeys {
+				if duplicates[i].IncidentKey != key {
+					t.Errorf("Expected incident %d at %d, got %d", key, i, duplicates[i].IncidentKey)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// InvalidValueError is returned when a field holds a value outside its allowed set
+type InvalidValueError struct {
+	Field   string
+	Value   string
+	Allowed []string
+}
+
+func (e *InvalidValueError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Value)
+}
+
+// InvalidSeverity builds the error for a severity outside ValidSeverities
+func InvalidSeverity(value models.IncidentSeverity) *InvalidValueError {
+	return &InvalidValueError{Field: "severity", Value: string(value), Allowed: allowedValues(models.ValidSeverities())}
+}
+
+// InvalidPriority builds the error for a priority outside ValidPriorities
+func InvalidPriority(value models.IncidentPriority) *InvalidValueError {
+	return &InvalidValueError{Field: "priority", Value: string(value), Allowed: allowedValues(models.ValidPriorities())}
+}
+
+// InvalidStatus builds the error for a status outside ValidStatuses
+func InvalidStatus(value models.IncidentStatus) *InvalidValueError {
+	return &InvalidValueError{Field: "status", Value: string(value), Allowed: allowedValues(models.ValidStatuses())}
+}
+
+// InvalidNoteType builds the error for a note type outside ValidNoteTypes
+func InvalidNoteType(value models.NoteType) *InvalidValueError {
+	return &InvalidValueError{Field: "note type", Value: string(value), Allowed: allowedValues(models.ValidNoteTypes())}
+}
+
+// InvalidNoteVisibility builds the error for a note visibility outside ValidNoteVisibilities
+func InvalidNoteVisibility(value models.NoteVisibility) *InvalidValueError {
+	return &InvalidValueError{Field: "visibility", Value: string(value), Allowed: allowedValues(models.ValidNoteVisibilities())}
+}
+
+// InvalidSearchField builds the error for a search field outside ValidSearchFields
+func InvalidSearchField(value models.SearchField) *InvalidValueError {
+	return &InvalidValueError{Field: "search field", Value: string(value), Allowed: allowedValues(models.ValidSearchFields())}
+}
+
+// InvalidSLAStatus builds the error for an SLA status outside ValidSLAStatuses
+func InvalidSLAStatus(value models.SLAStatus) *InvalidValueError {
+	return &InvalidValueError{Field: "sla", Value: string(value), Allowed: allowedValues(models.ValidSLAStatuses())}
+}
+
+// InvalidActionItemStatus builds the error for an action item status outside ValidActionItemStatuses
+func InvalidActionItemStatus(value models.ActionItemStatus) *InvalidValueError {
+	return &InvalidValueError{Field: "action item status", Value: string(value), Allowed: allowedValues(models.ValidActionItemStatuses())}
+}
+
+// InvalidFilterField builds the error for a filter group field outside ValidFilterFields
+func InvalidFilterField(value models.FilterField) *InvalidValueError {
+	return &InvalidValueError{Field: "filter field", Value: string(value), Allowed: allowedValues(models.ValidFilterFields())}
+}
+
+// allowedValues converts enum values to their string form
+func allowedValues[T ~string](values []T) []string {
+	allowed := make([]string, len(values))
+	for i, value := range values {
+		allowed[i] = string(value)
+	}
+	return allowed
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrNoEscalationChain is returned when no responders are configured for the incident's severity
+var ErrNoEscalationChain = errors.New("no escalation chain configured for severity")
+
+// GetNextResponder returns who should be paged next for the incident, moving
+// down the severity's escalation chain for every ack SLA it stays unacknowledged
+func (s *IncidentService) GetNextResponder(ctx context.Context, id string) (*models.NextResponder, error) {
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	chain := s.cfg.EscalationChains[string(incident.Severity)]
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%w %s", ErrNoEscalationChain, incident.Severity)
+	}
+
+	return nextResponder(incident, chain, s.cfg.AckSLA, time.Now()), nil
+}
+
+// nextResponder starts from the assignee's position in the chain (the top when
+// they are not in it) and, while the incident is still open, moves one responder
+// down per elapsed ack SLA, stopping at the end of the chain
+func nextResponder(incident *models.Incident, chain []string, ackSLA time.Duration, now time.Time) *models.NextResponder {
+	start := 0
+	for i, responder := range chain {
+		if strings.EqualFold(responder, incident.Assignee) {
+			start = i
+			break
+		}
+	}
+
+	position := start
+	if incident.Status == models.Open && ackSLA > 0 {
+		position += int(now.Sub(incident.CreatedAt) / ackSLA)
+	}
+	if position >= len(chain) {
+		position = len(chain) - 1
+	}
+
+	return &models.NextResponder{
+		Responder: chain[position],
+		Position:  position,
+		Escalated: position != start,
+	}
+}
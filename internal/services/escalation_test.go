@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+func TestNextResponder(t *testing.T) {
+	chain := []string{"primary@example.com", "secondary@example.com", "manager@example.com"}
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		status          models.IncidentStatus
+		assignee        string
+		age             time.Duration
+		expectResponder string
+		expectEscalated bool
+	}{
+		{name: "within ack SLA pages the assignee", status: models.Open, assignee: "primary@example.com", age: 5 * time.Minute, expectResponder: "primary@example.com"},
+		{name: "unacked past ack SLA pages the next in chain", status: models.Open, assignee: "primary@example.com", age: 20 * time.Minute, expectResponder: "secondary@example.com", expectEscalated: true},
+		{name: "escalation stops at the end of the chain", status: models.Open, assignee: "secondary@example.com", age: 2 * time.Hour, expectResponder: "manager@example.com", expectEscalated: true},
+		{name: "acknowledged incidents do not escalate", status: models.InProgress, assignee: "primary@example.com", age: 2 * time.Hour, expectResponder: "primary@example.com"},
+		{name: "unassigned starts at the top of the chain", status: models.Open, age: time.Minute, expectResponder: "primary@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			incident := &models.Incident{Status: tt.status, Assignee: tt.assignee, CreatedAt: now.Add(-tt.age)}
+
+			// Act
+			next := nextResponder(incident, chain, 15*time.Minute, now)
+
+			// Assert
+			if next.Responder != tt.expectResponder {
+				t.Errorf("Expected responder %s, got %s", tt.expectResponder, next.Responder)
+			}
+			if next.Escalated != tt.expectEscalated {
+				t.Errorf("Expected escalated %t, got %t", tt.expectEscalated, next.Escalated)
+			}
+		})
+	}
+}
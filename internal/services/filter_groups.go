@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// FilterExpressionError is returned when an ?or= filter group is not a
+// comma-separated list of field:value terms
+type FilterExpressionError struct {
+	Expression string
+	Reason     string
+}
+
+func (e *FilterExpressionError) Error() string {
+	return fmt.Sprintf("malformed filter %q: %s", e.Expression, e.Reason)
+}
+
+// ParseFilterGroup parses an OR group such as "status:open,severity:critical"
+// into its conditions. Fields must be one of ValidFilterFields, and status and
+// severity values must be valid for their field.
+func ParseFilterGroup(expression string) ([]models.FilterCondition, error) {
+	if strings.TrimSpace(expression) == "" {
+		return nil, &FilterExpressionError{Expression: expression, Reason: "expected at least one field:value term"}
+	}
+
+	terms := strings.Split(expression, ",")
+	conditions := make([]models.FilterCondition, 0, len(terms))
+	for _, term := range terms {
+		field, value, found := strings.Cut(strings.TrimSpace(term), ":")
+		if !found || field == "" || value == "" {
+			return nil, &FilterExpressionError{Expression: expression, Reason: fmt.Sprintf("term %q is not field:value", term)}
+		}
+
+		condition := models.FilterCondition{Field: models.FilterField(field), Value: value}
+		if err := validateFilterCondition(condition); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// validateFilterCondition checks the field is supported and, for enum fields,
+// that the value is one of its allowed values
+func validateFilterCondition(condition models.FilterCondition) error {
+	switch condition.Field {
+	case models.FilterStatus:
+		if status := models.IncidentStatus(condition.Value); !status.IsValid() {
+			return InvalidStatus(status)
+		}
+	case models.FilterSeverity:
+		if severity := models.IncidentSeverity(condition.Value); !severity.IsValid() {
+			return InvalidSeverity(severity)
+		}
+	default:
+		if !condition.Field.IsValid() {
+			return InvalidFilterField(condition.Field)
+		}
+	}
+	return nil
+}
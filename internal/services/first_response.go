@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"makers.anchor/incident/internal/metrics"
+	"makers.anchor/incident/internal/models"
+)
+
+// recordFirstResponse stamps the incident's first response at the given time when
+// it has none yet, observing the time to first response for its severity. Failing
+// to record it is logged rather than failing the note that triggered it.
+func (s *IncidentService) recordFirstResponse(ctx context.Context, incident *models.Incident, at time.Time) {
+	if incident.FirstResponseAt != nil {
+		return
+	}
+
+	seconds := max(at.Sub(incident.CreatedAt).Seconds(), 0)
+	claimed, err := s.repo.MarkFirstResponse(ctx, incident, at, seconds)
+	if err != nil {
+		log.Printf("Error recording first response for incident %d: %v", incident.IncidentKey, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	incident.FirstResponseAt = &at
+	incident.FirstResponseSeconds = seconds
+	metrics.FirstResponseTime.WithLabelValues(string(incident.Severity)).Observe(seconds)
+}
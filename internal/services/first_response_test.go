@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestAddNoteToIncident_RecordsFirstResponse(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("first note sets the first response", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		createdAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Millisecond)
+		incident := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "open"}, {Key: "created_at", Value: createdAt}}
+		withNote := append(incident, bson.E{Key: "notes", Value: bson.A{bson.D{{Key: "content", Value: "Looking into it"}}}})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: withNote}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+
+		// Act
+		updated, err := service.AddNoteToIncident(context.Background(), id.Hex(), &models.AddNoteRequest{Content: "Looking into it"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		mt.GetStartedEvent() // the note push
+		mark := mt.GetStartedEvent()
+		if mark == nil || mark.CommandName != "update" {
+			t.Fatalf("Expected the first response to be recorded, got %+v", mark)
+		}
+		statement := mark.Command.Lookup("updates").Array().Index(0).Value().Document()
+		if _, err := statement.LookupErr("q", "first_response_at", "$exists"); err != nil {
+			t.Errorf("Expected the update to skip incidents with a first response, got %s", statement)
+		}
+		if updated.FirstResponseAt == nil {
+			t.Fatalf("Expected FirstResponseAt to be set")
+		}
+		if updated.FirstResponseSeconds < time.Hour.Seconds() {
+			t.Errorf("Expected about an hour to first response, got %.0fs", updated.FirstResponseSeconds)
+		}
+	})
+
+	mt.Run("later notes keep the first response", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		firstResponse := time.Now().Add(-30 * time.Minute).UTC().Truncate(time.Millisecond)
+		incident := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "open"}, {Key: "first_response_at", Value: firstResponse}, {Key: "first_response_seconds", Value: 120.0}}
+		withNotes := append(incident, bson.E{Key: "notes", Value: bson.A{bson.D{{Key: "content", Value: "Looking into it"}}, bson.D{{Key: "content", Value: "Rolled back"}}}})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: withNotes}),
+		)
+
+		// Act
+		updated, err := service.AddNoteToIncident(context.Background(), id.Hex(), &models.AddNoteRequest{Content: "Rolled back"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		mt.GetStartedEvent() // the note push
+		if extra := mt.GetStartedEvent(); extra != nil {
+			t.Errorf("Expected no further updates, got %s", extra.CommandName)
+		}
+		if updated.FirstResponseAt == nil || !updated.FirstResponseAt.Equal(firstResponse) {
+			t.Errorf("Expected FirstResponseAt to stay %s, got %v", firstResponse, updated.FirstResponseAt)
+		}
+		if updated.FirstResponseSeconds != 120 {
+			t.Errorf("Expected FirstResponseSeconds to stay 120, got %.0f", updated.FirstResponseSeconds)
+		}
+	})
+}
+
+func TestUpdateIncidentStatus_NoteRecordsFirstResponse(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("status note sets the first response", func(mt *mtest.T) {
+		// Arrange
+		service := newTransitionNoteTestService(mt, map[string]string{"resolved": "resolution"})
+		id := primitive.NewObjectID()
+		createdAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Millisecond)
+		incident := append(transitionTestIncident(id, models.InProgress), bson.E{Key: "created_at", Value: createdAt})
+		resolved := append(transitionTestIncident(id, models.Resolved), bson.E{Key: "created_at", Value: createdAt})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: resolved}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+
+		// Act
+		updated, _, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{
+			Status: models.Resolved,
+			Note:   "Rolled back the bad deploy",
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		mt.GetStartedEvent() // the status update with its note
+		mark := mt.GetStartedEvent()
+		if mark == nil || mark.CommandName != "update" {
+			t.Fatalf("Expected the first response to be recorded, got %+v", mark)
+		}
+		if updated.FirstResponseAt == nil {
+			t.Fatalf("Expected FirstResponseAt to be set")
+		}
+		if updated.FirstResponseSeconds < time.Hour.Seconds() {
+			t.Errorf("Expected about an hour to first response, got %.0fs", updated.FirstResponseSeconds)
+		}
+	})
+}
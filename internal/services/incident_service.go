@@ -2,37 +2,247 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/badoux/checkmail"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
 	"makers.anchor/incident/internal/kafka"
+	"makers.anchor/incident/internal/metrics"
 	"makers.anchor/incident/internal/models"
 	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
 )
 
+// onCallRotationCounter is the counter tracking the on-call round-robin position
+const onCallRotationCounter = "oncall_rotation"
+
 // IncidentService handles business logic for incidents
 type IncidentService struct {
 	repo     *repository.IncidentRepository
 	producer *kafka.Producer
+	bus      *eventbus.EventBus
+	webhooks *webhooks.Dispatcher
+	hooks    *statusHookRegistry
+	cfg      *config.Config
+}
+
+// ErrTitleTooLong is returned when a title exceeds the maximum length and overflow is rejected
+var ErrTitleTooLong = errors.New("title is too long")
+
+// ErrInvalidPage is returned for a page or page size out of range
+var ErrInvalidPage = errors.New("invalid page")
+
+// ErrInvalidTrendParams is returned for an unknown trend interval or an empty range
+var ErrInvalidTrendParams = errors.New("invalid trend parameters")
+
+// ErrNoteLimitReached is returned when an incident already holds the maximum number of notes
+var ErrNoteLimitReached = errors.New("incident has reached the maximum number of notes")
+
+// ErrNoteAuthorRequired is returned when notes must have an author and none was given
+var ErrNoteAuthorRequired = errors.New("note author email is required")
+
+// ErrAssigneeRequired is returned when resolving or closing an unassigned incident
+// while REQUIRE_ASSIGNEE_TO_RESOLVE is on
+var ErrAssigneeRequired = errors.New("incident must be assigned before it is resolved or closed")
+
+// ErrInProgressRequired is returned when resolving an open incident while
+// MIN_TIME_IN_PROGRESS is set, which would skip the time it must spend in progress
+var ErrInProgressRequired = errors.New("incident must be in_progress before it is resolved")
+
+// ErrInvalidNoteAuthor is returned when a required note author email is malformed
+var ErrInvalidNoteAuthor = errors.New("invalid note author email")
+
+// DuplicateIncidentError is returned when an open incident with the same title was created recently
+type DuplicateIncidentError struct {
+	ExistingKey int
+}
+
+func (e *DuplicateIncidentError) Error() string {
+	return fmt.Sprintf("duplicate of open incident %s", models.FormatIncidentKey(e.ExistingKey))
+}
+
+// FieldConflictError is returned when a create request supplies fields that cannot coexist
+type FieldConflictError struct {
+	Rule    string
+	Fields  []string
+	Message string
+}
+
+func (e *FieldConflictError) Error() string {
+	return fmt.Sprintf("conflicting fields %s: %s", strings.Join(e.Fields, ", "), e.Message)
+}
+
+// createConflictRule describes a combination of create request fields that is rejected
+type createConflictRule struct {
+	fields    []string
+	message   string
+	conflicts func(req *models.CreateIncidentRequest) bool
+}
+
+// createConflictRules lists the cross-field rules that can be enabled by name
+var createConflictRules = map[string]createConflictRule{
+	// A new incident is always open, so it cannot already carry its resolution
+	"resolution_note": {
+		fields:  []string{"notes.type"},
+		message: "resolution notes cannot be supplied when creating an incident",
+		conflicts: func(req *models.CreateIncidentRequest) bool {
+			for _, note := range req.Notes {
+				if note.Type == models.Resolution {
+					return true
+				}
+			}
+			return false
+		},
+	},
+	// The author is added as a watcher, so assigning it to them mixes both roles
+	"assignee_is_watcher": {
+		fields:  []string{"assignee", "author_email"},
+		message: "assignee cannot be the author, who is added as a watcher",
+		conflicts: func(req *models.CreateIncidentRequest) bool {
+			assignee := strings.TrimSpace(req.Assignee)
+			return assignee != "" && strings.EqualFold(assignee, strings.TrimSpace(req.AuthorEmail))
+		},
+	},
+}
+
+// checkCreateConflicts returns the first enabled conflict rule the request violates
+func checkCreateConflicts(req *models.CreateIncidentRequest, enabled []string) error {
+	for _, name := range enabled {
+		rule, ok := createConflictRules[name]
+		if !ok {
+			log.Printf("Unknown create conflict rule: %s", name)
+			continue
+		}
+		if rule.conflicts(req) {
+			return &FieldConflictError{Rule: name, Fields: rule.fields, Message: rule.message}
+		}
+	}
+	return nil
 }
 
 // NewIncidentService creates a new incident service
-func NewIncidentService(repo *repository.IncidentRepository, producer *kafka.Producer) *IncidentService {
+func NewIncidentService(repo *repository.IncidentRepository, producer *kafka.Producer, bus *eventbus.EventBus, dispatcher *webhooks.Dispatcher, cfg *config.Config) *IncidentService {
 	return &IncidentService{
 		repo:     repo,
 		producer: producer,
+		bus:      bus,
+		webhooks: dispatcher,
+		hooks:    newStatusHookRegistry(),
+		cfg:      cfg,
+	}
+}
+
+// RegisterStatusHook registers a hook run asynchronously after an incident moves
+// from one status to another. Use AnyStatus as from to match every previous status.
+func (s *IncidentService) RegisterStatusHook(from, to models.IncidentStatus, hook StatusHook) {
+	s.hooks.register(from, to, hook)
+}
+
+// publish sends the event to Kafka, in-process subscribers and outbound webhooks.
+// Kafka only receives events for incidents at or above the minimum event severity,
+// and nothing is sent for drafts until they are published or for scheduled
+// incidents until they open. The Kafka produce is bound to ctx, so a cancelled
+// request stops waiting on the broker.
+func (s *IncidentService) publish(ctx context.Context, incident *models.Incident, event kafka.KafkaEvent) {
+	if incident.Draft || incident.Status == models.Scheduled {
+		return
+	}
+	if shouldEmitEvent(incident.Severity, models.IncidentSeverity(s.cfg.MinEventSeverity)) {
+		if err := s.producer.ProduceMessage(ctx, event); err != nil {
+			log.Printf("Error producing %s event: %v", event.GetEventType(), err)
+		}
 	}
+	s.bus.Publish(event.GetTopic(), event)
+	s.webhooks.Dispatch(event)
+}
+
+// shouldEmitEvent reports whether an incident's severity meets the minimum; an unset
+// or unknown minimum emits every event
+func shouldEmitEvent(severity, minSeverity models.IncidentSeverity) bool {
+	if !minSeverity.IsValid() {
+		return true
+	}
+	return severity.Rank() >= minSeverity.Rank()
+}
+
+// SubscribeToEvents registers a live subscriber for incident events
+func (s *IncidentService) SubscribeToEvents() *eventbus.Subscription {
+	return s.bus.Subscribe(models.EVENT_TOPIC)
+}
+
+// EventVisibleToTenant reports whether a subscriber scoped to tenantID may receive
+// the event. Unscoped subscribers, as in single-tenant deployments, receive every event.
+func EventVisibleToTenant(event kafka.KafkaEvent, tenantID string) bool {
+	if tenantID == "" {
+		return true
+	}
+	owned, ok := event.(kafka.TenantEvent)
+	return ok && owned.GetTenantID() == tenantID
+}
+
+// UnsubscribeFromEvents removes a live subscriber for incident events
+func (s *IncidentService) UnsubscribeFromEvents(sub *eventbus.Subscription) {
+	s.bus.Unsubscribe(sub)
 }
 
 // CreateIncident creates a new incident
 func (s *IncidentService) CreateIncident(ctx context.Context, req *models.CreateIncidentRequest) (*models.Incident, error) {
+	var timings createTimings
+	started := time.Now()
+
 	// Validate severity
 	if !req.Severity.IsValid() {
-		return nil, fmt.Errorf("invalid severity: %s", req.Severity)
+		return nil, InvalidSeverity(req.Severity)
+	}
+
+	// Priority is derived from the severity unless given
+	priority := req.Priority
+	if priority == "" {
+		priority = req.Severity.DefaultPriority()
+	}
+	if !priority.IsValid() {
+		return nil, InvalidPriority(priority)
+	}
+
+	// A retried request returns the incident its first attempt created
+	if req.IdempotencyKey != "" {
+		existing, err := s.repo.FindByIdempotencyKey(ctx, req.IdempotencyKey)
+		if err != nil {
+			log.Printf("Error checking idempotency key: %v", err)
+			return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+		}
+		if existing != nil {
+			return idempotentReplay(existing), nil
+		}
+	}
+
+	if err := checkCreateConflicts(req, s.cfg.CreateConflictRules); err != nil {
+		return nil, err
+	}
+
+	status, err := initialStatus(req.ActivateAt, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCustomFields(req.CustomFields, s.cfg.CustomFieldSchema); err != nil {
+		return nil, err
+	}
+
+	title, truncated, err := applyTitleLimit(req.Title, s.cfg.MaxTitleLength, s.cfg.TitleOverflow == "truncate")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkDescriptionLength(req.Description, req.Severity, s.cfg.MinDescriptionLengths); err != nil {
+		return nil, err
 	}
 
 	// Initialize notes array - handle both cases where req.Notes might exist or not
@@ -45,6 +255,7 @@ func (s *IncidentService) CreateIncident(ctx context.Context, req *models.Create
 				Content:     note.Content,
 				AuthorEmail: note.AuthorEmail,
 				CreatedAt:   time.Now().UTC(),
+				Visibility:  s.noteVisibility(note.Visibility),
 			}
 		}
 	} else {
@@ -56,16 +267,55 @@ func (s *IncidentService) CreateIncident(ctx context.Context, req *models.Create
 	// 1. If the mail is empty keep watchlist empty
 	// 2. If the mail is not empty, validate the format
 	// 3. If the format is valid, add to watchlist
+	// 4. The system actor never watches the incidents it creates
 	var watcherList []models.Watcher = []models.Watcher{}
-	if strings.Trim(req.AuthorEmail, " ") != "" {
+	if strings.Trim(req.AuthorEmail, " ") != "" && req.AuthorEmail != s.cfg.SystemActorEmail {
 		if conditionErr := s.validateEmail(req.AuthorEmail); conditionErr != nil {
 			return nil, fmt.Errorf("invalid email: %w", conditionErr)
 		}
 		watcherList = append(watcherList, models.Watcher{Email: req.AuthorEmail})
 	}
+	for _, watcher := range req.Watchers {
+		if conditionErr := s.validateEmail(watcher.Email); conditionErr != nil {
+			return nil, fmt.Errorf("invalid email: %w", conditionErr)
+		}
+	}
+	watcherList = mergeWatchers(watcherList, req.Watchers, defaultWatchers(s.cfg.DefaultWatchers, req.Severity))
+
+	// Reject duplicates of recently created open incidents when enabled
+	if s.cfg.DedupWindow > 0 {
+		candidates, err := s.repo.FindOpenIncidentsByTitle(ctx, req.Title)
+		if err != nil {
+			log.Printf("Error checking for duplicate incidents: %v", err)
+			return nil, fmt.Errorf("failed to check for duplicate incidents: %w", err)
+		}
+		if duplicate := findDuplicateIncident(candidates, req.Title, s.cfg.DedupWindow, time.Now()); duplicate != nil {
+			return nil, &DuplicateIncidentError{ExistingKey: duplicate.IncidentKey}
+		}
+	}
+
+	// Flag likely duplicates without refusing the create
+	possibleDuplicates, err := s.findPossibleDuplicates(ctx, req.Title, req.Services)
+	if err != nil {
+		log.Printf("Error checking for possible duplicates: %v", err)
+		return nil, fmt.Errorf("failed to check for possible duplicates: %w", err)
+	}
+
+	// Rotate through the on-call list when no assignee was given
+	assignee := req.Assignee
+	if strings.TrimSpace(assignee) == "" && len(s.cfg.OnCallAssignees) > 0 {
+		sequence, err := s.repo.NextSequence(ctx, onCallRotationCounter)
+		if err != nil {
+			log.Printf("Error rotating on-call assignee: %v", err)
+			return nil, fmt.Errorf("failed to assign on-call responder: %w", err)
+		}
+		assignee = roundRobinAssignee(s.cfg.OnCallAssignees, sequence)
+	}
 
 	// Get next incident key
-	nextKey, err := s.repo.GetNextIncidentKey(ctx)
+	stepStarted := time.Now()
+	nextKey, err := s.nextIncidentKey(ctx)
+	timings.KeyAllocation = time.Since(stepStarted)
 	if err != nil {
 		log.Printf("Error generating incident key: %v", err)
 		return nil, fmt.Errorf("failed to generate incident key: %w", err)
@@ -74,33 +324,158 @@ func (s *IncidentService) CreateIncident(ctx context.Context, req *models.Create
 	// Create incident with default status
 	incident := &models.Incident{
 		IncidentKey: nextKey,
-		Title:       req.Title,
+		Title:       title,
 		Severity:    req.Severity,
-		Status:      models.Open,
+		Priority:    priority,
+		Status:      status,
+		ActivateAt:  req.ActivateAt,
 		Notes:       notes,
 		WatchList:   watcherList,
 		CreatedBy:   req.AuthorEmail,
-		Description: req.Description,
-		Assignee:    req.Assignee,
+		Description: descriptionOrTemplate(req.Description, s.cfg.DescriptionTemplate),
+		Assignee:    assignee,
+		Tags:        req.Tags,
+		Services:    req.Services,
+
+		CustomFields:   req.CustomFields,
+		Draft:          req.Draft,
+		TitleTruncated: truncated,
+		ExternalID:     req.ExternalID,
+		IdempotencyKey: req.IdempotencyKey,
 	}
 
+	stepStarted = time.Now()
 	createdIncident, err := s.repo.Create(ctx, incident)
+	timings.Insert = time.Since(stepStarted)
+	if err != nil && req.IdempotencyKey != "" && errors.Is(err, repository.ErrDuplicateIncident) {
+		// A concurrent retry with the same key inserted first, so replay its incident
+		if existing, findErr := s.repo.FindByIdempotencyKey(ctx, req.IdempotencyKey); findErr == nil && existing != nil {
+			return idempotentReplay(existing), nil
+		}
+	}
 	if err != nil {
 		log.Printf("Error creating incident: %v", err)
 		return nil, fmt.Errorf("failed to create incident: %w", err)
 	}
 
-	log.Printf("Created new incident: ID=%s, Title=%s, Severity=%s",
+	config.Infof("Created new incident: ID=%s, Title=%s, Severity=%s",
 		createdIncident.ID.Hex(), createdIncident.Title, createdIncident.Severity)
+	if req.IdempotencyKey != "" {
+		metrics.IdempotentCreates.Inc()
+	}
+	createdIncident.PossibleDuplicates = possibleDuplicates
+
+	stepStarted = time.Now()
+	s.publish(ctx, createdIncident, incidentCreatedEvent(createdIncident))
+	timings.Produce = time.Since(stepStarted)
+
+	timings.Total = time.Since(started)
+	if warning, slow := slowCreateWarning(createdIncident.IncidentKey, timings, s.cfg.CreateLatencyBudget); slow {
+		log.Print(warning)
+	}
+
+	return createdIncident, nil
+}
+
+// idempotentReplay marks the incident a retried create returns in place of a new one
+func idempotentReplay(incident *models.Incident) *models.Incident {
+	metrics.DuplicateRequests.Inc()
+	incident.IdempotentReplay = true
+	return incident
+}
 
-	s.producer.ProduceMessage(models.IncidentCreated{
+// incidentCreatedEvent builds the event announcing a live incident
+func incidentCreatedEvent(incident *models.Incident) models.IncidentCreated {
+	return models.IncidentCreated{
 		EventKey: primitive.NewObjectID().Hex(),
-		Id:       createdIncident.ID.Hex(),
-		Title:    createdIncident.Title,
-		Severity: string(createdIncident.Severity),
+		Id:       incident.ID.Hex(),
+		TenantID: incident.TenantID,
+		Title:    incident.Title,
+		Severity: string(incident.Severity),
+	}
+}
+
+// PublishIncident makes a draft incident live, announcing it with IncidentCreated
+func (s *IncidentService) PublishIncident(ctx context.Context, id string) (*models.Incident, error) {
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if !incident.Draft {
+		return nil, repository.ErrNotDraft
+	}
+
+	publishedIncident, err := s.repo.PublishDraft(ctx, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+	config.Infof("Published draft incident: ID=%s", publishedIncident.ID.Hex())
+
+	s.publish(ctx, publishedIncident, incidentCreatedEvent(publishedIncident))
+	return publishedIncident, nil
+}
+
+// nextIncidentKey allocates an incident key using the configured numbering mode
+func (s *IncidentService) nextIncidentKey(ctx context.Context) (int, error) {
+	if s.cfg.IncidentKeyMode == "yearly" {
+		return s.repo.GetNextYearlyIncidentKey(ctx, time.Now())
+	}
+	return s.repo.GetNextIncidentKey(ctx)
+}
+
+// CreateSystemIncident creates an incident on behalf of a webhook or job, recording
+// the configured system actor as its author
+func (s *IncidentService) CreateSystemIncident(ctx context.Context, req *models.CreateIncidentRequest) (*models.Incident, error) {
+	return s.CreateIncident(ctx, asSystemActor(req, s.cfg.SystemActorEmail))
+}
+
+// AddSystemNote adds a note on behalf of a webhook or job, authored by the system actor
+func (s *IncidentService) AddSystemNote(ctx context.Context, incidentID, content string, noteType models.NoteType) (*models.Incident, error) {
+	return s.AddNoteToIncident(ctx, incidentID, &models.AddNoteRequest{
+		Content:     content,
+		AuthorEmail: s.cfg.SystemActorEmail,
+		Type:        noteType,
 	})
+}
 
-	return createdIncident, nil
+// asSystemActor returns a copy of the request authored by the system actor,
+// including any initial notes without an author of their own
+func asSystemActor(req *models.CreateIncidentRequest, actor string) *models.CreateIncidentRequest {
+	systemReq := *req
+	systemReq.AuthorEmail = actor
+
+	if req.Notes != nil {
+		systemReq.Notes = make([]models.Note, len(req.Notes))
+		for i, note := range req.Notes {
+			if strings.TrimSpace(note.AuthorEmail) == "" {
+				note.AuthorEmail = actor
+			}
+			systemReq.Notes[i] = note
+		}
+	}
+	return &systemReq
+}
+
+// roundRobinAssignee picks the assignee for the given 1-based rotation sequence
+func roundRobinAssignee(assignees []string, sequence int) string {
+	index := (sequence - 1) % len(assignees)
+	if index < 0 {
+		index += len(assignees)
+	}
+	return assignees[index]
+}
+
+// findDuplicateIncident returns the first candidate with the same title created within the window
+func findDuplicateIncident(candidates []models.Incident, title string, window time.Duration, now time.Time) *models.Incident {
+	for i := range candidates {
+		if candidates[i].Title != title {
+			continue
+		}
+		if now.Sub(candidates[i].CreatedAt) <= window {
+			return &candidates[i]
+		}
+	}
+	return nil
 }
 
 // GetByID fetches an incident by its ID
@@ -111,116 +486,483 @@ func (s *IncidentService) GetByID(ctx context.Context, id string) (*models.Incid
 		return nil, fmt.Errorf("failed to get incident: %w", err)
 	}
 
-	log.Printf("Fetched incident: ID=%s, Title=%s, Status=%s",
+	config.Infof("Fetched incident: ID=%s, Title=%s, Status=%s",
 		incident.ID.Hex(), incident.Title, incident.Status)
 
+	incident.NeedsAttention = needsAttention(incident.Status, incident.UpdatedAt, time.Now(), s.cfg.AttentionThreshold)
+	return incident, nil
+}
+
+// GetByIncidentKey fetches an incident by its numeric incident_key
+func (s *IncidentService) GetByIncidentKey(ctx context.Context, key string) (*models.Incident, error) {
+	incidentKey, err := models.ParseIncidentKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrInvalidIncidentID, err)
+	}
+
+	incident, err := s.repo.GetByIncidentKey(ctx, incidentKey)
+	if err != nil {
+		log.Printf("Error fetching incident by key %d: %v", incidentKey, err)
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	incident.NeedsAttention = needsAttention(incident.Status, incident.UpdatedAt, time.Now(), s.cfg.AttentionThreshold)
 	return incident, nil
 }
 
-// GetAllIncidents fetches all incidents
-func (s *IncidentService) GetAllIncidents(ctx context.Context) ([]models.Incident, error) {
-	incidents, err := s.repo.GetAllIncidents(ctx)
+// GetPublicStatus returns the public status page view of the incident with the
+// given key. Drafts are reported as not found.
+func (s *IncidentService) GetPublicStatus(ctx context.Context, key string) (*models.PublicIncident, error) {
+	incident, err := s.GetByIncidentKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if incident.Draft {
+		return nil, fmt.Errorf("failed to get incident: %w", repository.ErrIncidentNotFound)
+	}
+
+	public := models.NewPublicIncident(incident)
+	return &public, nil
+}
+
+// GetByObjectID fetches an incident by its MongoDB ObjectID
+func (s *IncidentService) GetByObjectID(ctx context.Context, id string) (*models.Incident, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrInvalidIncidentID, err)
+	}
+
+	incident, err := s.repo.GetByObjectID(ctx, objectID)
+	if err != nil {
+		log.Printf("Error fetching incident by ObjectID %s: %v", id, err)
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	incident.NeedsAttention = needsAttention(incident.Status, incident.UpdatedAt, time.Now(), s.cfg.AttentionThreshold)
+	return incident, nil
+}
+
+// maxRelatedIncidents caps the number of related incidents returned
+const maxRelatedIncidents = 10
+
+// GetRelatedIncidents returns other incidents sharing tags or services with the
+// incident, most overlapping first
+func (s *IncidentService) GetRelatedIncidents(ctx context.Context, id string) ([]models.RelatedIncident, error) {
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	if len(incident.Tags) == 0 && len(incident.Services) == 0 {
+		return []models.RelatedIncident{}, nil
+	}
+
+	related, err := s.repo.GetRelatedIncidents(ctx, incident, maxRelatedIncidents)
+	if err != nil {
+		log.Printf("Error fetching incidents related to %s: %v", id, err)
+		return nil, fmt.Errorf("failed to get related incidents: %w", err)
+	}
+	return related, nil
+}
+
+// GetAllIncidents fetches a page of the incidents matching the filters
+func (s *IncidentService) GetAllIncidents(ctx context.Context, params models.ListIncidentsParams) (*models.IncidentPage, error) {
+	if params.SortField == "" {
+		params.SortField = s.cfg.ListSortField
+		params.SortDirection = s.cfg.ListSortDirection
+	}
+	page, err := listPage(params.Page)
+	if err != nil {
+		return nil, err
+	}
+	params.Page = page
+
+	now := time.Now()
+	if s.filtersMatchNothing(params) {
+		return &models.IncidentPage{
+			Items:      []models.IncidentSummary{},
+			Pagination: models.Pagination{Page: page.Page, Limit: page.PageSize},
+		}, nil
+	}
+	params, err = s.resolveListFilters(params, now)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents, total, err := s.repo.GetAllIncidents(ctx, params)
 	if err != nil {
 		log.Printf("Error fetching incidents: %v", err)
 		return nil, fmt.Errorf("failed to get incidents: %w", err)
 	}
 
-	log.Printf("Fetched %d incidents", len(incidents))
-	return incidents, nil
+	for i := range incidents {
+		incidents[i].NeedsAttention = needsAttention(incidents[i].Status, incidents[i].UpdatedAt, now, s.cfg.AttentionThreshold)
+	}
+
+	config.Infof("Fetched %d incidents", len(incidents))
+	return &models.IncidentPage{
+		Items: incidents,
+		Pagination: models.Pagination{
+			Page:    page.Page,
+			Limit:   page.PageSize,
+			Total:   total,
+			HasMore: page.Page*page.PageSize < total,
+		},
+	}, nil
+}
+
+// listPage defaults the incident list to the first page of DefaultPageSize,
+// capping the limit at MaxPageSize
+func listPage(page models.PageParams) (models.PageParams, error) {
+	if page.Page == 0 {
+		page.Page = 1
+	}
+	if page.PageSize == 0 {
+		page.PageSize = models.DefaultPageSize
+	}
+	if page.Page < 1 || page.PageSize < 1 {
+		return page, fmt.Errorf("%w: page and limit must be at least 1", ErrInvalidPage)
+	}
+	page.PageSize = min(page.PageSize, models.MaxPageSize)
+	return page, nil
+}
+
+// resolveListFilters types the custom field filters against the schema and sets
+// the attention cutoff, shared by the list and facets queries
+func (s *IncidentService) resolveListFilters(params models.ListIncidentsParams, now time.Time) (models.ListIncidentsParams, error) {
+	if len(params.CustomFieldFilter) > 0 {
+		customFields, err := parseCustomFieldFilters(params.CustomFieldFilter, s.cfg.CustomFieldSchema)
+		if err != nil {
+			return params, err
+		}
+		params.CustomFields = customFields
+	}
+
+	if params.NeedsAttention {
+		params.UpdatedBefore = now.Add(-s.cfg.AttentionThreshold)
+	}
+	if params.SLAStatus != "" {
+		params.SLAWindows = slaWindows(params.SLAStatus, s.cfg.SLATargets, s.cfg.SLAAtRiskPercent, now)
+	}
+	return params, nil
+}
+
+// filtersMatchNothing reports list filters that are disabled by configuration:
+// needs_attention without a threshold, or sla without any targets
+func (s *IncidentService) filtersMatchNothing(params models.ListIncidentsParams) bool {
+	return (params.NeedsAttention && s.cfg.AttentionThreshold <= 0) ||
+		(params.SLAStatus != "" && len(s.cfg.SLATargets) == 0)
+}
+
+// GetIncidentFacets returns the distinct assignees, tags and services in use,
+// scoped by the same filters as the incident list
+func (s *IncidentService) GetIncidentFacets(ctx context.Context, params models.ListIncidentsParams) (*models.IncidentFacets, error) {
+	if s.filtersMatchNothing(params) {
+		return &models.IncidentFacets{Assignees: []string{}, Tags: []string{}, Services: []string{}}, nil
+	}
+	params, err := s.resolveListFilters(params, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := s.repo.GetFacets(ctx, params)
+	if err != nil {
+		log.Printf("Error fetching incident facets: %v", err)
+		return nil, fmt.Errorf("failed to get incident facets: %w", err)
+	}
+	return facets, nil
+}
+
+// needsAttention reports whether an unresolved incident has gone longer than the
+// threshold without an update (a zero threshold disables the flag)
+func needsAttention(status models.IncidentStatus, updatedAt, now time.Time, threshold time.Duration) bool {
+	if threshold <= 0 {
+		return false
+	}
+	if status != models.Open && status != models.InProgress {
+		return false
+	}
+	return now.Sub(updatedAt) > threshold
+}
+
+// GetIncidentStats computes summary statistics for incidents
+func (s *IncidentService) GetIncidentStats(ctx context.Context) (*models.IncidentStats, error) {
+	openByAge, err := s.repo.GetOpenIncidentAgeBuckets(ctx, time.Now())
+	if err != nil {
+		log.Printf("Error computing incident age buckets: %v", err)
+		return nil, fmt.Errorf("failed to get incident stats: %w", err)
+	}
+
+	return &models.IncidentStats{
+		OpenByAge: openByAge,
+	}, nil
+}
+
+// GetAssigneeStats returns a page of the open incidents per assignee, defaulting
+// to the first page of DefaultPageSize
+func (s *IncidentService) GetAssigneeStats(ctx context.Context, page models.PageParams) (*models.AssigneeStatsPage, error) {
+	if page.Page == 0 {
+		page.Page = 1
+	}
+	if page.PageSize == 0 {
+		page.PageSize = models.DefaultPageSize
+	}
+	if page.Page < 1 || page.PageSize < 1 || page.PageSize > models.MaxPageSize {
+		return nil, fmt.Errorf("%w: page must be at least 1 and page_size between 1 and %d", ErrInvalidPage, models.MaxPageSize)
+	}
+
+	items, total, err := s.repo.GetAssigneeStats(ctx, page)
+	if err != nil {
+		log.Printf("Error computing assignee stats: %v", err)
+		return nil, fmt.Errorf("failed to get assignee stats: %w", err)
+	}
+	return &models.AssigneeStatsPage{Items: items, Page: page.Page, PageSize: page.PageSize, Total: total}, nil
+}
+
+// GetIncidentLoad weights the open incidents by severity into a single load figure
+func (s *IncidentService) GetIncidentLoad(ctx context.Context) (*models.IncidentLoad, error) {
+	counts, err := s.repo.CountOpenIncidentsBySeverity(ctx)
+	if err != nil {
+		log.Printf("Error counting open incidents: %v", err)
+		return nil, fmt.Errorf("failed to get incident load: %w", err)
+	}
+	return weightedLoad(counts, s.cfg.SeverityWeights), nil
+}
+
+// weightedLoad sums the open incident counts multiplied by their severity's weight.
+// Severities without a weight, such as legacy values, count for nothing.
+func weightedLoad(counts map[models.IncidentSeverity]int, weights map[string]int) *models.IncidentLoad {
+	load := &models.IncidentLoad{
+		BySeverity: make(map[models.IncidentSeverity]int),
+		Weights:    make(map[models.IncidentSeverity]int),
+	}
+	for _, severity := range models.ValidSeverities() {
+		load.BySeverity[severity] = counts[severity]
+		load.Weights[severity] = weights[string(severity)]
+	}
+	for severity, count := range counts {
+		load.OpenCount += count
+		load.Load += count * weights[string(severity)]
+	}
+	return load
+}
+
+// defaultTrendRange is the period covered by a trend when no start is given
+const defaultTrendRange = 30 * 24 * time.Hour
+
+// GetSeverityTrend counts created incidents per interval and severity, defaulting
+// to daily buckets over the last 30 days
+func (s *IncidentService) GetSeverityTrend(ctx context.Context, params models.TrendParams) ([]models.TrendPoint, error) {
+	if params.Interval == "" {
+		params.Interval = models.TrendDay
+	}
+	if !params.Interval.IsValid() {
+		return nil, fmt.Errorf("%w: unknown interval %s", ErrInvalidTrendParams, params.Interval)
+	}
+	if params.To.IsZero() {
+		params.To = time.Now()
+	}
+	if params.From.IsZero() {
+		params.From = params.To.Add(-defaultTrendRange)
+	}
+	if !params.From.Before(params.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidTrendParams)
+	}
+
+	trend, err := s.repo.GetSeverityTrend(ctx, params)
+	if err != nil {
+		log.Printf("Error computing incident trend: %v", err)
+		return nil, fmt.Errorf("failed to get incident trend: %w", err)
+	}
+	return trend, nil
 }
 
 // UpdateIncidentStatus updates the status of an incident
-func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, id string, req *models.UpdateIncidentStatusRequest) (*models.Incident, error) {
+func (s *IncidentService) UpdateIncidentStatus(ctx context.Context, id string, req *models.UpdateIncidentStatusRequest) (*models.Incident, bool, error) {
 	// Validate status
 	if !req.Status.IsValid() {
-		return nil, fmt.Errorf("invalid status: %s", req.Status)
+		return nil, false, InvalidStatus(req.Status)
 	}
 
 	// Check if incident exists first
 	existingIncident, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("incident not found: %w", err)
+		return nil, false, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	// Setting the current status again changes nothing, so nothing is written or emitted
+	if existingIncident.Status == req.Status {
+		return existingIncident, false, nil
 	}
 
-	// Validate status transition (optional business rule)
-	if err := s.validateStatusTransition(existingIncident.Status, req.Status); err != nil {
-		return nil, fmt.Errorf("invalid status transition: %w", err)
+	if errs := s.statusChangeErrors(existingIncident, req.Status, time.Now()); len(errs) > 0 {
+		return nil, false, errs[0]
 	}
 
-	updatedIncident, err := s.repo.UpdateStatus(ctx, existingIncident.ID.Hex(), req.Status)
+	note, err := s.transitionNote(existingIncident, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var updatedIncident *models.Incident
+	if note != nil {
+		updatedIncident, err = s.repo.UpdateStatusWithNote(ctx, existingIncident.ID.Hex(), req.Status, *note)
+	} else {
+		updatedIncident, err = s.repo.UpdateStatus(ctx, existingIncident.ID.Hex(), req.Status)
+	}
 	if err != nil {
 		log.Printf("Error updating incident status: %v", err)
-		return nil, fmt.Errorf("failed to update incident status: %w", err)
+		return nil, false, fmt.Errorf("failed to update incident status: %w", err)
 	}
 
 	if strings.Trim(req.AuthorEmail, " ") != "" {
 		_, err = s.AddWatcherToIncident(ctx, id, &models.Watcher{Email: req.AuthorEmail})
 		if err != nil {
 			log.Printf("Error adding watcher to incident: %v", err)
-			return nil, fmt.Errorf("status updated but failed to add watcher to incident: %w", err)
+			return nil, false, fmt.Errorf("status updated but failed to add watcher to incident: %w", err)
 		}
 	}
-	log.Printf("Updated incident status: ID=%s, Status=%s", id, req.Status)
+	config.Infof("Updated incident status: ID=%s, Status=%s", id, req.Status)
+	if note != nil {
+		s.recordFirstResponse(ctx, updatedIncident, time.Now())
+	}
 
-	s.producer.ProduceMessage(models.IncidentStatusUpdated{
+	s.publish(ctx, updatedIncident, models.IncidentStatusUpdated{
 		EventKey: primitive.NewObjectID().Hex(),
 		Id:       updatedIncident.ID.Hex(),
+		TenantID: updatedIncident.TenantID,
 		Title:    updatedIncident.Title,
 		Status:   string(updatedIncident.Status),
 	})
+	if note != nil {
+		for _, event := range noteAddedEvents(updatedIncident, *note, s.cfg.NoteTypeEvents) {
+			s.publish(ctx, updatedIncident, event)
+		}
+	}
+	s.hooks.run(updatedIncident, existingIncident.Status, updatedIncident.Status)
 
-	return updatedIncident, nil
+	return updatedIncident, true, nil
 }
 
 // UpdateIncidentSeverity updates the severity of an incident
-func (s *IncidentService) UpdateIncidentSeverity(ctx context.Context, id string, req *models.UpdateIncidentSeverityRequest) (*models.Incident, error) {
+func (s *IncidentService) UpdateIncidentSeverity(ctx context.Context, id string, req *models.UpdateIncidentSeverityRequest) (*models.Incident, bool, error) {
 	// Validate
 	if !req.Severity.IsValid() {
-		return nil, fmt.Errorf("invalid severity: %s", req.Severity)
+		return nil, false, InvalidSeverity(req.Severity)
 	}
 
 	// Check if incident exists first
-	_, err := s.repo.GetByID(ctx, id)
+	existingIncident, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("incident not found: %w", err)
+		return nil, false, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	// Setting the current severity again changes nothing, so nothing is written or emitted
+	if existingIncident.Severity == req.Severity {
+		return existingIncident, false, nil
+	}
+	if err := s.checkSeverityCooldown(existingIncident, time.Now()); err != nil {
+		return nil, false, err
 	}
 
 	updatedIncident, err := s.repo.UpdateSeverity(ctx, id, req.Severity)
 	if err != nil {
 		log.Printf("Error updating incident severity: %v", err)
-		return nil, fmt.Errorf("failed to update incident severity: %w", err)
+		return nil, false, fmt.Errorf("failed to update incident severity: %w", err)
 	}
 	if strings.Trim(req.AuthorEmail, " ") != "" {
 		_, err = s.AddWatcherToIncident(ctx, id, &models.Watcher{Email: req.AuthorEmail})
 		if err != nil {
 			log.Printf("Error adding watcher to incident: %v", err)
-			return nil, fmt.Errorf("updated incident severity but failed to add watcher to incident: %w", err)
+			return nil, false, fmt.Errorf("updated incident severity but failed to add watcher to incident: %w", err)
 		}
 	}
-	log.Printf("Updated incident severity: ID=%s, Severity=%s", id, req.Severity)
+	config.Infof("Updated incident severity: ID=%s, Severity=%s", id, req.Severity)
 
-	s.producer.ProduceMessage(models.IncidentSeverityUpdated{
-		EventKey: primitive.NewObjectID().Hex(),
-		Id:       updatedIncident.ID.Hex(),
-		Title:    updatedIncident.Title,
-		Severity: string(updatedIncident.Severity),
-	})
+	for _, event := range severityUpdatedEvents(existingIncident.Severity, updatedIncident) {
+		s.publish(ctx, updatedIncident, event)
+	}
 
-	return updatedIncident, nil
+	return updatedIncident, true, nil
+}
+
+// noteAddedEvents builds the events emitted for a new note: the generic note event,
+// followed by a dedicated event when the note's type is one of typedEvents
+func noteAddedEvents(incident *models.Incident, note models.Note, typedEvents []string) []kafka.KafkaEvent {
+	events := []kafka.KafkaEvent{
+		models.IncidentNoteAdded{
+			EventKey: primitive.NewObjectID().Hex(),
+			Id:       incident.ID.Hex(),
+			TenantID: incident.TenantID,
+			Title:    incident.Title,
+			Content:  note.Content,
+		},
+	}
+
+	for _, noteType := range typedEvents {
+		if note.Type != "" && strings.EqualFold(noteType, string(note.Type)) {
+			events = append(events, models.IncidentTypedNoteAdded{
+				EventKey: primitive.NewObjectID().Hex(),
+				Id:       incident.ID.Hex(),
+				TenantID: incident.TenantID,
+				Title:    incident.Title,
+				Content:  note.Content,
+				NoteType: string(note.Type),
+			})
+			break
+		}
+	}
+	return events
+}
+
+// severityUpdatedEvents builds the events emitted for a severity change.
+// The generic severity event is always emitted; consumers that only care
+// about escalations additionally receive a dedicated event when an incident
+// becomes critical.
+func severityUpdatedEvents(previous models.IncidentSeverity, updated *models.Incident) []kafka.KafkaEvent {
+	events := []kafka.KafkaEvent{
+		models.IncidentSeverityUpdated{
+			EventKey: primitive.NewObjectID().Hex(),
+			Id:       updated.ID.Hex(),
+			TenantID: updated.TenantID,
+			Title:    updated.Title,
+			Severity: string(updated.Severity),
+		},
+	}
+
+	if updated.Severity == models.Critical && previous != models.Critical {
+		events = append(events, models.IncidentEscalatedToCritical{
+			EventKey:         primitive.NewObjectID().Hex(),
+			Id:               updated.ID.Hex(),
+			TenantID:         updated.TenantID,
+			Title:            updated.Title,
+			Severity:         string(updated.Severity),
+			PreviousSeverity: string(previous),
+		})
+	}
+
+	return events
 }
 
 // AddNoteToIncident adds a note to an incident
 func (s *IncidentService) AddNoteToIncident(ctx context.Context, incidentID string, req *models.AddNoteRequest) (*models.Incident, error) {
+	if err := s.validateNote(req); err != nil {
+		return nil, err
+	}
+
 	// Check if incident exists first
 	existingIncident, err := s.repo.GetByID(ctx, incidentID)
 	if err != nil {
-		return nil, fmt.Errorf("incident not found: %w", err)
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	if err := checkNoteLimit(len(existingIncident.Notes), s.cfg.MaxNotes); err != nil {
+		return nil, err
 	}
 
 	note := models.Note{
 		Content:     req.Content,
 		AuthorEmail: req.AuthorEmail,
 		Type:        req.Type,
+		Visibility:  s.noteVisibility(req.Visibility),
 	}
 
 	updatedIncident, err := s.repo.AddNote(ctx, existingIncident.ID.Hex(), note)
@@ -229,20 +971,206 @@ func (s *IncidentService) AddNoteToIncident(ctx context.Context, incidentID stri
 		return nil, fmt.Errorf("failed to add note to incident: %w", err)
 	}
 
-	log.Printf("Added note to incident: ID=%s, Author=%s", incidentID, req.AuthorEmail)
+	config.Infof("Added note to incident: ID=%s, Author=%s", incidentID, req.AuthorEmail)
+	s.recordFirstResponse(ctx, updatedIncident, time.Now())
 
-	s.producer.ProduceMessage(models.IncidentNoteAdded{
-		EventKey: primitive.NewObjectID().Hex(),
-		Id:       updatedIncident.ID.Hex(),
-		Title:    updatedIncident.Title,
-		Content:  note.Content,
-	})
+	for _, event := range noteAddedEvents(updatedIncident, note, s.cfg.NoteTypeEvents) {
+		s.publish(ctx, updatedIncident, event)
+	}
+
+	return updatedIncident, nil
+}
+
+// validateNote checks a new note's type, visibility, template and author
+func (s *IncidentService) validateNote(req *models.AddNoteRequest) error {
+	if req.Type != "" && !req.Type.IsValid() {
+		return InvalidNoteType(req.Type)
+	}
+
+	if req.Visibility != "" && !req.Visibility.IsValid() {
+		return InvalidNoteVisibility(req.Visibility)
+	}
+
+	if err := s.checkNoteTemplate(req.Type, req.Content); err != nil {
+		return err
+	}
+
+	return s.validateNoteAuthor(req.AuthorEmail)
+}
+
+// noteVisibility returns the visibility requested for a note, or the configured default
+func (s *IncidentService) noteVisibility(requested models.NoteVisibility) models.NoteVisibility {
+	if requested.IsValid() {
+		return requested
+	}
+	if visibility := models.NoteVisibility(s.cfg.DefaultNoteVisibility); visibility.IsValid() {
+		return visibility
+	}
+	return models.NoteInternal
+}
+
+// ListNotes returns the incident's notes, limited to one visibility when given
+func (s *IncidentService) ListNotes(ctx context.Context, incidentID string, visibility models.NoteVisibility) ([]models.Note, error) {
+	if visibility != "" && !visibility.IsValid() {
+		return nil, InvalidNoteVisibility(visibility)
+	}
+
+	incident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	return models.NotesWithVisibility(incident.Notes, visibility), nil
+}
+
+// validateNoteAuthor enforces a valid author email when notes require one
+func (s *IncidentService) validateNoteAuthor(email string) error {
+	if !s.cfg.RequireNoteAuthor {
+		return nil
+	}
+	if strings.TrimSpace(email) == "" {
+		return ErrNoteAuthorRequired
+	}
+	if err := s.validateEmail(email); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidNoteAuthor, err)
+	}
+	return nil
+}
+
+// ErrNoteNotFound is returned when an incident has no note with the given ID
+var ErrNoteNotFound = errors.New("note not found")
+
+// EditNote replaces a note's content, keeping the previous content in its edit history
+func (s *IncidentService) EditNote(ctx context.Context, incidentID, noteID string, req *models.EditNoteRequest) (*models.Incident, error) {
+	if err := s.validateNoteAuthor(req.AuthorEmail); err != nil {
+		return nil, err
+	}
+
+	noteObjectID, err := primitive.ObjectIDFromHex(noteID)
+	if err != nil {
+		return nil, ErrNoteNotFound
+	}
+
+	existingIncident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	var current *models.Note
+	for i := range existingIncident.Notes {
+		if existingIncident.Notes[i].ID == noteObjectID {
+			current = &existingIncident.Notes[i]
+			break
+		}
+	}
+	if current == nil {
+		return nil, ErrNoteNotFound
+	}
+
+	if err := s.checkNoteTemplate(current.Type, req.Content); err != nil {
+		return nil, err
+	}
+
+	edited := current.Edited(req.Content, req.AuthorEmail, time.Now().UTC())
+	updatedIncident, err := s.repo.ReplaceNote(ctx, existingIncident.ID, current.Content, edited)
+	if err != nil {
+		log.Printf("Error editing note %s on incident %s: %v", noteID, incidentID, err)
+		return nil, fmt.Errorf("failed to edit note: %w", err)
+	}
+
+	config.Infof("Edited note on incident: ID=%s, Note=%s, Editor=%s", incidentID, noteID, req.AuthorEmail)
 
 	return updatedIncident, nil
 }
 
+// ErrEmptyNoteQuery is returned when a note search has no query text
+var ErrEmptyNoteQuery = errors.New("search query is required")
+
+// SearchNotes returns the notes of one incident matching the query text
+func (s *IncidentService) SearchNotes(ctx context.Context, incidentID, query string, visibility models.NoteVisibility) ([]models.Note, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptyNoteQuery
+	}
+	if visibility != "" && !visibility.IsValid() {
+		return nil, InvalidNoteVisibility(visibility)
+	}
+
+	notes, err := s.repo.SearchNotes(ctx, incidentID, query)
+	if err != nil {
+		log.Printf("Error searching notes for incident %s: %v", incidentID, err)
+		return nil, fmt.Errorf("failed to search notes: %w", err)
+	}
+	return models.NotesWithVisibility(notes, visibility), nil
+}
+
+// descriptionOrTemplate seeds a blank description from the template, if one is configured
+func descriptionOrTemplate(description, template string) string {
+	if strings.TrimSpace(description) == "" && template != "" {
+		return template
+	}
+	return description
+}
+
+// applyTitleLimit enforces the maximum title length in characters (0 is unlimited),
+// either truncating the title or rejecting it
+func applyTitleLimit(title string, maxLength int, truncate bool) (string, bool, error) {
+	length := utf8.RuneCountInString(title)
+	if maxLength <= 0 || length <= maxLength {
+		return title, false, nil
+	}
+	if !truncate {
+		return "", false, fmt.Errorf("%w: %d characters, maximum is %d", ErrTitleTooLong, length, maxLength)
+	}
+	return string([]rune(title)[:maxLength]), true, nil
+}
+
+// DescriptionTooShortError is returned when an incident's description is shorter
+// than MIN_DESCRIPTION_LENGTHS requires for its severity
+type DescriptionTooShortError struct {
+	Severity models.IncidentSeverity
+	Length   int
+	Minimum  int
+}
+
+func (e *DescriptionTooShortError) Error() string {
+	return fmt.Sprintf("%s incidents need a description of at least %d characters, got %d", e.Severity, e.Minimum, e.Length)
+}
+
+// checkDescriptionLength enforces the minimum description length configured for
+// the severity, counting characters after trimming surrounding whitespace. The
+// description template does not count towards it.
+func checkDescriptionLength(description string, severity models.IncidentSeverity, minimums map[string]int) error {
+	minimum := minimums[string(severity)]
+	length := utf8.RuneCountInString(strings.TrimSpace(description))
+	if length < minimum {
+		return &DescriptionTooShortError{Severity: severity, Length: length, Minimum: minimum}
+	}
+	return nil
+}
+
+// checkNoteLimit rejects adding a note when the incident already has the maximum (0 is unlimited)
+func checkNoteLimit(existingNotes, maxNotes int) error {
+	if maxNotes > 0 && existingNotes >= maxNotes {
+		return fmt.Errorf("%w (%d)", ErrNoteLimitReached, maxNotes)
+	}
+	return nil
+}
+
+// TooSoonError is returned when an incident has not stayed in its status long
+// enough to make the requested transition
+type TooSoonError struct {
+	From      models.IncidentStatus
+	To        models.IncidentStatus
+	Remaining time.Duration
+}
+
+func (e *TooSoonError) Error() string {
+	return fmt.Sprintf("incident must stay %s for another %s before moving to %s", e.From, e.Remaining, e.To)
+}
+
 // validateStatusTransition validates if a status transition is allowed
-func (s *IncidentService) validateStatusTransition(currentStatus, newStatus models.IncidentStatus) error {
+func (s *IncidentService) validateStatusTransition(incident *models.Incident, newStatus models.IncidentStatus, now time.Time) error {
+	currentStatus := incident.Status
 	// Define allowed transitions (this is business logic that can be customized)
 	allowedTransitions := map[models.IncidentStatus][]models.IncidentStatus{
 		models.Open: {
@@ -269,7 +1197,7 @@ func (s *IncidentService) validateStatusTransition(currentStatus, newStatus mode
 	if allowedStatuses, exists := allowedTransitions[currentStatus]; exists {
 		for _, allowed := range allowedStatuses {
 			if allowed == newStatus {
-				return nil // Transition is allowed
+				return s.checkTimeInStatus(incident, newStatus, now)
 			}
 		}
 	}
@@ -277,6 +1205,66 @@ func (s *IncidentService) validateStatusTransition(currentStatus, newStatus mode
 	return fmt.Errorf("cannot transition from %s to %s", currentStatus, newStatus)
 }
 
+// checkAssignee requires an assignee before an incident is resolved or closed,
+// when the rule is enabled
+func (s *IncidentService) checkAssignee(incident *models.Incident, newStatus models.IncidentStatus) error {
+	if !s.cfg.RequireAssigneeToResolve || (newStatus != models.Resolved && newStatus != models.Closed) {
+		return nil
+	}
+	if strings.TrimSpace(incident.Assignee) == "" {
+		return ErrAssigneeRequired
+	}
+	return nil
+}
+
+// checkTimeInStatus keeps incidents in_progress for the configured minimum before
+// they are resolved, to stop flapping. Open incidents cannot be resolved directly,
+// so moving back to open does not skip the wait. Incidents without a recorded
+// status change time are not held back.
+func (s *IncidentService) checkTimeInStatus(incident *models.Incident, newStatus models.IncidentStatus, now time.Time) error {
+	if s.cfg.MinTimeInProgress <= 0 || newStatus != models.Resolved {
+		return nil
+	}
+	if incident.Status == models.Open {
+		return ErrInProgressRequired
+	}
+	if incident.Status != models.InProgress || incident.StatusChangedAt == nil {
+		return nil
+	}
+
+	if elapsed := now.Sub(*incident.StatusChangedAt); elapsed < s.cfg.MinTimeInProgress {
+		return &TooSoonError{
+			From:      incident.Status,
+			To:        newStatus,
+			Remaining: (s.cfg.MinTimeInProgress - elapsed).Round(time.Second),
+		}
+	}
+	return nil
+}
+
+// SeverityCooldownError is returned when an incident's severity changed too
+// recently to change again
+type SeverityCooldownError struct {
+	Remaining time.Duration
+}
+
+func (e *SeverityCooldownError) Error() string {
+	return fmt.Sprintf("severity was changed recently, try again in %s", e.Remaining)
+}
+
+// checkSeverityCooldown allows one severity change per configured cooldown to stop
+// flapping. Incidents whose severity never changed are not held back.
+func (s *IncidentService) checkSeverityCooldown(incident *models.Incident, now time.Time) error {
+	if s.cfg.SeverityChangeCooldown <= 0 || incident.SeverityChangedAt == nil {
+		return nil
+	}
+
+	if elapsed := now.Sub(*incident.SeverityChangedAt); elapsed < s.cfg.SeverityChangeCooldown {
+		return &SeverityCooldownError{Remaining: (s.cfg.SeverityChangeCooldown - elapsed).Round(time.Second)}
+	}
+	return nil
+}
+
 // validateEmail validates the format of an email address Using external
 func (s *IncidentService) validateEmail(email string) error {
 	// Format validation only
@@ -297,7 +1285,7 @@ func (s *IncidentService) AddWatcherToIncident(ctx context.Context, incidentID s
 	// Check if incident exists first
 	_, err := s.repo.GetByID(ctx, incidentID)
 	if err != nil {
-		return nil, fmt.Errorf("incident not found: %w", err)
+		return nil, fmt.Errorf("failed to get incident: %w", err)
 	}
 
 	// Block Explanation
@@ -314,7 +1302,7 @@ func (s *IncidentService) AddWatcherToIncident(ctx context.Context, incidentID s
 		return nil, fmt.Errorf("failed to add watcher to incident: %w", err)
 	}
 
-	log.Printf("Added watcher to incident: ID=%s, Email=%s", incidentID, watcher.Email)
+	config.Infof("Added watcher to incident: ID=%s, Email=%s", incidentID, watcher.Email)
 
 	return updatedIncident, nil
 }
@@ -2,13 +2,24 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
 	"makers.anchor/incident/internal/kafka"
+	"makers.anchor/incident/internal/metrics"
 	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
 )
 
 // MockKafkaProducer for testing
@@ -195,3 +206,1003 @@ func TestIncidentService_CreateIncident_Success(t *testing.T) {
 		t.Errorf("Expected note content %s, got %s", req.Notes[0].Content, result.Notes[0].Content)
 	}
 }
+
+func TestSeverityUpdatedEvents_EscalationToCritical(t *testing.T) {
+	// Arrange
+	updated := &models.Incident{
+		ID:       primitive.NewObjectID(),
+		Title:    "Payment API latency",
+		Severity: models.Critical,
+	}
+
+	// Act
+	events := severityUpdatedEvents(models.Medium, updated)
+
+	// Assert
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if _, ok := events[0].(models.IncidentSeverityUpdated); !ok {
+		t.Errorf("Expected first event to be IncidentSeverityUpdated, got %T", events[0])
+	}
+
+	escalated, ok := events[1].(models.IncidentEscalatedToCritical)
+	if !ok {
+		t.Fatalf("Expected second event to be IncidentEscalatedToCritical, got %T", events[1])
+	}
+
+	if escalated.PreviousSeverity != string(models.Medium) {
+		t.Errorf("Expected previous severity %s, got %s", models.Medium, escalated.PreviousSeverity)
+	}
+
+	if escalated.Id != updated.ID.Hex() {
+		t.Errorf("Expected id %s, got %s", updated.ID.Hex(), escalated.Id)
+	}
+}
+
+func TestSeverityUpdatedEvents_NonCriticalChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous models.IncidentSeverity
+		updated  models.IncidentSeverity
+	}{
+		{name: "low to medium", previous: models.Low, updated: models.Medium},
+		{name: "already critical", previous: models.Critical, updated: models.Critical},
+		{name: "critical to high", previous: models.Critical, updated: models.High},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			events := severityUpdatedEvents(tt.previous, &models.Incident{Severity: tt.updated})
+
+			// Assert
+			if len(events) != 1 {
+				t.Fatalf("Expected 1 event, got %d", len(events))
+			}
+
+			if _, ok := events[0].(models.IncidentSeverityUpdated); !ok {
+				t.Errorf("Expected IncidentSeverityUpdated, got %T", events[0])
+			}
+		})
+	}
+}
+
+func TestEventVisibleToTenant(t *testing.T) {
+	incident := &models.Incident{ID: primitive.NewObjectID(), TenantID: "team-b", Severity: models.High}
+	event := severityUpdatedEvents(models.Medium, incident)[0]
+
+	tests := []struct {
+		name     string
+		tenantID string
+		expected bool
+	}{
+		{name: "owning tenant", tenantID: "team-b", expected: true},
+		{name: "another tenant", tenantID: "team-a"},
+		{name: "unscoped subscriber", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			visible := EventVisibleToTenant(event, tt.tenantID)
+
+			// Assert
+			if visible != tt.expected {
+				t.Errorf("Expected visible %t, got %t", tt.expected, visible)
+			}
+		})
+	}
+}
+
+func TestFindDuplicateIncident(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	window := 15 * time.Minute
+
+	tests := []struct {
+		name       string
+		candidates []models.Incident
+		title      string
+		expected   int
+	}{
+		{
+			name:       "same title within window is a duplicate",
+			candidates: []models.Incident{{IncidentKey: 7, Title: "API down", CreatedAt: now.Add(-5 * time.Minute)}},
+			title:      "API down",
+			expected:   7,
+		},
+		{
+			name:       "same title outside window is allowed",
+			candidates: []models.Incident{{IncidentKey: 7, Title: "API down", CreatedAt: now.Add(-time.Hour)}},
+			title:      "API down",
+			expected:   0,
+		},
+		{
+			name:       "different title within window is allowed",
+			candidates: []models.Incident{{IncidentKey: 7, Title: "API down", CreatedAt: now.Add(-time.Minute)}},
+			title:      "Queue backlog",
+			expected:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			duplicate := findDuplicateIncident(tt.candidates, tt.title, window, now)
+
+			// Assert
+			if tt.expected == 0 {
+				if duplicate != nil {
+					t.Errorf("Expected no duplicate, got incident %d", duplicate.IncidentKey)
+				}
+				return
+			}
+			if duplicate == nil {
+				t.Fatalf("Expected duplicate incident %d, got nil", tt.expected)
+			}
+			if duplicate.IncidentKey != tt.expected {
+				t.Errorf("Expected duplicate incident %d, got %d", tt.expected, duplicate.IncidentKey)
+			}
+		})
+	}
+}
+
+func TestRoundRobinAssignee_CyclesInOrder(t *testing.T) {
+	// Arrange
+	assignees := []string{"alice@example.com", "bob@example.com", "carol@example.com"}
+	expected := []string{
+		"alice@example.com",
+		"bob@example.com",
+		"carol@example.com",
+		"alice@example.com",
+		"bob@example.com",
+	}
+
+	for i, want := range expected {
+		// Act: counter sequences start at 1
+		got := roundRobinAssignee(assignees, i+1)
+
+		// Assert
+		if got != want {
+			t.Errorf("Expected assignment %d to be %s, got %s", i+1, want, got)
+		}
+	}
+}
+
+func TestCheckNoteLimit_Boundary(t *testing.T) {
+	tests := []struct {
+		name          string
+		existingNotes int
+		maxNotes      int
+		expectErr     bool
+	}{
+		{name: "unlimited", existingNotes: 500, maxNotes: 0, expectErr: false},
+		{name: "one below the limit", existingNotes: 9, maxNotes: 10, expectErr: false},
+		{name: "at the limit", existingNotes: 10, maxNotes: 10, expectErr: true},
+		{name: "above the limit", existingNotes: 11, maxNotes: 10, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := checkNoteLimit(tt.existingNotes, tt.maxNotes)
+
+			// Assert
+			if tt.expectErr && !errors.Is(err, ErrNoteLimitReached) {
+				t.Errorf("Expected ErrNoteLimitReached, got %v", err)
+			}
+			if !tt.expectErr && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckCreateConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *models.CreateIncidentRequest
+		enabled      []string
+		expectFields []string
+	}{
+		{
+			name:         "resolution note at creation",
+			req:          &models.CreateIncidentRequest{Notes: []models.Note{{Content: "Fixed", Type: models.Resolution}}},
+			enabled:      []string{"resolution_note"},
+			expectFields: []string{"notes.type"},
+		},
+		{
+			name:    "investigation note at creation",
+			req:     &models.CreateIncidentRequest{Notes: []models.Note{{Content: "Looking", Type: models.Investigation}}},
+			enabled: []string{"resolution_note"},
+		},
+		{
+			name:         "assignee is the author",
+			req:          &models.CreateIncidentRequest{Assignee: "Alice@example.com", AuthorEmail: "alice@example.com"},
+			enabled:      []string{"assignee_is_watcher"},
+			expectFields: []string{"assignee", "author_email"},
+		},
+		{
+			name:    "rule disabled",
+			req:     &models.CreateIncidentRequest{Notes: []models.Note{{Content: "Fixed", Type: models.Resolution}}},
+			enabled: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := checkCreateConflicts(tt.req, tt.enabled)
+
+			// Assert
+			if tt.expectFields == nil {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var conflictErr *FieldConflictError
+			if !errors.As(err, &conflictErr) {
+				t.Fatalf("Expected FieldConflictError, got %v", err)
+			}
+			if strings.Join(conflictErr.Fields, ",") != strings.Join(tt.expectFields, ",") {
+				t.Errorf("Expected fields %v, got %v", tt.expectFields, conflictErr.Fields)
+			}
+		})
+	}
+}
+
+func TestShouldEmitEvent(t *testing.T) {
+	tests := []struct {
+		name        string
+		severity    models.IncidentSeverity
+		minSeverity models.IncidentSeverity
+		expected    bool
+	}{
+		{name: "low below high threshold", severity: models.Low, minSeverity: models.High, expected: false},
+		{name: "high at high threshold", severity: models.High, minSeverity: models.High, expected: true},
+		{name: "critical above high threshold", severity: models.Critical, minSeverity: models.High, expected: true},
+		{name: "no threshold", severity: models.Low, minSeverity: "", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			emit := shouldEmitEvent(tt.severity, tt.minSeverity)
+
+			// Assert
+			if emit != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, emit)
+			}
+		})
+	}
+}
+
+func TestValidateNoteAuthor(t *testing.T) {
+	tests := []struct {
+		name        string
+		required    bool
+		email       string
+		expectedErr error
+	}{
+		{name: "optional without author", required: false, email: "", expectedErr: nil},
+		{name: "required without author", required: true, email: " ", expectedErr: ErrNoteAuthorRequired},
+		{name: "required with invalid author", required: true, email: "not-an-email", expectedErr: ErrInvalidNoteAuthor},
+		{name: "required with valid author", required: true, email: "alice@example.com", expectedErr: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := &IncidentService{cfg: &config.Config{RequireNoteAuthor: tt.required}}
+
+			// Act
+			err := service.validateNoteAuthor(tt.email)
+
+			// Assert
+			if tt.expectedErr == nil && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestAsSystemActor_RecordsSystemActor(t *testing.T) {
+	// Arrange
+	actor := "system@incident.local"
+	webhookReq := &models.CreateIncidentRequest{
+		Title:    "Jira: payments degraded",
+		Severity: models.High,
+		Notes: []models.Note{
+			{Content: "Imported from Jira"},
+			{Content: "Reporter comment", AuthorEmail: "alice@example.com"},
+		},
+	}
+
+	// Act
+	req := asSystemActor(webhookReq, actor)
+
+	// Assert
+	if req.AuthorEmail != actor {
+		t.Errorf("Expected author %s, got %s", actor, req.AuthorEmail)
+	}
+	if req.Notes[0].AuthorEmail != actor {
+		t.Errorf("Expected unauthored note to record %s, got %s", actor, req.Notes[0].AuthorEmail)
+	}
+	if req.Notes[1].AuthorEmail != "alice@example.com" {
+		t.Errorf("Expected authored note to keep its author, got %s", req.Notes[1].AuthorEmail)
+	}
+	if webhookReq.AuthorEmail != "" || webhookReq.Notes[0].AuthorEmail != "" {
+		t.Error("Expected the original request to be left unchanged")
+	}
+}
+
+func TestNeedsAttention_ThresholdBoundary(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+	threshold := 4 * time.Hour
+
+	tests := []struct {
+		name      string
+		status    models.IncidentStatus
+		idle      time.Duration
+		threshold time.Duration
+		expected  bool
+	}{
+		{name: "just under threshold", status: models.Open, idle: threshold - time.Second, threshold: threshold, expected: false},
+		{name: "exactly at threshold", status: models.Open, idle: threshold, threshold: threshold, expected: false},
+		{name: "just over threshold", status: models.InProgress, idle: threshold + time.Second, threshold: threshold, expected: true},
+		{name: "resolved incidents never need attention", status: models.Resolved, idle: 48 * time.Hour, threshold: threshold, expected: false},
+		{name: "disabled threshold", status: models.Open, idle: 48 * time.Hour, threshold: 0, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			flagged := needsAttention(tt.status, now.Add(-tt.idle), now, tt.threshold)
+
+			// Assert
+			if flagged != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, flagged)
+			}
+		})
+	}
+}
+
+func TestDraftIncident_EmitsNoEventsUntilPublished(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("created draft is silent, publishing announces it", func(mt *mtest.T) {
+		// Arrange
+		bus := eventbus.New(eventbus.DefaultBufferSize)
+		sub := bus.Subscribe(models.EVENT_TOPIC)
+		defer bus.Unsubscribe(sub)
+		// A critical minimum keeps the low severity events off Kafka, so no producer is needed
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, bus, webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "incident_key", Value: 6}}),
+			mtest.CreateSuccessResponse(),
+		)
+
+		// Act
+		draft, err := service.CreateIncident(context.Background(), &models.CreateIncidentRequest{
+			Title:    "Planned failover",
+			Severity: models.Low,
+			Draft:    true,
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !draft.Draft {
+			t.Fatal("Expected the incident to be a draft")
+		}
+		select {
+		case event := <-sub.Events():
+			t.Fatalf("Expected no event for a draft, got %s", event.GetEventType())
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		// Arrange
+		stored := bson.D{
+			{Key: "_id", Value: draft.ID},
+			{Key: "incident_key", Value: 7},
+			{Key: "title", Value: "Planned failover"},
+			{Key: "severity", Value: "low"},
+			{Key: "status", Value: "open"},
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, append(stored, bson.E{Key: "draft", Value: true})),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: stored}),
+		)
+
+		// Act
+		published, err := service.PublishIncident(context.Background(), draft.ID.Hex())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if published.Draft {
+			t.Error("Expected the published incident not to be a draft")
+		}
+		select {
+		case event := <-sub.Events():
+			if event.GetEventType() != "incident.created" {
+				t.Errorf("Expected incident.created, got %s", event.GetEventType())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected IncidentCreated once the draft is published")
+		}
+	})
+}
+
+func TestCheckDescriptionLength(t *testing.T) {
+	minimums := map[string]int{"critical": 50}
+
+	tests := []struct {
+		name        string
+		description string
+		severity    models.IncidentSeverity
+		expectErr   bool
+	}{
+		{name: "short critical description is rejected", description: "DB down", severity: models.Critical, expectErr: true},
+		{name: "padding does not count", description: "DB down" + strings.Repeat(" ", 60), severity: models.Critical, expectErr: true},
+		{name: "adequate critical description is accepted", description: strings.Repeat("x", 50), severity: models.Critical},
+		{name: "lower severities are unaffected", description: "", severity: models.High},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := checkDescriptionLength(tt.description, tt.severity, minimums)
+
+			// Assert
+			var shortErr *DescriptionTooShortError
+			if tt.expectErr {
+				if !errors.As(err, &shortErr) || shortErr.Minimum != 50 {
+					t.Fatalf("Expected DescriptionTooShortError with minimum 50, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestApplyTitleLimit(t *testing.T) {
+	longTitle := strings.Repeat("a", 300)
+
+	tests := []struct {
+		name            string
+		maxLength       int
+		truncate        bool
+		expectTitle     string
+		expectTruncated bool
+		expectErr       error
+	}{
+		{name: "default limit rejects", maxLength: 255, expectErr: ErrTitleTooLong},
+		{name: "higher limit accepts", maxLength: 500, expectTitle: longTitle},
+		{name: "truncates to the limit", maxLength: 255, truncate: true, expectTitle: strings.Repeat("a", 255), expectTruncated: true},
+		{name: "zero is unlimited", maxLength: 0, expectTitle: longTitle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			title, truncated, err := applyTitleLimit(longTitle, tt.maxLength, tt.truncate)
+
+			// Assert
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			if title != tt.expectTitle {
+				t.Errorf("Expected title of %d characters, got %d", len(tt.expectTitle), len(title))
+			}
+			if truncated != tt.expectTruncated {
+				t.Errorf("Expected truncated %t, got %t", tt.expectTruncated, truncated)
+			}
+		})
+	}
+}
+
+func TestApplyTitleLimit_CountsCharacters(t *testing.T) {
+	// Act
+	title, truncated, err := applyTitleLimit("Débit échoué", 5, true)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if title != "Débit" || !truncated {
+		t.Errorf("Expected Débit truncated, got %q (truncated %t)", title, truncated)
+	}
+}
+
+func TestValidateStatusTransition_MinTimeInProgress(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	startedAt := func(ago time.Duration) *time.Time {
+		at := now.Add(-ago)
+		return &at
+	}
+
+	tests := []struct {
+		name            string
+		statusChangedAt *time.Time
+		newStatus       models.IncidentStatus
+		expectRemaining time.Duration
+	}{
+		{name: "too soon to resolve", statusChangedAt: startedAt(10 * time.Minute), newStatus: models.Resolved, expectRemaining: 5 * time.Minute},
+		{name: "allowed after the threshold", statusChangedAt: startedAt(15 * time.Minute), newStatus: models.Resolved},
+		{name: "other transitions are not held back", statusChangedAt: startedAt(time.Minute), newStatus: models.Closed},
+		{name: "older incidents without a change time are allowed", newStatus: models.Resolved},
+	}
+
+	t.Run("open incidents cannot skip in_progress", func(t *testing.T) {
+		// Arrange
+		service := NewIncidentService(nil, nil, nil, nil, &config.Config{MinTimeInProgress: 15 * time.Minute})
+		incident := &models.Incident{Status: models.Open, StatusChangedAt: startedAt(time.Hour)}
+
+		// Act
+		err := service.validateStatusTransition(incident, models.Resolved, now)
+
+		// Assert
+		if !errors.Is(err, ErrInProgressRequired) {
+			t.Fatalf("Expected ErrInProgressRequired, got %v", err)
+		}
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := NewIncidentService(nil, nil, nil, nil, &config.Config{MinTimeInProgress: 15 * time.Minute})
+			incident := &models.Incident{Status: models.InProgress, StatusChangedAt: tt.statusChangedAt}
+
+			// Act
+			err := service.validateStatusTransition(incident, tt.newStatus, now)
+
+			// Assert
+			if tt.expectRemaining == 0 {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			var tooSoonErr *TooSoonError
+			if !errors.As(err, &tooSoonErr) {
+				t.Fatalf("Expected TooSoonError, got %v", err)
+			}
+			if tooSoonErr.Remaining != tt.expectRemaining {
+				t.Errorf("Expected %s remaining, got %s", tt.expectRemaining, tooSoonErr.Remaining)
+			}
+		})
+	}
+}
+
+func TestDescriptionOrTemplate(t *testing.T) {
+	template := "## Impact\n\n## Mitigation\n"
+
+	tests := []struct {
+		name        string
+		description string
+		template    string
+		expected    string
+	}{
+		{name: "empty description uses the template", description: "", template: template, expected: template},
+		{name: "blank description uses the template", description: "  \n", template: template, expected: template},
+		{name: "provided description is kept", description: "Checkout errors in eu-west", template: template, expected: "Checkout errors in eu-west"},
+		{name: "disabled without a template", description: "", template: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			description := descriptionOrTemplate(tt.description, tt.template)
+
+			// Assert
+			if description != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, description)
+			}
+		})
+	}
+}
+
+func TestGetIncidentLoad_WeightsOpenIncidentsBySeverity(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("mix of open severities", func(mt *mtest.T) {
+		// Arrange
+		cfg := &config.Config{SeverityWeights: map[string]int{"low": 1, "medium": 2, "high": 5, "critical": 10}}
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, nil, nil, cfg)
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch,
+			bson.D{{Key: "_id", Value: "critical"}, {Key: "count", Value: 2}},
+			bson.D{{Key: "_id", Value: "high"}, {Key: "count", Value: 1}},
+			bson.D{{Key: "_id", Value: "low"}, {Key: "count", Value: 4}},
+			bson.D{{Key: "_id", Value: "sev0"}, {Key: "count", Value: 3}},
+		))
+
+		// Act
+		load, err := service.GetIncidentLoad(context.Background())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if load.Load != 2*10+1*5+4*1 {
+			t.Errorf("Expected load 29, got %d", load.Load)
+		}
+		if load.OpenCount != 10 {
+			t.Errorf("Expected 10 open incidents, got %d", load.OpenCount)
+		}
+		if load.BySeverity[models.Medium] != 0 || load.BySeverity[models.Critical] != 2 {
+			t.Errorf("Expected counts for every severity, got %v", load.BySeverity)
+		}
+	})
+}
+
+func TestNoteAddedEvents_DedicatedEventPerConfiguredType(t *testing.T) {
+	incident := &models.Incident{ID: primitive.NewObjectID(), Title: "Payment API latency"}
+
+	tests := []struct {
+		name     string
+		noteType models.NoteType
+		expected []string
+	}{
+		{name: "resolution note", noteType: models.Resolution, expected: []string{"incident.notes.added", "incident.notes.resolution.added"}},
+		{name: "update note", noteType: models.Update, expected: []string{"incident.notes.added"}},
+		{name: "untyped note", noteType: "", expected: []string{"incident.notes.added"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			events := noteAddedEvents(incident, models.Note{Content: "Rolled back", Type: tt.noteType}, []string{"resolution"})
+
+			// Assert
+			if len(events) != len(tt.expected) {
+				t.Fatalf("Expected %d events, got %d", len(tt.expected), len(events))
+			}
+			for i, eventType := range tt.expected {
+				if events[i].GetEventType() != eventType {
+					t.Errorf("Expected event %d to be %s, got %s", i, eventType, events[i].GetEventType())
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateIncidentStatus_SameStatusIsNoOp(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name          string
+		status        models.IncidentStatus
+		expectChanged bool
+	}{
+		{name: "same status", status: models.InProgress, expectChanged: false},
+		{name: "real change", status: models.Resolved, expectChanged: true},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			bus := eventbus.New(eventbus.DefaultBufferSize)
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, bus, webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+			sub := service.SubscribeToEvents()
+			defer service.UnsubscribeFromEvents(sub)
+
+			id := primitive.NewObjectID()
+			incident := func(status models.IncidentStatus) bson.D {
+				return bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: string(status)}}
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(models.InProgress)),
+				mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident(tt.status)}),
+			)
+
+			// Act
+			updated, changed, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{Status: tt.status})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if changed != tt.expectChanged || updated.Status != tt.status {
+				t.Errorf("Expected changed=%t with status %s, got %t with %s", tt.expectChanged, tt.status, changed, updated.Status)
+			}
+			select {
+			case event := <-sub.Events():
+				if !tt.expectChanged {
+					t.Errorf("Expected no event, got %s", event.GetEventType())
+				}
+			default:
+				if tt.expectChanged {
+					t.Error("Expected a status event")
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateIncidentStatus_RequireAssigneeToResolve(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name          string
+		requireRule   bool
+		assignee      string
+		expectBlocked bool
+	}{
+		{name: "rule on blocks unassigned", requireRule: true, expectBlocked: true},
+		{name: "rule on allows assigned", requireRule: true, assignee: "oncall@example.com"},
+		{name: "rule off allows unassigned", requireRule: false},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			cfg := &config.Config{MinEventSeverity: "critical", RequireAssigneeToResolve: tt.requireRule}
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), cfg)
+
+			id := primitive.NewObjectID()
+			incident := func(status models.IncidentStatus) bson.D {
+				return bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: string(status)}, {Key: "assignee", Value: tt.assignee}}
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(models.InProgress)),
+				mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident(models.Resolved)}),
+			)
+
+			// Act
+			updated, _, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{Status: models.Resolved})
+
+			// Assert
+			if tt.expectBlocked {
+				if !errors.Is(err, ErrAssigneeRequired) {
+					t.Fatalf("Expected ErrAssigneeRequired, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if updated.Status != models.Resolved {
+				t.Errorf("Expected status resolved, got %s", updated.Status)
+			}
+		})
+	}
+}
+
+func TestUpdateIncidentSeverity_Cooldown(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name          string
+		changedAgo    time.Duration
+		expectBlocked bool
+	}{
+		{name: "rapid second change is rejected", changedAgo: time.Minute, expectBlocked: true},
+		{name: "change after the cooldown is allowed", changedAgo: 6 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			cfg := &config.Config{MinEventSeverity: "critical", SeverityChangeCooldown: 5 * time.Minute}
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), cfg)
+
+			id := primitive.NewObjectID()
+			incident := func(severity models.IncidentSeverity, changedAt time.Time) bson.D {
+				return bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: string(severity)}, {Key: "status", Value: "open"}, {Key: "severity_changed_at", Value: changedAt}}
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(models.Medium, time.Now().Add(-tt.changedAgo))),
+				mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident(models.Low, time.Now())}),
+			)
+
+			// Act
+			updated, changed, err := service.UpdateIncidentSeverity(context.Background(), id.Hex(), &models.UpdateIncidentSeverityRequest{Severity: models.Low})
+
+			// Assert
+			if tt.expectBlocked {
+				var cooldownErr *SeverityCooldownError
+				if !errors.As(err, &cooldownErr) {
+					t.Fatalf("Expected SeverityCooldownError, got %v", err)
+				}
+				if cooldownErr.Remaining <= 3*time.Minute || cooldownErr.Remaining > 4*time.Minute {
+					t.Errorf("Expected about 4m remaining, got %s", cooldownErr.Remaining)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !changed || updated.Severity != models.Low {
+				t.Errorf("Expected severity changed to low, got %s (changed %t)", updated.Severity, changed)
+			}
+		})
+	}
+}
+
+func TestCreateIncident_IdempotencyKeyDeduplicatesRetries(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("retry returns the first incident", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		req := func() *models.CreateIncidentRequest {
+			return &models.CreateIncidentRequest{Title: "Payment API latency", Severity: models.Medium, IdempotencyKey: "retry-1"}
+		}
+		createdBefore := counterValue(t, metrics.IdempotentCreates)
+		duplicatesBefore := counterValue(t, metrics.DuplicateRequests)
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateSuccessResponse(),
+		)
+		first, err := service.CreateIncident(context.Background(), req())
+		if err != nil {
+			t.Fatalf("Expected no error on first request, got %v", err)
+		}
+		mt.ClearEvents()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: first.ID},
+			{Key: "incident_key", Value: first.IncidentKey},
+			{Key: "idempotency_key", Value: "retry-1"},
+		}))
+
+		// Act
+		retried, err := service.CreateIncident(context.Background(), req())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error on retry, got %v", err)
+		}
+		if !retried.IdempotentReplay || retried.ID != first.ID {
+			t.Errorf("Expected the first incident %s replayed, got %s (replay=%t)", first.ID.Hex(), retried.ID.Hex(), retried.IdempotentReplay)
+		}
+		if commands := mt.GetAllStartedEvents(); len(commands) != 1 || commands[0].CommandName != "find" {
+			t.Errorf("Expected only the idempotency lookup on retry, got %d commands", len(commands))
+		}
+		if got := counterValue(t, metrics.IdempotentCreates) - createdBefore; got != 1 {
+			t.Errorf("Expected 1 idempotent create, got %v", got)
+		}
+		if got := counterValue(t, metrics.DuplicateRequests) - duplicatesBefore; got != 1 {
+			t.Errorf("Expected 1 duplicate request, got %v", got)
+		}
+	})
+
+	mt.Run("retry losing the insert race replays the winner", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		winner := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateWriteErrorsResponse(mtest.WriteError{Code: 11000, Message: "E11000 duplicate key error collection: db.incidents index: incident_idempotency_key"}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: winner},
+				{Key: "incident_key", Value: 7},
+				{Key: "idempotency_key", Value: "retry-2"},
+			}),
+		)
+
+		// Act
+		incident, err := service.CreateIncident(context.Background(), &models.CreateIncidentRequest{Title: "Payment API latency", Severity: models.Medium, IdempotencyKey: "retry-2"})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected the winning incident to be replayed, got %v", err)
+		}
+		if !incident.IdempotentReplay || incident.ID != winner {
+			t.Errorf("Expected incident %s replayed, got %s (replay=%t)", winner.Hex(), incident.ID.Hex(), incident.IdempotentReplay)
+		}
+	})
+}
+
+// counterValue returns the current value of a Prometheus counter
+func counterValue(t *testing.T, counter prometheus.Counter) float64 {
+	t.Helper()
+
+	var metric dto.Metric
+	if err := counter.Write(&metric); err != nil {
+		t.Fatalf("Expected no error reading counter, got %v", err)
+	}
+	return metric.GetCounter().GetValue()
+}
+
+func TestListPage_DefaultsAndCap(t *testing.T) {
+	tests := []struct {
+		name        string
+		page        models.PageParams
+		expected    models.PageParams
+		expectError bool
+	}{
+		{name: "defaults to the first page of 20", expected: models.PageParams{Page: 1, PageSize: 20}},
+		{name: "keeps a requested page", page: models.PageParams{Page: 3, PageSize: 50}, expected: models.PageParams{Page: 3, PageSize: 50}},
+		{name: "caps the limit at 100", page: models.PageParams{Page: 1, PageSize: 500}, expected: models.PageParams{Page: 1, PageSize: 100}},
+		{name: "rejects a negative page", page: models.PageParams{Page: -1}, expectError: true},
+		{name: "rejects a negative limit", page: models.PageParams{PageSize: -10}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			page, err := listPage(tt.page)
+
+			// Assert
+			if tt.expectError {
+				if !errors.Is(err, ErrInvalidPage) {
+					t.Errorf("Expected ErrInvalidPage, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if page != tt.expected {
+				t.Errorf("Expected %+v, got %+v", tt.expected, page)
+			}
+		})
+	}
+}
+
+func TestGetAllIncidents_ReportsMorePages(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("first of three pages", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, nil, nil, &config.Config{})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()}, {Key: "incident_key", Value: 9}, {Key: "severity", Value: "low"}, {Key: "status", Value: "open"},
+			}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "n", Value: 3}}),
+		)
+
+		// Act
+		page, err := service.GetAllIncidents(context.Background(), models.ListIncidentsParams{Page: models.PageParams{PageSize: 1}})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := models.Pagination{Page: 1, Limit: 1, Total: 3, HasMore: true}
+		if page.Pagination != expected || len(page.Items) != 1 {
+			t.Errorf("Expected %+v with one incident, got %+v with %d", expected, page.Pagination, len(page.Items))
+		}
+	})
+}
+
+func TestGetSeverityTrend_RejectsInvalidParams(t *testing.T) {
+	service := &IncidentService{cfg: &config.Config{}}
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		params models.TrendParams
+	}{
+		{name: "unknown interval", params: models.TrendParams{Interval: "fortnight"}},
+		{name: "empty range", params: models.TrendParams{From: now, To: now}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := service.GetSeverityTrend(context.Background(), tt.params)
+
+			// Assert
+			if !errors.Is(err, ErrInvalidTrendParams) {
+				t.Fatalf("Expected ErrInvalidTrendParams, got %v", err)
+			}
+		})
+	}
+}
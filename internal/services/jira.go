@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrMissingJiraKey is returned when a Jira event has no issue key to correlate on
+var ErrMissingJiraKey = errors.New("jira issue key is required")
+
+// ErrUnsupportedJiraEvent is returned for Jira events that do not create or update issues
+var ErrUnsupportedJiraEvent = errors.New("unsupported jira event")
+
+// jiraPriorities maps Jira priority names onto incident severities
+var jiraPriorities = map[string]models.IncidentSeverity{
+	"blocker":  models.Critical,
+	"highest":  models.Critical,
+	"critical": models.Critical,
+	"high":     models.High,
+	"major":    models.High,
+	"medium":   models.Medium,
+	"low":      models.Low,
+	"minor":    models.Low,
+	"lowest":   models.Low,
+	"trivial":  models.Low,
+}
+
+// jiraStatuses maps Jira status names onto incident statuses
+var jiraStatuses = map[string]models.IncidentStatus{
+	"open":        models.Open,
+	"to do":       models.Open,
+	"in progress": models.InProgress,
+	"resolved":    models.Resolved,
+	"done":        models.Resolved,
+	"closed":      models.Closed,
+}
+
+// JiraExternalID is the external id incidents synced from a Jira issue are stored under
+func JiraExternalID(issueKey string) string {
+	return "jira:" + issueKey
+}
+
+// SyncJiraIssue creates an incident for a Jira issue seen for the first time and
+// applies status and priority changes to the incident already correlated with
+// its key. It reports whether a new incident was created.
+func (s *IncidentService) SyncJiraIssue(ctx context.Context, event *models.JiraIssueEvent) (*models.Incident, bool, error) {
+	if event.WebhookEvent != "jira:issue_created" && event.WebhookEvent != "jira:issue_updated" {
+		return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedJiraEvent, event.WebhookEvent)
+	}
+	if strings.TrimSpace(event.Issue.Key) == "" {
+		return nil, false, ErrMissingJiraKey
+	}
+
+	externalID := JiraExternalID(event.Issue.Key)
+	existingIncident, err := s.repo.FindByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to look up jira issue %s: %w", event.Issue.Key, err)
+	}
+
+	fields := event.Issue.Fields
+	if existingIncident == nil {
+		incident, err := s.CreateSystemIncident(ctx, &models.CreateIncidentRequest{
+			Title:       fields.Summary,
+			Severity:    jiraSeverity(fields.Priority),
+			Description: fields.Description,
+			ExternalID:  externalID,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return incident, true, nil
+	}
+
+	incident := existingIncident
+	id := existingIncident.ID.Hex()
+	if status, ok := jiraStatus(fields.Status); ok && status != incident.Status {
+		updated, _, err := s.UpdateIncidentStatus(ctx, id, &models.UpdateIncidentStatusRequest{Status: status})
+		if err != nil {
+			// Jira workflows allow moves ours does not; keep the incident's status rather than fail the sync
+			log.Printf("Skipping status %s from jira issue %s: %v", status, event.Issue.Key, err)
+		} else {
+			incident = updated
+		}
+	}
+	if fields.Priority != nil {
+		if severity := jiraSeverity(fields.Priority); severity != incident.Severity {
+			updated, _, err := s.UpdateIncidentSeverity(ctx, id, &models.UpdateIncidentSeverityRequest{Severity: severity})
+			if err != nil {
+				return nil, false, err
+			}
+			incident = updated
+		}
+	}
+
+	return incident, false, nil
+}
+
+// jiraSeverity maps a Jira priority onto a severity, defaulting to medium
+func jiraSeverity(priority *models.JiraNamed) models.IncidentSeverity {
+	if priority != nil {
+		if severity, ok := jiraPriorities[strings.ToLower(priority.Name)]; ok {
+			return severity
+		}
+	}
+	return models.Medium
+}
+
+// jiraStatus maps a Jira status onto an incident status, if it has an equivalent
+func jiraStatus(status *models.JiraNamed) (models.IncidentStatus, bool) {
+	if status == nil {
+		return "", false
+	}
+	mapped, ok := jiraStatuses[strings.ToLower(status.Name)]
+	return mapped, ok
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+// newJiraTestService wires a service to the mock deployment. A critical minimum
+// keeps the medium severity events in these tests off Kafka, so no producer is needed.
+func newJiraTestService(mt *mtest.T) *IncidentService {
+	return NewIncidentService(
+		repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection),
+		nil,
+		eventbus.New(eventbus.DefaultBufferSize),
+		webhooks.NewDispatcher(nil, ""),
+		&config.Config{MinEventSeverity: "critical", SystemActorEmail: "system@incident.local"},
+	)
+}
+
+func TestSyncJiraIssue_CreatesIncidentForNewIssue(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("new issue key", func(mt *mtest.T) {
+		// Arrange
+		service := newJiraTestService(mt)
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "incident_key", Value: 41}}),
+			mtest.CreateSuccessResponse(),
+		)
+		event := &models.JiraIssueEvent{
+			WebhookEvent: "jira:issue_created",
+			Issue: models.JiraIssue{Key: "OPS-12", Fields: models.JiraIssueFields{
+				Summary:  "Payments failing in eu-west",
+				Priority: &models.JiraNamed{Name: "Medium"},
+			}},
+		}
+
+		// Act
+		incident, created, err := service.SyncJiraIssue(context.Background(), event)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !created {
+			t.Error("Expected a new incident to be created")
+		}
+		if incident.ExternalID != "jira:OPS-12" || incident.IncidentKey != 42 {
+			t.Errorf("Expected incident 42 correlated with jira:OPS-12, got %d %q", incident.IncidentKey, incident.ExternalID)
+		}
+		if incident.Severity != models.Medium || incident.CreatedBy != "system@incident.local" {
+			t.Errorf("Expected a medium incident created by the system actor, got %s by %s", incident.Severity, incident.CreatedBy)
+		}
+	})
+}
+
+func TestSyncJiraIssue_UpdatesIncidentForExistingKey(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("known issue key", func(mt *mtest.T) {
+		// Arrange
+		service := newJiraTestService(mt)
+		id := primitive.NewObjectID()
+		incident := func(status models.IncidentStatus) bson.D {
+			return bson.D{
+				{Key: "_id", Value: id},
+				{Key: "incident_key", Value: 42},
+				{Key: "severity", Value: "medium"},
+				{Key: "status", Value: string(status)},
+				{Key: "external_id", Value: "jira:OPS-12"},
+			}
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(models.Open)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(models.Open)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident(models.InProgress)}),
+		)
+		event := &models.JiraIssueEvent{
+			WebhookEvent: "jira:issue_updated",
+			Issue: models.JiraIssue{Key: "OPS-12", Fields: models.JiraIssueFields{
+				Summary:  "Payments failing in eu-west",
+				Priority: &models.JiraNamed{Name: "Medium"},
+				Status:   &models.JiraNamed{Name: "In Progress"},
+			}},
+		}
+
+		// Act
+		updated, created, err := service.SyncJiraIssue(context.Background(), event)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if created {
+			t.Error("Expected the existing incident to be updated, not a new one created")
+		}
+		if updated.ID != id || updated.Status != models.InProgress {
+			t.Errorf("Expected incident %s moved to in_progress, got %s %s", id.Hex(), updated.ID.Hex(), updated.Status)
+		}
+	})
+}
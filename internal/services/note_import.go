@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrNoNotesToImport is returned when a bulk note import is empty
+var ErrNoNotesToImport = errors.New("at least one note is required")
+
+// ErrNoteContentRequired is returned when an imported note has no content
+var ErrNoteContentRequired = errors.New("note content is required")
+
+// NoteImportError is returned when a note in a bulk import is invalid, naming
+// its position so the whole batch can be corrected and resent
+type NoteImportError struct {
+	Index int
+	Err   error
+}
+
+func (e *NoteImportError) Error() string {
+	return fmt.Sprintf("note %d: %v", e.Index, e.Err)
+}
+
+func (e *NoteImportError) Unwrap() error {
+	return e.Err
+}
+
+// ImportNotes validates every note like AddNoteToIncident, then appends them in
+// the given order with one update. Each note keeps its created_at when set and not
+// in the future; otherwise it is stamped with the import time. Nothing is imported
+// when any note is invalid.
+func (s *IncidentService) ImportNotes(ctx context.Context, incidentID string, reqs []models.ImportNoteRequest) (*models.Incident, error) {
+	if len(reqs) == 0 {
+		return nil, ErrNoNotesToImport
+	}
+
+	now := time.Now().UTC()
+	notes := make([]models.Note, len(reqs))
+	for i, req := range reqs {
+		if strings.TrimSpace(req.Content) == "" {
+			return nil, &NoteImportError{Index: i, Err: ErrNoteContentRequired}
+		}
+		if err := s.validateNote(&req.AddNoteRequest); err != nil {
+			return nil, &NoteImportError{Index: i, Err: err}
+		}
+		notes[i] = models.Note{
+			Content:     req.Content,
+			AuthorEmail: req.AuthorEmail,
+			Type:        req.Type,
+			Visibility:  s.noteVisibility(req.Visibility),
+			CreatedAt:   importedNoteTime(req.CreatedAt, now),
+		}
+	}
+
+	existingIncident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	// The last imported note must still fit within the limit
+	if err := checkNoteLimit(len(existingIncident.Notes)+len(notes)-1, s.cfg.MaxNotes); err != nil {
+		return nil, err
+	}
+
+	updatedIncident, err := s.repo.AddNotes(ctx, existingIncident.ID.Hex(), notes)
+	if err != nil {
+		log.Printf("Error importing notes to incident: %v", err)
+		return nil, fmt.Errorf("failed to import notes to incident: %w", err)
+	}
+
+	config.Infof("Imported %d notes to incident: ID=%s", len(notes), incidentID)
+	s.recordFirstResponse(ctx, updatedIncident, earliestNoteTime(notes))
+
+	for _, note := range notes {
+		for _, event := range noteAddedEvents(updatedIncident, note, s.cfg.NoteTypeEvents) {
+			s.publish(ctx, updatedIncident, event)
+		}
+	}
+
+	return updatedIncident, nil
+}
+
+// importedNoteTime keeps a provided creation time unless it is unset or in the future
+func importedNoteTime(createdAt *time.Time, now time.Time) time.Time {
+	if createdAt == nil || createdAt.IsZero() || createdAt.After(now) {
+		return now
+	}
+	return createdAt.UTC()
+}
+
+// earliestNoteTime returns the creation time of the earliest note
+func earliestNoteTime(notes []models.Note) time.Time {
+	earliest := notes[0].CreatedAt
+	for _, note := range notes[1:] {
+		if note.CreatedAt.Before(earliest) {
+			earliest = note.CreatedAt
+		}
+	}
+	return earliest
+}
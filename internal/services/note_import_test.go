@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestImportNotes_PushesNotesInOrderWithTimestamps(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("backfilled transcript", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		incident := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "resolved"}}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident}),
+		)
+
+		backfilled := time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC)
+		future := time.Now().Add(time.Hour)
+		reqs := []models.ImportNoteRequest{
+			{AddNoteRequest: models.AddNoteRequest{Content: "Paged on-call"}, CreatedAt: &backfilled},
+			{AddNoteRequest: models.AddNoteRequest{Content: "Rolled back deploy"}},
+			{AddNoteRequest: models.AddNoteRequest{Content: "Errors recovered"}, CreatedAt: &future},
+		}
+
+		// Act
+		before := time.Now()
+		_, err := service.ImportNotes(context.Background(), id.Hex(), reqs)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		pushed, err := update.Lookup("$push", "notes", "$each").Array().Values()
+		if err != nil {
+			t.Fatalf("Expected notes pushed with $each, got %v", err)
+		}
+		if len(pushed) != len(reqs) {
+			t.Fatalf("Expected %d notes in one push, got %d", len(reqs), len(pushed))
+		}
+		for i, value := range pushed {
+			note := value.Document()
+			if content := note.Lookup("content").StringValue(); content != reqs[i].Content {
+				t.Errorf("Expected note %d to be %q, got %q", i, reqs[i].Content, content)
+			}
+		}
+
+		if createdAt := pushed[0].Document().Lookup("created_at").Time(); !createdAt.Equal(backfilled) {
+			t.Errorf("Expected backfilled timestamp %s, got %s", backfilled, createdAt)
+		}
+		for _, i := range []int{1, 2} {
+			if createdAt := pushed[i].Document().Lookup("created_at").Time(); createdAt.Before(before.Truncate(time.Millisecond)) || createdAt.After(time.Now()) {
+				t.Errorf("Expected note %d stamped with the import time, got %s", i, createdAt)
+			}
+		}
+	})
+
+	mt.Run("invalid note rejects the batch", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		reqs := []models.ImportNoteRequest{
+			{AddNoteRequest: models.AddNoteRequest{Content: "Paged on-call"}},
+			{AddNoteRequest: models.AddNoteRequest{Content: "Rolled back", Type: "bogus"}},
+		}
+
+		// Act
+		_, err := service.ImportNotes(context.Background(), primitive.NewObjectID().Hex(), reqs)
+
+		// Assert
+		var importErr *NoteImportError
+		if !errors.As(err, &importErr) || importErr.Index != 1 {
+			t.Fatalf("Expected NoteImportError for note 1, got %v", err)
+		}
+		var invalidErr *InvalidValueError
+		if !errors.As(err, &invalidErr) {
+			t.Errorf("Expected the invalid note type to be reported, got %v", err)
+		}
+	})
+}
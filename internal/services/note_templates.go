@@ -0,0 +1,99 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// noteTemplateNames are the display names of the predefined note templates
+var noteTemplateNames = map[models.NoteType]string{
+	models.Update:        "Status update",
+	models.Investigation: "Investigation",
+	models.Resolution:    "Resolution",
+	models.Communication: "Customer communication",
+}
+
+// defaultNoteTemplateSections are the headings each note type's template requires,
+// unless overridden by NOTE_TEMPLATE_<TYPE>
+var defaultNoteTemplateSections = map[models.NoteType][]string{
+	models.Update:        {"Current status", "Impact", "Next steps"},
+	models.Investigation: {"Hypothesis", "Findings"},
+	models.Resolution:    {"Root cause", "Fix"},
+	models.Communication: {"Summary", "Next update"},
+}
+
+// MissingSectionsError is returned when an enforced note template's headings are missing
+type MissingSectionsError struct {
+	Type    models.NoteType
+	Missing []string
+}
+
+func (e *MissingSectionsError) Error() string {
+	return fmt.Sprintf("%s note is missing required sections: %s", e.Type, strings.Join(e.Missing, ", "))
+}
+
+// NoteTemplates returns the template for every note type, in note type order
+func (s *IncidentService) NoteTemplates() []models.NoteTemplate {
+	templates := make([]models.NoteTemplate, 0, len(models.ValidNoteTypes()))
+	for _, noteType := range models.ValidNoteTypes() {
+		sections := s.noteTemplateSections(noteType)
+		templates = append(templates, models.NoteTemplate{
+			Type:     noteType,
+			Name:     noteTemplateNames[noteType],
+			Sections: sections,
+			Body:     templateBody(sections),
+		})
+	}
+	return templates
+}
+
+// noteTemplateSections returns the configured headings for a note type, falling
+// back to the predefined ones
+func (s *IncidentService) noteTemplateSections(noteType models.NoteType) []string {
+	if sections := s.cfg.NoteTemplates[string(noteType)]; len(sections) > 0 {
+		return sections
+	}
+	return defaultNoteTemplateSections[noteType]
+}
+
+// checkNoteTemplate rejects typed notes missing their template's headings when
+// template enforcement is on
+func (s *IncidentService) checkNoteTemplate(noteType models.NoteType, content string) error {
+	if !s.cfg.EnforceNoteTemplates || noteType == "" {
+		return nil
+	}
+	if missing := missingSections(content, s.noteTemplateSections(noteType)); len(missing) > 0 {
+		return &MissingSectionsError{Type: noteType, Missing: missing}
+	}
+	return nil
+}
+
+// missingSections returns the sections without a heading line in the content. A
+// heading matches case-insensitively, with or without leading '#' and a trailing ':'.
+func missingSections(content string, sections []string) []string {
+	headings := make(map[string]bool)
+	for _, line := range strings.Split(content, "\n") {
+		heading := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+		heading = strings.TrimSpace(strings.TrimSuffix(heading, ":"))
+		headings[strings.ToLower(heading)] = true
+	}
+
+	var missing []string
+	for _, section := range sections {
+		if !headings[strings.ToLower(section)] {
+			missing = append(missing, section)
+		}
+	}
+	return missing
+}
+
+// templateBody is a Markdown skeleton with a heading per section
+func templateBody(sections []string) string {
+	var body strings.Builder
+	for _, section := range sections {
+		fmt.Fprintf(&body, "## %s\n\n", section)
+	}
+	return body.String()
+}
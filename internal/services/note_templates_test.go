@@ -0,0 +1,91 @@
+package services
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+func TestCheckNoteTemplate(t *testing.T) {
+	tests := []struct {
+		name          string
+		enforce       bool
+		noteType      models.NoteType
+		content       string
+		expectMissing []string
+	}{
+		{
+			name:     "all sections present",
+			enforce:  true,
+			noteType: models.Update,
+			content:  "## Current status\nDegraded\n\nImpact:\nCheckout\n\n# next steps\nFail over",
+		},
+		{
+			name:          "missing a required section",
+			enforce:       true,
+			noteType:      models.Update,
+			content:       "## Current status\nDegraded\n\n## Impact\nCheckout",
+			expectMissing: []string{"Next steps"},
+		},
+		{
+			name:     "enforcement off",
+			noteType: models.Update,
+			content:  "Still looking",
+		},
+		{
+			name:    "untyped notes are not checked",
+			enforce: true,
+			content: "Still looking",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			service := NewIncidentService(nil, nil, nil, nil, &config.Config{EnforceNoteTemplates: tt.enforce})
+
+			// Act
+			err := service.checkNoteTemplate(tt.noteType, tt.content)
+
+			// Assert
+			if tt.expectMissing == nil {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			var sectionsErr *MissingSectionsError
+			if !errors.As(err, &sectionsErr) {
+				t.Fatalf("Expected MissingSectionsError, got %v", err)
+			}
+			if !reflect.DeepEqual(sectionsErr.Missing, tt.expectMissing) {
+				t.Errorf("Expected missing %v, got %v", tt.expectMissing, sectionsErr.Missing)
+			}
+		})
+	}
+}
+
+func TestNoteTemplates_ConfiguredSectionsOverrideDefaults(t *testing.T) {
+	// Arrange
+	service := NewIncidentService(nil, nil, nil, nil, &config.Config{
+		NoteTemplates: map[string][]string{"resolution": {"Cause", "Fix", "Follow-ups"}},
+	})
+
+	// Act
+	templates := service.NoteTemplates()
+
+	// Assert
+	if len(templates) != len(models.ValidNoteTypes()) {
+		t.Fatalf("Expected a template per note type, got %d", len(templates))
+	}
+	resolution := templates[2]
+	if !reflect.DeepEqual(resolution.Sections, []string{"Cause", "Fix", "Follow-ups"}) {
+		t.Errorf("Expected configured resolution sections, got %v", resolution.Sections)
+	}
+	if resolution.Body != "## Cause\n\n## Fix\n\n## Follow-ups\n\n" {
+		t.Errorf("Unexpected template body %q", resolution.Body)
+	}
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+)
+
+// OutageService handles business logic for outages
+type OutageService struct {
+	repo            *repository.OutageRepository
+	incidentRepo    *repository.IncidentRepository
+	incidentService *IncidentService
+}
+
+// NewOutageService creates a new outage service
+func NewOutageService(repo *repository.OutageRepository, incidentRepo *repository.IncidentRepository, incidentService *IncidentService) *OutageService {
+	return &OutageService{
+		repo:            repo,
+		incidentRepo:    incidentRepo,
+		incidentService: incidentService,
+	}
+}
+
+// CreateOutage creates a new outage
+func (s *OutageService) CreateOutage(ctx context.Context, req *models.CreateOutageRequest) (*models.Outage, error) {
+	title := strings.TrimSpace(req.Title)
+	if len(title) < 3 || len(title) > 255 {
+		return nil, fmt.Errorf("title must be between 3 and 255 characters")
+	}
+
+	outage, err := s.repo.Create(ctx, &models.Outage{
+		Title:       title,
+		Description: req.Description,
+		Status:      models.OutageOpen,
+	})
+	if err != nil {
+		log.Printf("Error creating outage: %v", err)
+		return nil, err
+	}
+
+	config.Infof("Created new outage: ID=%s, Title=%s", outage.ID.Hex(), outage.Title)
+
+	return outage, nil
+}
+
+// GetOutage fetches an outage together with its child incidents
+func (s *OutageService) GetOutage(ctx context.Context, id string) (*models.OutageWithIncidents, error) {
+	outage, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents, err := s.incidentRepo.GetByOutageID(ctx, outage.ID)
+	if err != nil {
+		log.Printf("Error fetching incidents for outage %s: %v", id, err)
+		return nil, err
+	}
+
+	return &models.OutageWithIncidents{
+		Outage:    *outage,
+		Incidents: incidents,
+	}, nil
+}
+
+// AttachIncident groups the incident with the given key under an outage
+func (s *OutageService) AttachIncident(ctx context.Context, outageID, incidentKey string) (*models.Incident, error) {
+	key, err := models.ParseIncidentKey(incidentKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", repository.ErrInvalidIncidentID, err)
+	}
+
+	outage, err := s.repo.GetByID(ctx, outageID)
+	if err != nil {
+		return nil, err
+	}
+	if outage.Status == models.OutageClosed {
+		return nil, fmt.Errorf("cannot attach incidents to a closed outage")
+	}
+
+	incident, err := s.incidentRepo.SetOutage(ctx, key, outage.ID)
+	if err != nil {
+		log.Printf("Error attaching incident %d to outage %s: %v", key, outageID, err)
+		return nil, err
+	}
+
+	config.Infof("Attached incident %d to outage %s", key, outageID)
+
+	return incident, nil
+}
+
+// CloseOutage closes an outage and, when requested, every child incident that is still unclosed
+func (s *OutageService) CloseOutage(ctx context.Context, id string, req *models.CloseOutageRequest) (*models.OutageWithIncidents, error) {
+	outage, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CloseIncidents {
+		incidents, err := s.incidentRepo.GetByOutageID(ctx, outage.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, incident := range incidents {
+			if incident.Status == models.Closed {
+				continue
+			}
+			_, _, err := s.incidentService.UpdateIncidentStatus(ctx, strconv.Itoa(incident.IncidentKey), &models.UpdateIncidentStatusRequest{
+				Status:      models.Closed,
+				AuthorEmail: req.AuthorEmail,
+			})
+			if err != nil {
+				log.Printf("Error closing incident %d for outage %s: %v", incident.IncidentKey, id, err)
+				return nil, fmt.Errorf("failed to close incident %d: %w", incident.IncidentKey, err)
+			}
+		}
+	}
+
+	if _, err := s.repo.UpdateStatus(ctx, outage.ID, models.OutageClosed); err != nil {
+		log.Printf("Error closing outage %s: %v", id, err)
+		return nil, err
+	}
+
+	config.Infof("Closed outage: ID=%s, CloseIncidents=%t", id, req.CloseIncidents)
+
+	return s.GetOutage(ctx, id)
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+)
+
+// toDoc converts a model into the document shape returned by MongoDB
+func toDoc(t *testing.T, v interface{}) bson.D {
+	t.Helper()
+
+	data, err := bson.Marshal(v)
+	if err != nil {
+		t.Fatalf("Expected no error marshaling %T, got %v", v, err)
+	}
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Expected no error unmarshaling %T, got %v", v, err)
+	}
+	return doc
+}
+
+func newTestOutageService(mt *mtest.T) *OutageService {
+	return NewOutageService(
+		repository.NewOutageRepository(mt.DB),
+		repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection),
+		nil,
+	)
+}
+
+func TestOutageService_AttachIncident(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	outage := models.Outage{ID: primitive.NewObjectID(), Title: "Region us-east-1 degraded", Status: models.OutageOpen}
+
+	mt.Run("attaches the incident to an open outage", func(mt *mtest.T) {
+		// Arrange
+		service := newTestOutageService(mt)
+		attached := models.Incident{ID: primitive.NewObjectID(), IncidentKey: 42, Title: "API errors", OutageID: &outage.ID}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.outages", mtest.FirstBatch, toDoc(t, outage)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: toDoc(t, attached)}),
+		)
+
+		// Act
+		incident, err := service.AttachIncident(context.Background(), outage.ID.Hex(), "42")
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if incident.OutageID == nil || *incident.OutageID != outage.ID {
+			t.Errorf("Expected incident to reference outage %s, got %v", outage.ID.Hex(), incident.OutageID)
+		}
+	})
+
+	mt.Run("rejects closed outages", func(mt *mtest.T) {
+		// Arrange
+		service := newTestOutageService(mt)
+		closed := outage
+		closed.Status = models.OutageClosed
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.outages", mtest.FirstBatch, toDoc(t, closed)))
+
+		// Act
+		_, err := service.AttachIncident(context.Background(), closed.ID.Hex(), "42")
+
+		// Assert
+		if err == nil {
+			t.Fatal("Expected error attaching to a closed outage, got nil")
+		}
+	})
+}
+
+func TestOutageService_GetOutage_ReturnsChildIncidents(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("returns the grouped view", func(mt *mtest.T) {
+		// Arrange
+		service := newTestOutageService(mt)
+		outage := models.Outage{ID: primitive.NewObjectID(), Title: "Region us-east-1 degraded", Status: models.OutageOpen}
+		first := models.Incident{ID: primitive.NewObjectID(), IncidentKey: 41, Title: "API errors", Severity: models.High, Status: models.Open, OutageID: &outage.ID}
+		second := models.Incident{ID: primitive.NewObjectID(), IncidentKey: 42, Title: "Login failures", Severity: models.Critical, Status: models.Open, OutageID: &outage.ID}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.outages", mtest.FirstBatch, toDoc(t, outage)),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, toDoc(t, first), toDoc(t, second)),
+		)
+
+		// Act
+		grouped, err := service.GetOutage(context.Background(), outage.ID.Hex())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if grouped.ID != outage.ID {
+			t.Errorf("Expected outage %s, got %s", outage.ID.Hex(), grouped.ID.Hex())
+		}
+		if len(grouped.Incidents) != 2 {
+			t.Fatalf("Expected 2 incidents, got %d", len(grouped.Incidents))
+		}
+		if grouped.Incidents[0].IncidentKey != 41 || grouped.Incidents[1].IncidentKey != 42 {
+			t.Errorf("Expected incidents 41 and 42, got %d and %d", grouped.Incidents[0].IncidentKey, grouped.Incidents[1].IncidentKey)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrPostmortemNotResolved is returned when attaching a postmortem to an incident
+// that is not yet resolved or closed
+var ErrPostmortemNotResolved = errors.New("postmortems can only be attached to resolved or closed incidents")
+
+// ErrPostmortemIncomplete is returned when a postmortem lacks its summary or root cause
+var ErrPostmortemIncomplete = errors.New("postmortem is incomplete")
+
+// ErrPostmortemNotFound is returned when an incident has no postmortem
+var ErrPostmortemNotFound = errors.New("incident has no postmortem")
+
+// ErrPostmortemRequired is returned when closing an incident whose severity is
+// listed in POSTMORTEM_REQUIRED_SEVERITIES before it has a postmortem
+var ErrPostmortemRequired = errors.New("incident must have a postmortem before it is closed")
+
+// SavePostmortem attaches the postmortem to a resolved or closed incident,
+// replacing any earlier one while keeping its creation time
+func (s *IncidentService) SavePostmortem(ctx context.Context, incidentID string, req *models.PostmortemRequest) (*models.Postmortem, error) {
+	postmortem, err := newPostmortem(req)
+	if err != nil {
+		return nil, err
+	}
+
+	incident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if incident.Status != models.Resolved && incident.Status != models.Closed {
+		return nil, ErrPostmortemNotResolved
+	}
+
+	now := time.Now()
+	postmortem.CreatedAt, postmortem.UpdatedAt = now, now
+	if incident.Postmortem != nil {
+		postmortem.CreatedAt = incident.Postmortem.CreatedAt
+	}
+
+	updated, err := s.repo.SetPostmortem(ctx, incidentID, postmortem)
+	if err != nil {
+		log.Printf("Error saving postmortem: %v", err)
+		return nil, fmt.Errorf("failed to save postmortem: %w", err)
+	}
+	config.Infof("Saved postmortem for incident: ID=%s", incidentID)
+	return updated.Postmortem, nil
+}
+
+// GetPostmortem fetches the postmortem attached to an incident
+func (s *IncidentService) GetPostmortem(ctx context.Context, incidentID string) (*models.Postmortem, error) {
+	incident, err := s.repo.GetByID(ctx, incidentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+	if incident.Postmortem == nil {
+		return nil, ErrPostmortemNotFound
+	}
+	return incident.Postmortem, nil
+}
+
+// newPostmortem validates the request, defaulting action items to open
+func newPostmortem(req *models.PostmortemRequest) (models.Postmortem, error) {
+	var missing []string
+	if strings.TrimSpace(req.Summary) == "" {
+		missing = append(missing, "summary")
+	}
+	if strings.TrimSpace(req.RootCause) == "" {
+		missing = append(missing, "root_cause")
+	}
+	if len(missing) > 0 {
+		return models.Postmortem{}, fmt.Errorf("%w: %s required", ErrPostmortemIncomplete, strings.Join(missing, " and "))
+	}
+
+	actionItems := make([]models.ActionItem, len(req.ActionItems))
+	for i, item := range req.ActionItems {
+		if item.Status == "" {
+			item.Status = models.ActionItemOpen
+		}
+		if !item.Status.IsValid() {
+			return models.Postmortem{}, InvalidActionItemStatus(item.Status)
+		}
+		actionItems[i] = item
+	}
+
+	timeline := req.Timeline
+	if timeline == nil {
+		timeline = []models.PostmortemEvent{}
+	}
+
+	return models.Postmortem{
+		Summary:     req.Summary,
+		Timeline:    timeline,
+		RootCause:   req.RootCause,
+		ActionItems: actionItems,
+		AuthorEmail: req.AuthorEmail,
+	}, nil
+}
+
+// checkPostmortem requires a postmortem before closing incidents of the severities
+// configured in POSTMORTEM_REQUIRED_SEVERITIES
+func (s *IncidentService) checkPostmortem(incident *models.Incident, newStatus models.IncidentStatus) error {
+	if newStatus != models.Closed || !slices.Contains(s.cfg.PostmortemRequiredSeverities, string(incident.Severity)) {
+		return nil
+	}
+	if incident.Postmortem == nil {
+		return ErrPostmortemRequired
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestSaveAndGetPostmortem(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("saved postmortem is returned on fetch", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		incident := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "resolved"}}
+		withPostmortem := append(incident, bson.E{Key: "postmortem", Value: bson.D{
+			{Key: "summary", Value: "Checkout was down for 20 minutes"},
+			{Key: "root_cause", Value: "Expired certificate"},
+			{Key: "action_items", Value: bson.A{bson.D{{Key: "description", Value: "Alert on expiry"}, {Key: "owner", Value: "sre@example.com"}, {Key: "status", Value: "open"}}}},
+		}})
+		req := &models.PostmortemRequest{
+			Summary:     "Checkout was down for 20 minutes",
+			RootCause:   "Expired certificate",
+			ActionItems: []models.ActionItem{{Description: "Alert on expiry", Owner: "sre@example.com"}},
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: withPostmortem}),
+		)
+
+		// Act
+		saved, err := service.SavePostmortem(context.Background(), id.Hex(), req)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		status := update.Lookup("$set", "postmortem", "action_items").Array().Index(0).Value().Document().Lookup("status").StringValue()
+		if status != string(models.ActionItemOpen) {
+			t.Errorf("Expected action item stored as open, got %s", status)
+		}
+		if saved.RootCause != "Expired certificate" {
+			t.Errorf("Expected saved root cause, got %+v", saved)
+		}
+
+		// Act
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, withPostmortem))
+		fetched, err := service.GetPostmortem(context.Background(), id.Hex())
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if fetched.Summary != req.Summary || len(fetched.ActionItems) != 1 || fetched.ActionItems[0].Owner != "sre@example.com" {
+			t.Errorf("Expected the saved postmortem, got %+v", fetched)
+		}
+	})
+
+	mt.Run("open incidents cannot have a postmortem", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "_id", Value: id}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "open"}}))
+
+		// Act
+		_, err := service.SavePostmortem(context.Background(), id.Hex(), &models.PostmortemRequest{Summary: "Too early", RootCause: "Unknown"})
+
+		// Assert
+		if !errors.Is(err, ErrPostmortemNotResolved) {
+			t.Fatalf("Expected ErrPostmortemNotResolved, got %v", err)
+		}
+	})
+}
+
+func TestNewPostmortem_Validation(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              models.PostmortemRequest
+		expectIncomplete bool
+		expectInvalid    bool
+	}{
+		{name: "summary and root cause are required", req: models.PostmortemRequest{Summary: "Outage"}, expectIncomplete: true},
+		{name: "action item status must be valid", req: models.PostmortemRequest{Summary: "Outage", RootCause: "Bad deploy", ActionItems: []models.ActionItem{{Status: "later"}}}, expectInvalid: true},
+		{name: "complete postmortem", req: models.PostmortemRequest{Summary: "Outage", RootCause: "Bad deploy"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := newPostmortem(&tt.req)
+
+			// Assert
+			var invalidErr *InvalidValueError
+			switch {
+			case tt.expectIncomplete:
+				if !errors.Is(err, ErrPostmortemIncomplete) {
+					t.Errorf("Expected ErrPostmortemIncomplete, got %v", err)
+				}
+			case tt.expectInvalid:
+				if !errors.As(err, &invalidErr) {
+					t.Errorf("Expected InvalidValueError, got %v", err)
+				}
+			case err != nil:
+				t.Errorf("Expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateIncidentStatus_RequirePostmortemToClose(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name          string
+		severity      models.IncidentSeverity
+		hasPostmortem bool
+		expectBlocked bool
+	}{
+		{name: "required severity without postmortem is blocked", severity: models.High, expectBlocked: true},
+		{name: "required severity with postmortem closes", severity: models.High, hasPostmortem: true},
+		{name: "other severities close without postmortem", severity: models.Medium},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			cfg := &config.Config{MinEventSeverity: "critical", PostmortemRequiredSeverities: []string{"high"}}
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), cfg)
+
+			id := primitive.NewObjectID()
+			incident := func(status models.IncidentStatus) bson.D {
+				doc := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: string(tt.severity)}, {Key: "status", Value: string(status)}}
+				if tt.hasPostmortem {
+					doc = append(doc, bson.E{Key: "postmortem", Value: bson.D{{Key: "summary", Value: "Outage"}, {Key: "root_cause", Value: "Bad deploy"}}})
+				}
+				return doc
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(models.Resolved)),
+				mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident(models.Closed)}),
+			)
+
+			// Act
+			updated, _, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{Status: models.Closed})
+
+			// Assert
+			if tt.expectBlocked {
+				if !errors.Is(err, ErrPostmortemRequired) {
+					t.Fatalf("Expected ErrPostmortemRequired, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if updated.Status != models.Closed {
+				t.Errorf("Expected status closed, got %s", updated.Status)
+			}
+		})
+	}
+}
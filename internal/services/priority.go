@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// UpdateIncidentPriority updates the priority of an incident. Unlike severity
+// changes there is no cooldown, as priority is expected to move during triage.
+func (s *IncidentService) UpdateIncidentPriority(ctx context.Context, id string, req *models.UpdateIncidentPriorityRequest) (*models.Incident, bool, error) {
+	if !req.Priority.IsValid() {
+		return nil, false, InvalidPriority(req.Priority)
+	}
+
+	existingIncident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	// Setting the current priority again changes nothing, so nothing is written or emitted
+	if existingIncident.Priority == req.Priority {
+		return existingIncident, false, nil
+	}
+
+	updatedIncident, err := s.repo.UpdatePriority(ctx, id, req.Priority)
+	if err != nil {
+		log.Printf("Error updating incident priority: %v", err)
+		return nil, false, fmt.Errorf("failed to update incident priority: %w", err)
+	}
+	if strings.TrimSpace(req.AuthorEmail) != "" {
+		_, err = s.AddWatcherToIncident(ctx, id, &models.Watcher{Email: req.AuthorEmail})
+		if err != nil {
+			log.Printf("Error adding watcher to incident: %v", err)
+			return nil, false, fmt.Errorf("updated incident priority but failed to add watcher to incident: %w", err)
+		}
+	}
+	config.Infof("Updated incident priority: ID=%s, Priority=%s", id, req.Priority)
+
+	s.publish(ctx, updatedIncident, models.IncidentPriorityUpdated{
+		EventKey:         primitive.NewObjectID().Hex(),
+		Id:               updatedIncident.ID.Hex(),
+		TenantID:         updatedIncident.TenantID,
+		Title:            updatedIncident.Title,
+		Priority:         string(updatedIncident.Priority),
+		PreviousPriority: string(existingIncident.Priority),
+	})
+
+	return updatedIncident, true, nil
+}
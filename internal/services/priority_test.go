@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestCreateIncident_Priority(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name     string
+		severity models.IncidentSeverity
+		priority models.IncidentPriority
+		expected models.IncidentPriority
+	}{
+		{name: "derived from severity when omitted", severity: models.High, expected: models.P2},
+		{name: "given priority is kept", severity: models.Low, priority: models.P1, expected: models.P1},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+				mtest.CreateSuccessResponse(),
+			)
+
+			// Act
+			incident, err := service.CreateIncident(context.Background(), &models.CreateIncidentRequest{Title: "Payment API latency", Severity: tt.severity, Priority: tt.priority})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if incident.Priority != tt.expected {
+				t.Errorf("Expected priority %s, got %s", tt.expected, incident.Priority)
+			}
+		})
+	}
+
+	mt.Run("invalid priority is rejected", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+
+		// Act
+		_, err := service.CreateIncident(context.Background(), &models.CreateIncidentRequest{Title: "Payment API latency", Severity: models.High, Priority: "P0"})
+
+		// Assert
+		var invalidErr *InvalidValueError
+		if !errors.As(err, &invalidErr) || invalidErr.Field != "priority" {
+			t.Fatalf("Expected an invalid priority error, got %v", err)
+		}
+	})
+}
+
+func TestUpdateIncidentPriority(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	incident := func(id primitive.ObjectID, priority models.IncidentPriority) bson.D {
+		return bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "severity", Value: "medium"}, {Key: "priority", Value: string(priority)}, {Key: "status", Value: "open"}}
+	}
+
+	mt.Run("change is saved and announced", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		sub := service.SubscribeToEvents()
+		defer service.UnsubscribeFromEvents(sub)
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(id, models.P3)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident(id, models.P1)}),
+		)
+
+		// Act
+		updated, changed, err := service.UpdateIncidentPriority(context.Background(), id.Hex(), &models.UpdateIncidentPriorityRequest{Priority: models.P1})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !changed || updated.Priority != models.P1 {
+			t.Errorf("Expected priority changed to P1, got %s (changed %t)", updated.Priority, changed)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		if priority := update.Lookup("$set", "priority").StringValue(); priority != string(models.P1) {
+			t.Errorf("Expected priority P1 to be set, got %s", priority)
+		}
+		var event models.IncidentPriorityUpdated
+		select {
+		case published := <-sub.Events():
+			var ok bool
+			if event, ok = published.(models.IncidentPriorityUpdated); !ok {
+				t.Fatalf("Expected IncidentPriorityUpdated, got %s", published.GetEventType())
+			}
+		default:
+			t.Fatal("Expected a priority event")
+		}
+		if event.Priority != string(models.P1) || event.PreviousPriority != string(models.P3) {
+			t.Errorf("Expected P3 to P1, got %s to %s", event.PreviousPriority, event.Priority)
+		}
+	})
+
+	mt.Run("same priority writes nothing", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident(id, models.P2)))
+
+		// Act
+		_, changed, err := service.UpdateIncidentPriority(context.Background(), id.Hex(), &models.UpdateIncidentPriorityRequest{Priority: models.P2})
+
+		// Assert
+		if err != nil || changed {
+			t.Fatalf("Expected an unchanged incident, got changed=%t err=%v", changed, err)
+		}
+		if commands := mt.GetAllStartedEvents(); len(commands) != 1 {
+			t.Errorf("Expected only the incident lookup, got %d commands", len(commands))
+		}
+	})
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+func TestReplayEvents_ChronologicalOrder(t *testing.T) {
+	// Arrange
+	created := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	resolved := created.Add(2 * time.Hour)
+	incident := &models.Incident{
+		Title:           "Checkout down",
+		Severity:        models.High,
+		Status:          models.Resolved,
+		CreatedAt:       created,
+		StatusChangedAt: &resolved,
+		Notes: []models.Note{
+			{Content: "Rolled back", CreatedAt: created.Add(3 * time.Hour)},
+			{Content: "Investigating", CreatedAt: created.Add(time.Hour)},
+		},
+	}
+
+	// Act
+	events := replayEvents(incident)
+
+	// Assert
+	expected := []string{"incident.created", "incident.notes.added", "incident.status.updated", "incident.notes.added"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+	for i, event := range events {
+		if event.GetEventType() != expected[i] {
+			t.Errorf("Expected event %d to be %s, got %s", i, expected[i], event.GetEventType())
+		}
+	}
+	if note := events[1].(models.IncidentNoteAdded); note.Content != "Investigating" {
+		t.Errorf("Expected the earlier note first, got %q", note.Content)
+	}
+}
+
+func TestReplayEvents_DraftHasNoEvents(t *testing.T) {
+	// Act
+	events := replayEvents(&models.Incident{Draft: true, CreatedAt: time.Now()})
+
+	// Assert
+	if len(events) != 0 {
+		t.Errorf("Expected no events for a draft, got %d", len(events))
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+)
+
+// ErrSavedSearchNameRequired is returned when a saved search has no name
+var ErrSavedSearchNameRequired = errors.New("saved search name is required")
+
+// SavedSearchService handles business logic for saved searches
+type SavedSearchService struct {
+	repo            *repository.SavedSearchRepository
+	incidentService *IncidentService
+}
+
+// NewSavedSearchService creates a new saved search service
+func NewSavedSearchService(repo *repository.SavedSearchRepository, incidentService *IncidentService) *SavedSearchService {
+	return &SavedSearchService{
+		repo:            repo,
+		incidentService: incidentService,
+	}
+}
+
+// CreateSavedSearch validates and stores a named filter set
+func (s *SavedSearchService) CreateSavedSearch(ctx context.Context, req *models.SavedSearchRequest) (*models.SavedSearch, error) {
+	name, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	search, err := s.repo.Create(ctx, &models.SavedSearch{
+		Name:      name,
+		Filter:    req.Filter,
+		CreatedBy: strings.TrimSpace(req.AuthorEmail),
+	})
+	if err != nil {
+		log.Printf("Error creating saved search: %v", err)
+		return nil, err
+	}
+
+	config.Infof("Created saved search: ID=%s, Name=%s", search.ID.Hex(), search.Name)
+	return search, nil
+}
+
+// GetSavedSearch fetches a saved search
+func (s *SavedSearchService) GetSavedSearch(ctx context.Context, id string) (*models.SavedSearch, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListSavedSearches returns every saved search, ordered by name
+func (s *SavedSearchService) ListSavedSearches(ctx context.Context) ([]models.SavedSearch, error) {
+	return s.repo.List(ctx)
+}
+
+// UpdateSavedSearch replaces the name and filter of a saved search
+func (s *SavedSearchService) UpdateSavedSearch(ctx context.Context, id string, req *models.SavedSearchRequest) (*models.SavedSearch, error) {
+	name, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Update(ctx, id, name, req.Filter)
+}
+
+// DeleteSavedSearch removes a saved search
+func (s *SavedSearchService) DeleteSavedSearch(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// ListIncidents runs the saved search against the incident list, returning the
+// same incidents as passing its filter as query parameters
+func (s *SavedSearchService) ListIncidents(ctx context.Context, id string, page models.PageParams) (*models.IncidentPage, error) {
+	search, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	params := search.Filter.ListParams()
+	params.Page = page
+	return s.incidentService.GetAllIncidents(ctx, params)
+}
+
+// validate checks the request's name and filter, returning the trimmed name
+func (s *SavedSearchService) validate(req *models.SavedSearchRequest) (string, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return "", ErrSavedSearchNameRequired
+	}
+
+	if req.Filter.Status != "" && !req.Filter.Status.IsValid() {
+		return "", InvalidStatus(req.Filter.Status)
+	}
+	if req.Filter.Severity != "" && !req.Filter.Severity.IsValid() {
+		return "", InvalidSeverity(req.Filter.Severity)
+	}
+	for _, noteType := range []models.NoteType{req.Filter.HasNoteType, req.Filter.MissingNoteType} {
+		if noteType != "" && !noteType.IsValid() {
+			return "", InvalidNoteType(noteType)
+		}
+	}
+	if req.Filter.SLA != "" && !req.Filter.SLA.IsValid() {
+		return "", InvalidSLAStatus(req.Filter.SLA)
+	}
+	if _, err := parseCustomFieldFilters(req.Filter.CustomFields, s.incidentService.cfg.CustomFieldSchema); err != nil {
+		return "", err
+	}
+	for _, group := range req.Filter.Or {
+		if len(group) == 0 {
+			return "", &FilterExpressionError{Reason: "expected at least one field:value term"}
+		}
+		for _, condition := range group {
+			if err := validateFilterCondition(condition); err != nil {
+				return "", err
+			}
+		}
+	}
+	return name, nil
+}
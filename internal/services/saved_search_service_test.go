@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+)
+
+func TestSavedSearch_ListIncidentsMatchesInlineFilter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("filter by note type and custom field", func(mt *mtest.T) {
+		// Arrange
+		cfg := &config.Config{CustomFieldSchema: map[string]string{"region": "string"}}
+		incidentService := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, nil, nil, cfg)
+		service := NewSavedSearchService(repository.NewSavedSearchRepository(mt.DB), incidentService)
+		filter := models.SearchFilter{
+			MissingNoteType: models.Resolution,
+			CustomFields:    map[string]string{"region": "eu-west"},
+		}
+		incidentDoc := bson.D{
+			{Key: "_id", Value: primitive.NewObjectID()},
+			{Key: "incident_key", Value: 42},
+			{Key: "title", Value: "Payment API latency"},
+			{Key: "severity", Value: "high"},
+			{Key: "status", Value: "open"},
+		}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		search, err := service.CreateSavedSearch(context.Background(), &models.SavedSearchRequest{
+			Name:   "EU incidents awaiting resolution",
+			Filter: filter,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error creating saved search, got %v", err)
+		}
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.saved_searches", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: search.ID},
+				{Key: "name", Value: search.Name},
+				{Key: "filter", Value: filter},
+			}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incidentDoc),
+		)
+
+		// Act
+		mt.ClearEvents()
+		saved, err := service.ListIncidents(context.Background(), search.ID.Hex(), models.PageParams{})
+		mt.GetStartedEvent() // the saved search lookup
+		savedFilter := listFilterConditions(mt.GetStartedEvent())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incidentDoc))
+		inline, inlineErr := incidentService.GetAllIncidents(context.Background(), models.ListIncidentsParams{
+			MissingNoteType:   models.Resolution,
+			CustomFieldFilter: map[string]string{"region": "eu-west"},
+		})
+		inlineFilter := listFilterConditions(mt.GetStartedEvent())
+
+		// Assert
+		if err != nil || inlineErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", err, inlineErr)
+		}
+		if len(savedFilter.Value) == 0 || !savedFilter.Equal(inlineFilter) {
+			t.Errorf("Expected saved search filter %v to match inline filter %v", savedFilter, inlineFilter)
+		}
+		if len(saved.Items) != 1 || len(inline.Items) != 1 || saved.Items[0].IncidentKey != inline.Items[0].IncidentKey {
+			t.Errorf("Expected the same incidents, got %v and %v", saved, inline)
+		}
+	})
+}
+
+func TestSavedSearch_RoundTripsListFilters(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("status, severity, assignee and or groups are replayed", func(mt *mtest.T) {
+		// Arrange
+		incidentService := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, nil, nil, &config.Config{})
+		service := NewSavedSearchService(repository.NewSavedSearchRepository(mt.DB), incidentService)
+		filter := models.SearchFilter{
+			Status:   models.InProgress,
+			Severity: models.Critical,
+			Assignee: "alice@example.com",
+			Or: [][]models.FilterCondition{{
+				{Field: models.FilterTag, Value: "payments"},
+				{Field: models.FilterService, Value: "checkout"},
+			}},
+		}
+
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		search, err := service.CreateSavedSearch(context.Background(), &models.SavedSearchRequest{Name: "Critical payments", Filter: filter})
+		if err != nil {
+			t.Fatalf("Expected no error creating saved search, got %v", err)
+		}
+		stored := mt.GetStartedEvent().Command.Lookup("documents").Array().Index(0).Value().Document().Lookup("filter")
+
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.saved_searches", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: search.ID},
+				{Key: "name", Value: search.Name},
+				{Key: "filter", Value: stored},
+			}),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+		)
+
+		// Act
+		_, err = service.ListIncidents(context.Background(), search.ID.Hex(), models.PageParams{})
+		mt.GetStartedEvent() // the saved search lookup
+		savedFilter := listFilterConditions(mt.GetStartedEvent())
+
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch))
+		_, inlineErr := incidentService.GetAllIncidents(context.Background(), models.ListIncidentsParams{
+			Status:   models.InProgress,
+			Severity: models.Critical,
+			Assignee: "alice@example.com",
+			AnyOf:    filter.Or,
+		})
+		inlineFilter := listFilterConditions(mt.GetStartedEvent())
+
+		// Assert
+		if err != nil || inlineErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", err, inlineErr)
+		}
+		if !savedFilter.Equal(inlineFilter) {
+			t.Errorf("Expected saved search filter %v to match inline filter %v", savedFilter, inlineFilter)
+		}
+		if conditions, _ := savedFilter.Array().Values(); len(conditions) != 4 {
+			t.Errorf("Expected status, severity, assignee and the or group, got %v", conditions)
+		}
+	})
+}
+
+// listFilterConditions returns the list filter conditions matched by the first
+// stage of a list aggregation
+func listFilterConditions(started *event.CommandStartedEvent) bson.RawValue {
+	return started.Command.Lookup("pipeline").Array().Index(0).Value().Document().Lookup("$match", "$and")
+}
+
+func TestCreateSavedSearch_Validation(t *testing.T) {
+	service := NewSavedSearchService(nil, NewIncidentService(nil, nil, nil, nil, &config.Config{
+		CustomFieldSchema: map[string]string{"customer_count": "number"},
+	}))
+
+	tests := []struct {
+		name string
+		req  models.SavedSearchRequest
+		want func(error) bool
+	}{
+		{
+			name: "blank name",
+			req:  models.SavedSearchRequest{Name: "  "},
+			want: func(err error) bool { return errors.Is(err, ErrSavedSearchNameRequired) },
+		},
+		{
+			name: "unknown note type",
+			req:  models.SavedSearchRequest{Name: "Triage", Filter: models.SearchFilter{HasNoteType: "bogus"}},
+			want: func(err error) bool { var e *InvalidValueError; return errors.As(err, &e) },
+		},
+		{
+			name: "unknown status",
+			req:  models.SavedSearchRequest{Name: "Triage", Filter: models.SearchFilter{Status: "triaged"}},
+			want: func(err error) bool { var e *InvalidValueError; return errors.As(err, &e) },
+		},
+		{
+			name: "or group on an unknown field",
+			req:  models.SavedSearchRequest{Name: "Triage", Filter: models.SearchFilter{Or: [][]models.FilterCondition{{{Field: "owner", Value: "alice"}}}}},
+			want: func(err error) bool { var e *InvalidValueError; return errors.As(err, &e) },
+		},
+		{
+			name: "custom field of the wrong type",
+			req:  models.SavedSearchRequest{Name: "Triage", Filter: models.SearchFilter{CustomFields: map[string]string{"customer_count": "many"}}},
+			want: func(err error) bool { var e *CustomFieldError; return errors.As(err, &e) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := service.CreateSavedSearch(context.Background(), &tt.req)
+
+			// Assert
+			if !tt.want(err) {
+				t.Fatalf("Expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSavedSearch_RejectsMalformedID(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("every lookup reports the invalid id", func(mt *mtest.T) {
+		// Arrange
+		service := NewSavedSearchService(repository.NewSavedSearchRepository(mt.DB), NewIncidentService(nil, nil, nil, nil, &config.Config{}))
+		lookups := map[string]func() error{
+			"get": func() error {
+				_, err := service.GetSavedSearch(context.Background(), "not-an-id")
+				return err
+			},
+			"update": func() error {
+				_, err := service.UpdateSavedSearch(context.Background(), "not-an-id", &models.SavedSearchRequest{Name: "Triage"})
+				return err
+			},
+			"delete": func() error {
+				return service.DeleteSavedSearch(context.Background(), "not-an-id")
+			},
+		}
+
+		for name, lookup := range lookups {
+			// Act
+			err := lookup()
+
+			// Assert
+			if !errors.Is(err, repository.ErrInvalidSavedSearchID) {
+				t.Errorf("Expected %s to return ErrInvalidSavedSearchID, got %v", name, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+)
+
+// ErrActivationNotInFuture is returned when creating a scheduled incident whose
+// activation time has already passed
+var ErrActivationNotInFuture = errors.New("activate_at must be in the future")
+
+// initialStatus returns the status a new incident starts in: scheduled when it
+// has a future activation time, open otherwise
+func initialStatus(activateAt *time.Time, now time.Time) (models.IncidentStatus, error) {
+	if activateAt == nil {
+		return models.Open, nil
+	}
+	if !activateAt.After(now) {
+		return "", ErrActivationNotInFuture
+	}
+	return models.Scheduled, nil
+}
+
+// ActivateScheduledIncidents opens every scheduled incident due at now,
+// announcing each with IncidentCreated, and returns how many were opened
+func (s *IncidentService) ActivateScheduledIncidents(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.repo.FindDueScheduled(ctx, now)
+	if err != nil {
+		log.Printf("Error finding scheduled incidents: %v", err)
+		return 0, err
+	}
+
+	activated := 0
+	for i := range due {
+		incident, err := s.repo.ActivateScheduled(ctx, due[i].ID, now)
+		if errors.Is(err, repository.ErrNotScheduled) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error activating scheduled incident %d: %v", due[i].IncidentKey, err)
+			continue
+		}
+
+		config.Infof("Activated scheduled incident: ID=%s", incident.ID.Hex())
+		s.publish(ctx, incident, incidentCreatedEvent(incident))
+		activated++
+	}
+	return activated, nil
+}
+
+// RunScheduler opens due scheduled incidents every interval until ctx is done.
+// A run already started finishes even if ctx is cancelled meanwhile.
+func (s *IncidentService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.ActivateScheduledIncidents(context.WithoutCancel(ctx), now)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestInitialStatus(t *testing.T) {
+	now := time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC)
+	past, future := now.Add(-time.Minute), now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		activateAt *time.Time
+		expected   models.IncidentStatus
+		expectErr  error
+	}{
+		{name: "unscheduled incidents open immediately", expected: models.Open},
+		{name: "future activation is scheduled", activateAt: &future, expected: models.Scheduled},
+		{name: "past activation is rejected", activateAt: &past, expectErr: ErrActivationNotInFuture},
+		{name: "activation now is rejected", activateAt: &now, expectErr: ErrActivationNotInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			status, err := initialStatus(tt.activateAt, now)
+
+			// Assert
+			if !errors.Is(err, tt.expectErr) {
+				t.Fatalf("Expected error %v, got %v", tt.expectErr, err)
+			}
+			if status != tt.expected {
+				t.Errorf("Expected status %q, got %q", tt.expected, status)
+			}
+		})
+	}
+}
+
+func TestScheduledIncident_OpensAtActivationTime(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	activateAt := time.Now().Add(time.Hour).UTC().Truncate(time.Millisecond)
+	id := primitive.NewObjectID()
+	stored := func(status models.IncidentStatus) bson.D {
+		return bson.D{
+			{Key: "_id", Value: id},
+			{Key: "incident_key", Value: 7},
+			{Key: "title", Value: "Database maintenance"},
+			{Key: "severity", Value: "low"},
+			{Key: "status", Value: string(status)},
+			{Key: "activate_at", Value: activateAt},
+		}
+	}
+
+	mt.Run("scheduled incident is silent until due", func(mt *mtest.T) {
+		// Arrange
+		bus := eventbus.New(eventbus.DefaultBufferSize)
+		sub := bus.Subscribe(models.EVENT_TOPIC)
+		defer bus.Unsubscribe(sub)
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, bus, webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "incident_key", Value: 6}}),
+			mtest.CreateSuccessResponse(),
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch),
+		)
+
+		// Act
+		created, err := service.CreateIncident(context.Background(), &models.CreateIncidentRequest{
+			Title:      "Database maintenance",
+			Severity:   models.Low,
+			ActivateAt: &activateAt,
+		})
+		activated, activateErr := service.ActivateScheduledIncidents(context.Background(), activateAt.Add(-time.Second))
+
+		// Assert
+		if err != nil || activateErr != nil {
+			t.Fatalf("Expected no errors, got %v and %v", err, activateErr)
+		}
+		if created.Status != models.Scheduled {
+			t.Errorf("Expected status scheduled, got %s", created.Status)
+		}
+		if activated != 0 {
+			t.Errorf("Expected nothing activated before its time, got %d", activated)
+		}
+		mt.GetStartedEvent() // the key allocation
+		mt.GetStartedEvent() // the insert
+		filter := mt.GetStartedEvent().Command.Lookup("filter").Document()
+		if due := filter.Lookup("activate_at", "$lte").Time(); !due.Equal(activateAt.Add(-time.Second)) {
+			t.Errorf("Expected only incidents due by the run time, got %s", due)
+		}
+		select {
+		case event := <-sub.Events():
+			t.Fatalf("Expected no event before activation, got %s", event.GetEventType())
+		case <-time.After(50 * time.Millisecond):
+		}
+	})
+
+	mt.Run("due incident opens and is announced", func(mt *mtest.T) {
+		// Arrange
+		bus := eventbus.New(eventbus.DefaultBufferSize)
+		sub := bus.Subscribe(models.EVENT_TOPIC)
+		defer bus.Unsubscribe(sub)
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, bus, webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, stored(models.Scheduled)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: stored(models.Open)}),
+		)
+
+		// Act
+		activated, err := service.ActivateScheduledIncidents(context.Background(), activateAt)
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if activated != 1 {
+			t.Fatalf("Expected 1 incident activated, got %d", activated)
+		}
+		mt.GetStartedEvent() // the due incident lookup
+		claim := mt.GetStartedEvent().Command
+		if status := claim.Lookup("query", "status").StringValue(); status != string(models.Scheduled) {
+			t.Errorf("Expected only scheduled incidents to be activated, got %s", status)
+		}
+		if status := claim.Lookup("update", "$set", "status").StringValue(); status != string(models.Open) {
+			t.Errorf("Expected the incident to open, got %s", status)
+		}
+		select {
+		case event := <-sub.Events():
+			if event.GetEventType() != "incident.created" {
+				t.Errorf("Expected incident.created, got %s", event.GetEventType())
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected IncidentCreated once the incident opens")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrEmptySearchQuery is returned when an incident search has no query text
+var ErrEmptySearchQuery = errors.New("search query is required")
+
+// maxSearchResults caps the number of incidents a search returns
+const maxSearchResults = 50
+
+// SearchIncidents returns the incidents whose fields match the query words, most
+// relevant first. No fields searches the title and description.
+func (s *IncidentService) SearchIncidents(ctx context.Context, query string, fields []models.SearchField) ([]models.IncidentSearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	fields, err := searchFields(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.repo.SearchIncidents(ctx, query, fields, maxSearchResults)
+	if err != nil {
+		log.Printf("Error searching incidents: %v", err)
+		return nil, fmt.Errorf("failed to search incidents: %w", err)
+	}
+	return results, nil
+}
+
+// searchFields validates the fields a search is scoped to, dropping repeats
+func searchFields(fields []models.SearchField) ([]models.SearchField, error) {
+	var unique []models.SearchField
+	for _, field := range fields {
+		if !field.IsValid() {
+			return nil, InvalidSearchField(field)
+		}
+		if !slices.Contains(unique, field) {
+			unique = append(unique, field)
+		}
+	}
+	return unique, nil
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+func TestSearchIncidents_RequiresQuery(t *testing.T) {
+	// Arrange
+	service := NewIncidentService(nil, nil, nil, nil, &config.Config{})
+
+	for _, query := range []string{"", "   "} {
+		// Act
+		_, err := service.SearchIncidents(context.Background(), query, nil)
+
+		// Assert
+		if !errors.Is(err, ErrEmptySearchQuery) {
+			t.Errorf("Expected ErrEmptySearchQuery for %q, got %v", query, err)
+		}
+	}
+}
+
+func TestSearchFields(t *testing.T) {
+	tests := []struct {
+		name          string
+		fields        []models.SearchField
+		expected      []models.SearchField
+		expectInvalid bool
+	}{
+		{name: "no scope", fields: nil, expected: nil},
+		{name: "repeats are dropped", fields: []models.SearchField{"title", "notes", "title"}, expected: []models.SearchField{models.SearchTitle, models.SearchNotes}},
+		{name: "unknown field", fields: []models.SearchField{"title", "assignee"}, expectInvalid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			fields, err := searchFields(tt.fields)
+
+			// Assert
+			if tt.expectInvalid {
+				var invalidErr *InvalidValueError
+				if !errors.As(err, &invalidErr) {
+					t.Fatalf("Expected InvalidValueError, got %v", err)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(fields, tt.expected) {
+				t.Errorf("Expected %v, got %v (%v)", tt.expected, fields, err)
+			}
+		})
+	}
+}
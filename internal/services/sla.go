@@ -0,0 +1,36 @@
+package services
+
+import (
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// slaWindows converts an SLA state into the creation time range, per severity with
+// a target, of the unresolved incidents in that state at now. An incident is at
+// risk once atRiskPercent of its target has elapsed and breached once all of it has.
+func slaWindows(status models.SLAStatus, targets map[string]time.Duration, atRiskPercent int, now time.Time) []models.SLAWindow {
+	var windows []models.SLAWindow
+	for _, severity := range models.ValidSeverities() {
+		target, ok := targets[string(severity)]
+		if !ok {
+			continue
+		}
+
+		breachedAt := now.Add(-target)
+		atRiskAt := now.Add(-target * time.Duration(atRiskPercent) / 100)
+
+		window := models.SLAWindow{Severity: severity}
+		switch status {
+		case models.SLABreached:
+			window.CreatedBefore = breachedAt
+		case models.SLAAtRisk:
+			window.CreatedAfter = breachedAt
+			window.CreatedBefore = atRiskAt
+		case models.SLAOnTrack:
+			window.CreatedAfter = atRiskAt
+		}
+		windows = append(windows, window)
+	}
+	return windows
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// NotifyStaleIncidents emits an IncidentStale event for every unresolved incident
+// that has crossed a stale threshold it was not yet notified for, returning how
+// many were notified. Severity and the rest of the incident are left unchanged.
+func (s *IncidentService) NotifyStaleIncidents(ctx context.Context) (int, error) {
+	thresholds := s.cfg.StaleThresholds
+	if len(thresholds) == 0 {
+		return 0, nil
+	}
+
+	now := time.Now()
+	candidates, err := s.repo.FindStaleCandidates(ctx, now.Add(-thresholds[0]), thresholds[len(thresholds)-1])
+	if err != nil {
+		log.Printf("Error finding stale incidents: %v", err)
+		return 0, err
+	}
+
+	notified := 0
+	for i := range candidates {
+		incident := &candidates[i]
+		threshold, ok := staleThreshold(incident, thresholds, now)
+		if !ok {
+			continue
+		}
+
+		claimed, err := s.repo.MarkStaleNotified(ctx, incident, threshold, now)
+		if err != nil {
+			log.Printf("Error marking incident %d stale: %v", incident.IncidentKey, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		s.publish(ctx, incident, staleEvent(incident, threshold, now))
+		notified++
+	}
+
+	if notified > 0 {
+		config.Infof("Notified %d stale incidents", notified)
+	}
+	return notified, nil
+}
+
+// RunStaleNotifier checks for stale incidents every interval until ctx is done.
+// A run already started finishes even if ctx is cancelled meanwhile.
+func (s *IncidentService) RunStaleNotifier(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.NotifyStaleIncidents(context.WithoutCancel(ctx))
+		}
+	}
+}
+
+// staleThreshold returns the largest threshold the incident's age has reached,
+// reporting false when it was already notified for that threshold or is resolved.
+// Thresholds must be ascending; ones skipped while the job was not running are
+// not notified separately.
+func staleThreshold(incident *models.Incident, thresholds []time.Duration, now time.Time) (time.Duration, bool) {
+	if incident.Status != models.Open && incident.Status != models.InProgress {
+		return 0, false
+	}
+
+	age := now.Sub(incident.CreatedAt)
+	for i := len(thresholds) - 1; i >= 0; i-- {
+		if age >= thresholds[i] {
+			return thresholds[i], thresholds[i] > incident.StaleNotifiedAfter
+		}
+	}
+	return 0, false
+}
+
+// staleEvent builds the stale notification, addressed to the assignee and watchers
+func staleEvent(incident *models.Incident, threshold time.Duration, now time.Time) models.IncidentStale {
+	recipients := []string{}
+	for _, watcher := range mergeWatchers([]models.Watcher{{Email: incident.Assignee}}, incident.WatchList) {
+		recipients = append(recipients, watcher.Email)
+	}
+
+	return models.IncidentStale{
+		EventKey:   primitive.NewObjectID().Hex(),
+		Id:         incident.ID.Hex(),
+		TenantID:   incident.TenantID,
+		Title:      incident.Title,
+		Severity:   string(incident.Severity),
+		Status:     string(incident.Status),
+		Threshold:  threshold.String(),
+		Age:        now.Sub(incident.CreatedAt).Truncate(time.Minute).String(),
+		Recipients: recipients,
+	}
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestStaleThreshold(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	thresholds := []time.Duration{24 * time.Hour, 72 * time.Hour}
+
+	tests := []struct {
+		name              string
+		status            models.IncidentStatus
+		age               time.Duration
+		notifiedAfter     time.Duration
+		expectedThreshold time.Duration
+		expectedNotify    bool
+	}{
+		{name: "younger than every threshold", status: models.Open, age: 23 * time.Hour},
+		{name: "first threshold", status: models.Open, age: 30 * time.Hour, expectedThreshold: 24 * time.Hour, expectedNotify: true},
+		{name: "first threshold already notified", status: models.InProgress, age: 30 * time.Hour, notifiedAfter: 24 * time.Hour, expectedThreshold: 24 * time.Hour},
+		{name: "next threshold after notifying the first", status: models.Open, age: 80 * time.Hour, notifiedAfter: 24 * time.Hour, expectedThreshold: 72 * time.Hour, expectedNotify: true},
+		{name: "skipped thresholds notify only the largest", status: models.Open, age: 100 * time.Hour, expectedThreshold: 72 * time.Hour, expectedNotify: true},
+		{name: "resolved incidents are never stale", status: models.Resolved, age: 100 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			incident := &models.Incident{Status: tt.status, CreatedAt: now.Add(-tt.age), StaleNotifiedAfter: tt.notifiedAfter}
+
+			// Act
+			threshold, notify := staleThreshold(incident, thresholds, now)
+
+			// Assert
+			if threshold != tt.expectedThreshold || notify != tt.expectedNotify {
+				t.Errorf("Expected (%s, %t), got (%s, %t)", tt.expectedThreshold, tt.expectedNotify, threshold, notify)
+			}
+		})
+	}
+}
+
+func TestNotifyStaleIncidents_OncePerThreshold(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name           string
+		notifiedAfter  time.Duration
+		matched        int
+		expectedEvents int
+	}{
+		{name: "first crossing is notified", matched: 1, expectedEvents: 1},
+		{name: "already notified threshold is skipped", notifiedAfter: 24 * time.Hour, expectedEvents: 0},
+		{name: "claimed by a concurrent run", matched: 0, expectedEvents: 0},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			cfg := &config.Config{MinEventSeverity: "critical", StaleThresholds: []time.Duration{24 * time.Hour}}
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), cfg)
+			sub := service.SubscribeToEvents()
+			defer service.UnsubscribeFromEvents(sub)
+
+			incident := bson.D{
+				{Key: "_id", Value: primitive.NewObjectID()},
+				{Key: "incident_key", Value: 7},
+				{Key: "title", Value: "Payment API latency"},
+				{Key: "severity", Value: "medium"},
+				{Key: "status", Value: "open"},
+				{Key: "assignee", Value: "oncall@example.com"},
+				{Key: "watchlist", Value: bson.A{bson.D{{Key: "email", Value: "lead@example.com"}}}},
+				{Key: "created_at", Value: time.Now().Add(-30 * time.Hour)},
+			}
+			if tt.notifiedAfter > 0 {
+				incident = append(incident, bson.E{Key: "stale_notified_after", Value: tt.notifiedAfter})
+			}
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+				mtest.CreateSuccessResponse(bson.E{Key: "n", Value: tt.matched}, bson.E{Key: "nModified", Value: tt.matched}),
+			)
+
+			// Act
+			notified, err := service.NotifyStaleIncidents(context.Background())
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if notified != tt.expectedEvents {
+				t.Errorf("Expected %d notified, got %d", tt.expectedEvents, notified)
+			}
+			select {
+			case event := <-sub.Events():
+				stale, ok := event.(models.IncidentStale)
+				if tt.expectedEvents == 0 || !ok {
+					t.Fatalf("Expected no stale event, got %s", event.GetEventType())
+				}
+				if stale.Severity != "medium" || stale.Threshold != "24h0m0s" || len(stale.Recipients) != 2 {
+					t.Errorf("Expected a 24h notification for the assignee and watcher at medium severity, got %+v", stale)
+				}
+			default:
+				if tt.expectedEvents > 0 {
+					t.Error("Expected a stale event")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/tenant"
+)
+
+// AnyStatus matches every previous status when registering a status hook
+const AnyStatus models.IncidentStatus = ""
+
+// StatusHook runs after an incident has moved from one status to another
+type StatusHook func(ctx context.Context, incident *models.Incident, from, to models.IncidentStatus) error
+
+// statusTransition identifies the transitions a hook is registered for
+type statusTransition struct {
+	from models.IncidentStatus
+	to   models.IncidentStatus
+}
+
+// statusHookRegistry holds status hooks keyed by transition
+type statusHookRegistry struct {
+	mu    sync.RWMutex
+	hooks map[statusTransition][]StatusHook
+}
+
+func newStatusHookRegistry() *statusHookRegistry {
+	return &statusHookRegistry{
+		hooks: make(map[statusTransition][]StatusHook),
+	}
+}
+
+// register adds a hook for the transition; a from of AnyStatus matches any previous status
+func (r *statusHookRegistry) register(from, to models.IncidentStatus, hook StatusHook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := statusTransition{from: from, to: to}
+	r.hooks[key] = append(r.hooks[key], hook)
+}
+
+// matching returns the hooks registered for the transition
+func (r *statusHookRegistry) matching(from, to models.IncidentStatus) []StatusHook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var hooks []StatusHook
+	hooks = append(hooks, r.hooks[statusTransition{from: from, to: to}]...)
+	if from != AnyStatus {
+		hooks = append(hooks, r.hooks[statusTransition{from: AnyStatus, to: to}]...)
+	}
+	return hooks
+}
+
+// run invokes the matching hooks asynchronously, logging any errors
+func (r *statusHookRegistry) run(incident *models.Incident, from, to models.IncidentStatus) {
+	for _, hook := range r.matching(from, to) {
+		go func(hook StatusHook) {
+			ctx := tenant.NewContext(context.Background(), incident.TenantID)
+			if err := hook(ctx, incident, from, to); err != nil {
+				log.Printf("Status hook for incident %d (%s -> %s) failed: %v", incident.IncidentKey, from, to, err)
+			}
+		}(hook)
+	}
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+func TestStatusHookRegistry_FiresForMatchingTransitionOnly(t *testing.T) {
+	// Arrange
+	registry := newStatusHookRegistry()
+	fired := make(chan models.IncidentStatus, 4)
+	registry.register(models.InProgress, models.Resolved, func(ctx context.Context, incident *models.Incident, from, to models.IncidentStatus) error {
+		fired <- from
+		return nil
+	})
+	incident := &models.Incident{IncidentKey: 7}
+
+	// Act
+	registry.run(incident, models.Open, models.InProgress)
+	registry.run(incident, models.Open, models.Resolved)
+	registry.run(incident, models.InProgress, models.Resolved)
+
+	// Assert
+	select {
+	case from := <-fired:
+		if from != models.InProgress {
+			t.Errorf("Expected hook to fire for in_progress -> resolved, fired from %s", from)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected hook to fire for the matching transition")
+	}
+	select {
+	case from := <-fired:
+		t.Errorf("Expected hook to fire once, fired again from %s", from)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStatusHookRegistry_AnyStatusMatchesEveryPreviousStatus(t *testing.T) {
+	// Arrange
+	registry := newStatusHookRegistry()
+	registry.register(AnyStatus, models.Resolved, func(ctx context.Context, incident *models.Incident, from, to models.IncidentStatus) error {
+		return nil
+	})
+
+	// Act
+	resolvedHooks := registry.matching(models.Open, models.Resolved)
+	closedHooks := registry.matching(models.Resolved, models.Closed)
+
+	// Assert
+	if len(resolvedHooks) != 1 {
+		t.Errorf("Expected 1 hook for open -> resolved, got %d", len(resolvedHooks))
+	}
+	if len(closedHooks) != 0 {
+		t.Errorf("Expected no hooks for resolved -> closed, got %d", len(closedHooks))
+	}
+}
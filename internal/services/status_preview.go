@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// statusChangeErrors runs every rule gating a move to newStatus, returning the
+// violations in the order UpdateIncidentStatus enforces them
+func (s *IncidentService) statusChangeErrors(incident *models.Incident, newStatus models.IncidentStatus, now time.Time) []error {
+	var errs []error
+	if err := s.validateStatusTransition(incident, newStatus, now); err != nil {
+		errs = append(errs, fmt.Errorf("invalid status transition: %w", err))
+	}
+	if err := s.checkAssignee(incident, newStatus); err != nil {
+		errs = append(errs, err)
+	}
+	if err := s.checkPostmortem(incident, newStatus); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// PreviewStatusChange reports whether UpdateIncidentStatus would accept the
+// request and what else it would do, without changing the incident
+func (s *IncidentService) PreviewStatusChange(ctx context.Context, id string, req *models.UpdateIncidentStatusRequest) (*models.StatusChangePreview, error) {
+	if !req.Status.IsValid() {
+		return nil, InvalidStatus(req.Status)
+	}
+
+	incident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	preview := &models.StatusChangePreview{
+		From:        incident.Status,
+		To:          req.Status,
+		Blockers:    []string{},
+		SideEffects: []string{},
+	}
+	// Setting the current status again is accepted and changes nothing
+	if incident.Status == req.Status {
+		preview.Allowed = true
+		return preview, nil
+	}
+
+	for _, err := range s.statusChangeErrors(incident, req.Status, time.Now()) {
+		preview.Blockers = append(preview.Blockers, err.Error())
+	}
+	if noteType, required := s.requiredTransitionNote(req.Status); required {
+		preview.RequiredNote = noteType
+	}
+	note, err := s.transitionNote(incident, req)
+	if err != nil {
+		preview.Blockers = append(preview.Blockers, err.Error())
+	}
+	preview.Allowed = len(preview.Blockers) == 0
+
+	preview.SideEffects = s.statusChangeSideEffects(incident, req, note)
+	return preview, nil
+}
+
+// statusChangeSideEffects describes what UpdateIncidentStatus does besides
+// changing the status
+func (s *IncidentService) statusChangeSideEffects(incident *models.Incident, req *models.UpdateIncidentStatusRequest, note *models.Note) []string {
+	effects := []string{}
+	if note != nil {
+		effects = append(effects, fmt.Sprintf("adds a note of type %s", note.Type))
+	}
+
+	author := strings.TrimSpace(req.AuthorEmail)
+	if author != "" && !slices.ContainsFunc(incident.WatchList, func(w models.Watcher) bool { return w.Email == author }) {
+		effects = append(effects, fmt.Sprintf("adds %s as a watcher", author))
+	}
+
+	if !incident.Draft {
+		effects = append(effects, fmt.Sprintf("emits an %s event", models.IncidentStatusUpdated{}.GetEventType()))
+	}
+
+	if hooks := len(s.hooks.matching(incident.Status, req.Status)); hooks > 0 {
+		effects = append(effects, fmt.Sprintf("runs status hooks (%d)", hooks))
+	}
+	return effects
+}
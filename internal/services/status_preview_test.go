@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestPreviewStatusChange(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name                string
+		status              models.IncidentStatus
+		req                 models.UpdateIncidentStatusRequest
+		expectAllowed       bool
+		expectBlockers      int
+		expectRequiredNote  models.NoteType
+		expectedSideEffects []string
+	}{
+		{
+			name:               "closing without a postmortem or note is blocked",
+			status:             models.Resolved,
+			req:                models.UpdateIncidentStatusRequest{Status: models.Closed},
+			expectBlockers:     2,
+			expectRequiredNote: models.Resolution,
+			expectedSideEffects: []string{
+				"emits an incident.status.updated event",
+			},
+		},
+		{
+			name:          "allowed change lists its side effects",
+			status:        models.Open,
+			req:           models.UpdateIncidentStatusRequest{Status: models.InProgress, Note: "Investigating", AuthorEmail: "oncall@example.com"},
+			expectAllowed: true,
+			expectedSideEffects: []string{
+				"adds a note of type update",
+				"adds oncall@example.com as a watcher",
+				"emits an incident.status.updated event",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			// Arrange
+			cfg := &config.Config{
+				MinEventSeverity:             "critical",
+				PostmortemRequiredSeverities: []string{"high"},
+				TransitionNotes:              map[string]string{"closed": "resolution"},
+			}
+			service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), cfg)
+			id := primitive.NewObjectID()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+				{Key: "_id", Value: id},
+				{Key: "incident_key", Value: 7},
+				{Key: "severity", Value: "high"},
+				{Key: "status", Value: string(tt.status)},
+			}))
+
+			// Act
+			preview, err := service.PreviewStatusChange(context.Background(), id.Hex(), &tt.req)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if preview.Allowed != tt.expectAllowed {
+				t.Errorf("Expected allowed %t, got %t (blockers %v)", tt.expectAllowed, preview.Allowed, preview.Blockers)
+			}
+			if len(preview.Blockers) != tt.expectBlockers {
+				t.Errorf("Expected %d blockers, got %v", tt.expectBlockers, preview.Blockers)
+			}
+			if preview.RequiredNote != tt.expectRequiredNote {
+				t.Errorf("Expected required note %q, got %q", tt.expectRequiredNote, preview.RequiredNote)
+			}
+			if !slices.Equal(preview.SideEffects, tt.expectedSideEffects) {
+				t.Errorf("Expected side effects %v, got %v", tt.expectedSideEffects, preview.SideEffects)
+			}
+
+			mt.GetStartedEvent() // the incident lookup
+			if write := mt.GetStartedEvent(); write != nil {
+				t.Errorf("Expected the incident to be left unchanged, got %s", write.CommandName)
+			}
+		})
+	}
+}
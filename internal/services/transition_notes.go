@@ -0,0 +1,57 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// TransitionNoteRequiredError is returned when a status change configured in
+// TRANSITION_NOTES arrives without note content
+type TransitionNoteRequiredError struct {
+	Status models.IncidentStatus
+	Type   models.NoteType
+}
+
+func (e *TransitionNoteRequiredError) Error() string {
+	return fmt.Sprintf("a %s note is required to move an incident to %s", e.Type, e.Status)
+}
+
+// requiredTransitionNote returns the note type required to move into status, if any
+func (s *IncidentService) requiredTransitionNote(status models.IncidentStatus) (models.NoteType, bool) {
+	noteType, ok := s.cfg.TransitionNotes[string(status)]
+	return models.NoteType(noteType), ok
+}
+
+// transitionNote builds the note saved with a status change. It returns nil when
+// the request carries no note and the transition does not require one.
+func (s *IncidentService) transitionNote(incident *models.Incident, req *models.UpdateIncidentStatusRequest) (*models.Note, error) {
+	noteType, required := s.requiredTransitionNote(req.Status)
+	if strings.TrimSpace(req.Note) == "" {
+		if required {
+			return nil, &TransitionNoteRequiredError{Status: req.Status, Type: noteType}
+		}
+		return nil, nil
+	}
+	if !required {
+		noteType = models.Update
+	}
+
+	if err := s.checkNoteTemplate(noteType, req.Note); err != nil {
+		return nil, err
+	}
+	if err := s.validateNoteAuthor(req.AuthorEmail); err != nil {
+		return nil, err
+	}
+	if err := checkNoteLimit(len(incident.Notes), s.cfg.MaxNotes); err != nil {
+		return nil, err
+	}
+
+	return &models.Note{
+		Content:     req.Note,
+		AuthorEmail: req.AuthorEmail,
+		Type:        noteType,
+		Visibility:  s.noteVisibility(""),
+	}, nil
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func newTransitionNoteTestService(mt *mtest.T, transitionNotes map[string]string) *IncidentService {
+	return NewIncidentService(
+		repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection),
+		nil,
+		eventbus.New(eventbus.DefaultBufferSize),
+		webhooks.NewDispatcher(nil, ""),
+		&config.Config{MinEventSeverity: "critical", TransitionNotes: transitionNotes},
+	)
+}
+
+func transitionTestIncident(id primitive.ObjectID, status models.IncidentStatus) bson.D {
+	return bson.D{
+		{Key: "_id", Value: id},
+		{Key: "incident_key", Value: 7},
+		{Key: "title", Value: "Checkout latency"},
+		{Key: "severity", Value: "medium"},
+		{Key: "status", Value: string(status)},
+	}
+}
+
+func TestUpdateIncidentStatus_RequiredResolutionNote(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("missing note is rejected", func(mt *mtest.T) {
+		// Arrange
+		service := newTransitionNoteTestService(mt, map[string]string{"resolved": "resolution"})
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, transitionTestIncident(id, models.InProgress)))
+
+		// Act
+		_, _, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{Status: models.Resolved})
+
+		// Assert
+		var noteErr *TransitionNoteRequiredError
+		if !errors.As(err, &noteErr) {
+			t.Fatalf("Expected TransitionNoteRequiredError, got %v", err)
+		}
+		if noteErr.Type != models.Resolution {
+			t.Errorf("Expected a resolution note to be required, got %s", noteErr.Type)
+		}
+		mt.GetStartedEvent()
+		if mt.GetStartedEvent() != nil {
+			t.Error("Expected the status to be left untouched")
+		}
+	})
+
+	mt.Run("note is saved with the status", func(mt *mtest.T) {
+		// Arrange
+		service := newTransitionNoteTestService(mt, map[string]string{"resolved": "resolution"})
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, transitionTestIncident(id, models.InProgress)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: transitionTestIncident(id, models.Resolved)}),
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1}, bson.E{Key: "nModified", Value: 1}),
+		)
+
+		// Act
+		_, _, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{
+			Status: models.Resolved,
+			Note:   "Rolled back the bad deploy",
+		})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent()
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		note, err := update.LookupErr("$push", "notes")
+		if err != nil {
+			t.Fatalf("Expected the note to be pushed with the status update, got %v", update)
+		}
+		if noteType := note.Document().Lookup("type").StringValue(); noteType != "resolution" {
+			t.Errorf("Expected a resolution note, got %s", noteType)
+		}
+		if status := update.Lookup("$set", "status").StringValue(); status != "resolved" {
+			t.Errorf("Expected status resolved, got %s", status)
+		}
+	})
+}
+
+func TestUpdateIncidentStatus_NoteNotRequiredWhenDisabled(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("resolve without note", func(mt *mtest.T) {
+		// Arrange
+		service := newTransitionNoteTestService(mt, nil)
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, transitionTestIncident(id, models.InProgress)),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: transitionTestIncident(id, models.Resolved)}),
+		)
+
+		// Act
+		incident, _, err := service.UpdateIncidentStatus(context.Background(), id.Hex(), &models.UpdateIncidentStatusRequest{Status: models.Resolved})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if incident.Status != models.Resolved {
+			t.Errorf("Expected status resolved, got %s", incident.Status)
+		}
+		mt.GetStartedEvent()
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		if _, err := update.LookupErr("$push"); err == nil {
+			t.Errorf("Expected no note to be pushed, got %v", update)
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+// ErrInvalidTitleLength is returned for a title outside the 3 to 255 characters
+// allowed at creation
+var ErrInvalidTitleLength = errors.New("title must be between 3 and 255 characters")
+
+// ErrNoIncidentChanges is returned when an update request sets none of its fields
+var ErrNoIncidentChanges = errors.New("at least one of title, description or assignee is required")
+
+// UpdateIncident edits the title, description and assignee present in the
+// request. Fields set to their current value are ignored, and an update that
+// changes nothing writes nothing and emits no event.
+func (s *IncidentService) UpdateIncident(ctx context.Context, id string, req *models.UpdateIncidentRequest) (*models.Incident, error) {
+	if req.Title == nil && req.Description == nil && req.Assignee == nil {
+		return nil, ErrNoIncidentChanges
+	}
+	if err := s.normalizeIncidentUpdate(req); err != nil {
+		return nil, err
+	}
+
+	existingIncident, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get incident: %w", err)
+	}
+
+	fields := dropUnchangedFields(existingIncident, req)
+	if len(fields) == 0 {
+		return existingIncident, nil
+	}
+	// A new description must meet the minimum for the incident's severity, as at creation
+	if req.Description != nil {
+		if err := checkDescriptionLength(*req.Description, existingIncident.Severity, s.cfg.MinDescriptionLengths); err != nil {
+			return nil, err
+		}
+	}
+
+	var change *models.AssignmentChange
+	if req.Assignee != nil {
+		change = &models.AssignmentChange{
+			From:      existingIncident.Assignee,
+			To:        *req.Assignee,
+			ChangedBy: strings.TrimSpace(req.AuthorEmail),
+			ChangedAt: time.Now(),
+		}
+	}
+
+	updatedIncident, err := s.repo.UpdateDetails(ctx, existingIncident.ID, *req, change)
+	if err != nil {
+		log.Printf("Error updating incident: %v", err)
+		return nil, fmt.Errorf("failed to update incident: %w", err)
+	}
+	config.Infof("Updated incident: ID=%s, Fields=%s", id, strings.Join(fields, ","))
+
+	s.publish(ctx, updatedIncident, models.IncidentUpdated{
+		EventKey: primitive.NewObjectID().Hex(),
+		Id:       updatedIncident.ID.Hex(),
+		TenantID: updatedIncident.TenantID,
+		Title:    updatedIncident.Title,
+		Fields:   fields,
+	})
+	return updatedIncident, nil
+}
+
+// normalizeIncidentUpdate trims the present fields and validates them like
+// CreateIncident does, applying the configured title limit
+func (s *IncidentService) normalizeIncidentUpdate(req *models.UpdateIncidentRequest) error {
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if length := utf8.RuneCountInString(title); length < 3 || length > 255 {
+			return fmt.Errorf("%w, got %d", ErrInvalidTitleLength, length)
+		}
+		title, truncated, err := applyTitleLimit(title, s.cfg.MaxTitleLength, s.cfg.TitleOverflow == "truncate")
+		if err != nil {
+			return err
+		}
+		req.Title, req.TitleTruncated = &title, truncated
+	}
+
+	if req.Assignee != nil {
+		assignee := strings.TrimSpace(*req.Assignee)
+		if assignee != "" {
+			if err := s.validateEmail(assignee); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidAssignee, err)
+			}
+		}
+		req.Assignee = &assignee
+	}
+	return nil
+}
+
+// dropUnchangedFields clears the request fields that already hold the incident's
+// value, returning the names of the fields left to change
+func dropUnchangedFields(incident *models.Incident, req *models.UpdateIncidentRequest) []string {
+	var fields []string
+	if req.Title != nil {
+		if *req.Title == incident.Title {
+			req.Title = nil
+		} else {
+			fields = append(fields, "title")
+		}
+	}
+	if req.Description != nil {
+		if *req.Description == incident.Description {
+			req.Description = nil
+		} else {
+			fields = append(fields, "description")
+		}
+	}
+	if req.Assignee != nil {
+		if *req.Assignee == incident.Assignee {
+			req.Assignee = nil
+		} else {
+			fields = append(fields, "assignee")
+		}
+	}
+	return fields
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/eventbus"
+	"makers.anchor/incident/internal/models"
+	"makers.anchor/incident/internal/repository"
+	"makers.anchor/incident/internal/webhooks"
+)
+
+func TestUpdateIncident_SetsOnlyChangedFields(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("title and assignee", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		sub := service.SubscribeToEvents()
+		defer service.UnsubscribeFromEvents(sub)
+
+		id := primitive.NewObjectID()
+		incident := bson.D{{Key: "_id", Value: id}, {Key: "incident_key", Value: 7}, {Key: "title", Value: "DB down"}, {Key: "description", Value: "Primary unreachable"}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "open"}, {Key: "assignee", Value: "alice@example.com"}}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, incident),
+			mtest.CreateSuccessResponse(bson.E{Key: "value", Value: incident}),
+		)
+		title, description, assignee := "  Database primary down ", "Primary unreachable", "bob@example.com"
+
+		// Act
+		_, err := service.UpdateIncident(context.Background(), id.Hex(), &models.UpdateIncidentRequest{Title: &title, Description: &description, Assignee: &assignee})
+
+		// Assert
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		update := mt.GetStartedEvent().Command.Lookup("update").Document()
+		if got := update.Lookup("$set", "title").StringValue(); got != "Database primary down" {
+			t.Errorf("Expected the trimmed title, got %q", got)
+		}
+		if _, err := update.LookupErr("$set", "description"); err == nil {
+			t.Error("Expected the unchanged description not to be set")
+		}
+		change := update.Lookup("$push", "assignment_history").Document()
+		if change.Lookup("from").StringValue() != "alice@example.com" || change.Lookup("to").StringValue() != "bob@example.com" {
+			t.Errorf("Expected the reassignment from alice to bob recorded, got %v", change)
+		}
+
+		select {
+		case event := <-sub.Events():
+			updated, ok := event.(models.IncidentUpdated)
+			if !ok || !slices.Equal(updated.Fields, []string{"title", "assignee"}) {
+				t.Errorf("Expected an update event for title and assignee, got %+v", event)
+			}
+		default:
+			t.Error("Expected an incident.updated event")
+		}
+	})
+
+	mt.Run("no field changes", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{MinEventSeverity: "critical"})
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{{Key: "_id", Value: id}, {Key: "title", Value: "DB down"}, {Key: "severity", Value: "medium"}, {Key: "status", Value: "open"}}))
+		title := "DB down"
+
+		// Act
+		updated, err := service.UpdateIncident(context.Background(), id.Hex(), &models.UpdateIncidentRequest{Title: &title})
+
+		// Assert
+		if err != nil || updated.Title != "DB down" {
+			t.Fatalf("Expected the incident unchanged, got %+v and %v", updated, err)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		if event := mt.GetStartedEvent(); event != nil {
+			t.Errorf("Expected no update, got %s", event.CommandName)
+		}
+	})
+}
+
+func TestUpdateIncident_Validation(t *testing.T) {
+	service := NewIncidentService(nil, nil, nil, nil, &config.Config{})
+	short, long, badEmail := "ab", strings.Repeat("x", 256), "not-an-email"
+
+	tests := []struct {
+		name        string
+		req         models.UpdateIncidentRequest
+		expectedErr error
+	}{
+		{name: "no fields", req: models.UpdateIncidentRequest{}, expectedErr: ErrNoIncidentChanges},
+		{name: "title too short", req: models.UpdateIncidentRequest{Title: &short}, expectedErr: ErrInvalidTitleLength},
+		{name: "title too long", req: models.UpdateIncidentRequest{Title: &long}, expectedErr: ErrInvalidTitleLength},
+		{name: "invalid assignee", req: models.UpdateIncidentRequest{Assignee: &badEmail}, expectedErr: ErrInvalidAssignee},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := service.UpdateIncident(context.Background(), primitive.NewObjectID().Hex(), &tt.req)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Expected %v, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestUpdateIncident_EnforcesDescriptionMinimum(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("description shortened below the severity minimum", func(mt *mtest.T) {
+		// Arrange
+		service := NewIncidentService(repository.NewIncidentRepository(mt.DB, repository.IncidentsCollection), nil, eventbus.New(eventbus.DefaultBufferSize), webhooks.NewDispatcher(nil, ""), &config.Config{
+			MinEventSeverity:      "critical",
+			MinDescriptionLengths: map[string]int{"high": 20},
+		})
+		id := primitive.NewObjectID()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "db.incidents", mtest.FirstBatch, bson.D{
+			{Key: "_id", Value: id}, {Key: "title", Value: "DB down"}, {Key: "description", Value: "Primary unreachable since the failover"},
+			{Key: "severity", Value: "high"}, {Key: "status", Value: "open"},
+		}))
+		description := "  Down  "
+
+		// Act
+		_, err := service.UpdateIncident(context.Background(), id.Hex(), &models.UpdateIncidentRequest{Description: &description})
+
+		// Assert
+		var descriptionErr *DescriptionTooShortError
+		if !errors.As(err, &descriptionErr) {
+			t.Fatalf("Expected DescriptionTooShortError, got %v", err)
+		}
+		if descriptionErr.Minimum != 20 || descriptionErr.Length != 4 {
+			t.Errorf("Expected 4 of 20 characters, got %d of %d", descriptionErr.Length, descriptionErr.Minimum)
+		}
+		mt.GetStartedEvent() // the incident lookup
+		if extra := mt.GetStartedEvent(); extra != nil {
+			t.Errorf("Expected no update, got %s", extra.CommandName)
+		}
+	})
+}
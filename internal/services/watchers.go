@@ -0,0 +1,34 @@
+package services
+
+import (
+	"strings"
+
+	"makers.anchor/incident/internal/models"
+)
+
+// defaultWatchers returns the configured watchers of every new incident of the severity
+func defaultWatchers(configured map[string][]string, severity models.IncidentSeverity) []models.Watcher {
+	watchers := []models.Watcher{}
+	for _, email := range configured[string(severity)] {
+		watchers = append(watchers, models.Watcher{Email: email})
+	}
+	return watchers
+}
+
+// mergeWatchers concatenates the watcher lists, keeping the first watcher for each
+// email compared case-insensitively
+func mergeWatchers(lists ...[]models.Watcher) []models.Watcher {
+	merged := []models.Watcher{}
+	seen := make(map[string]bool)
+	for _, list := range lists {
+		for _, watcher := range list {
+			email := strings.ToLower(strings.TrimSpace(watcher.Email))
+			if email == "" || seen[email] {
+				continue
+			}
+			seen[email] = true
+			merged = append(merged, watcher)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"testing"
+
+	"makers.anchor/incident/internal/models"
+)
+
+func TestDefaultWatchers_AppliedBySeverity(t *testing.T) {
+	// Arrange
+	configured := map[string][]string{"critical": {"incidents@example.com", "sre-leads@example.com"}}
+	author := []models.Watcher{{Email: "dev@example.com"}}
+	requested := []models.Watcher{{Email: "INCIDENTS@example.com", Name: "Incident list"}}
+
+	tests := []struct {
+		name     string
+		severity models.IncidentSeverity
+		expected []string
+	}{
+		{name: "critical adds the configured list", severity: models.Critical, expected: []string{"dev@example.com", "INCIDENTS@example.com", "sre-leads@example.com"}},
+		{name: "low adds nothing", severity: models.Low, expected: []string{"dev@example.com", "INCIDENTS@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			watchers := mergeWatchers(author, requested, defaultWatchers(configured, tt.severity))
+
+			// Assert
+			if len(watchers) != len(tt.expected) {
+				t.Fatalf("Expected %d watchers, got %+v", len(tt.expected), watchers)
+			}
+			for i, email := range tt.expected {
+				if watchers[i].Email != email {
+					t.Errorf("Expected watcher %d to be %s, got %s", i, email, watchers[i].Email)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3Storage presigns uploads against an S3-compatible endpoint using path-style URLs
+type S3Storage struct {
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	now       func() time.Time
+}
+
+// NewS3Storage creates a new S3-compatible attachment storage
+func NewS3Storage(endpoint, bucket, region, accessKey, secretKey string) (*S3Storage, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid storage endpoint %q", endpoint)
+	}
+
+	return &S3Storage{
+		endpoint:  parsed,
+		bucket:    bucket,
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		now:       time.Now,
+	}, nil
+}
+
+// PresignUpload returns a presigned PUT URL for the object
+func (s *S3Storage) PresignUpload(ctx context.Context, objectKey string, expiry time.Duration) (string, error) {
+	path := "/" + s.bucket + "/" + strings.TrimPrefix(objectKey, "/")
+	return s.presign("PUT", s.endpoint.Host, path, expiry)
+}
+
+// presign builds an AWS Signature Version 4 query-string authenticated URL
+func (s *S3Storage) presign(method, host, path string, expiry time.Duration) (string, error) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    s.accessKey + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(expiry.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	canonicalQuery := canonicalQueryString(query)
+	canonicalPath := encodePath(path)
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		canonicalPath,
+		canonicalQuery,
+		"host:" + host + "\n",
+		"host",
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	return fmt.Sprintf("%s://%s%s?%s&X-Amz-Signature=%s",
+		s.endpoint.Scheme, host, canonicalPath, canonicalQuery, signature), nil
+}
+
+// canonicalQueryString sorts and URI-encodes the query parameters
+func canonicalQueryString(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, uriEncode(key, true)+"="+uriEncode(query[key], true))
+	}
+	return strings.Join(pairs, "&")
+}
+
+// encodePath URI-encodes each segment of the object path
+func encodePath(path string) string {
+	return uriEncode(path, false)
+}
+
+// uriEncode encodes every byte except the unreserved characters, keeping '/' when encoding paths
+func uriEncode(value string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
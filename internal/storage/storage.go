@@ -0,0 +1,12 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// AttachmentStorage abstracts the object storage that incident attachments are uploaded to
+type AttachmentStorage interface {
+	// PresignUpload returns a URL the client can PUT the object to until the expiry elapses
+	PresignUpload(ctx context.Context, objectKey string, expiry time.Duration) (string, error)
+}
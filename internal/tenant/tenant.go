@@ -0,0 +1,17 @@
+package tenant
+
+import "context"
+
+// contextKey is the context key carrying the caller's tenant ID
+type contextKey struct{}
+
+// NewContext returns a copy of ctx scoped to the tenant
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ctx is scoped to, or "" when it is unscoped
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,28 @@
+package version
+
+// Build information, injected at build time via -ldflags, e.g.
+//
+//	go build -ldflags "-X makers.anchor/incident/internal/version.Version=1.2.0 \
+//	  -X makers.anchor/incident/internal/version.Commit=$(git rev-parse --short HEAD) \
+//	  -X makers.anchor/incident/internal/version.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)" ./cmd
+var (
+	Version   = "dev"
+	Commit    = "dev"
+	BuildTime = "dev"
+)
+
+// Info represents the build information of the running service
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// Get returns the build information of the running service
+func Get() Info {
+	return Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+	}
+}
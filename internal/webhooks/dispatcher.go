@@ -0,0 +1,185 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/kafka"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body
+	SignatureHeader = "X-Incident-Signature"
+	// EventTypeHeader carries the type of the delivered event
+	EventTypeHeader = "X-Incident-Event"
+)
+
+// Dispatcher posts incident events to the configured outbound webhooks
+type Dispatcher struct {
+	targets     []config.WebhookTarget
+	secret      string
+	client      *http.Client
+	maxAttempts int
+	retryDelay  time.Duration
+	slots       chan struct{} // Bounds in-flight deliveries when non-nil
+	wg          sync.WaitGroup
+
+	// Cancelled when Close gives up waiting, aborting pending requests and retries
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewDispatcher creates a new webhook dispatcher
+func NewDispatcher(targets []config.WebhookTarget, secret string) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		targets:     targets,
+		secret:      secret,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		maxAttempts: 3,
+		retryDelay:  time.Second,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// WithLimits bounds the number of deliveries in flight across all events and the
+// attempts made per delivery. A concurrency of 0 leaves deliveries unbounded and
+// maxAttempts below 1 keeps the default.
+func (d *Dispatcher) WithLimits(concurrency, maxAttempts int) *Dispatcher {
+	if concurrency > 0 {
+		d.slots = make(chan struct{}, concurrency)
+	}
+	if maxAttempts > 0 {
+		d.maxAttempts = maxAttempts
+	}
+	return d
+}
+
+// Dispatch delivers the event in the background to every webhook subscribed to its type.
+// Delivery failures are logged and never returned to the caller.
+func (d *Dispatcher) Dispatch(event kafka.KafkaEvent) {
+	if len(d.targets) == 0 {
+		return
+	}
+
+	payload, err := event.GetPayload()
+	if err != nil {
+		log.Printf("Error encoding %s event for webhooks: %v", event.GetEventType(), err)
+		return
+	}
+
+	for _, target := range d.targets {
+		if !subscribed(target, event.GetEventType()) {
+			continue
+		}
+
+		d.wg.Add(1)
+		go func(url string) {
+			defer d.wg.Done()
+			if d.slots != nil {
+				select {
+				case d.slots <- struct{}{}:
+					defer func() { <-d.slots }()
+				case <-d.ctx.Done():
+					log.Printf("Dropped %s event for webhook %s: dispatcher closed", event.GetEventType(), url)
+					return
+				}
+			}
+			if err := d.deliver(url, event.GetEventType(), payload); err != nil {
+				log.Printf("Error delivering %s event to webhook %s: %v", event.GetEventType(), url, err)
+			}
+		}(target.URL)
+	}
+}
+
+// Close waits for in-flight deliveries to finish. If ctx ends first, pending
+// requests and retries are cancelled and the context's error is returned.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		d.cancel()
+		return fmt.Errorf("webhook deliveries still in flight: %w", ctx.Err())
+	}
+}
+
+// deliver posts the payload, retrying failed attempts with a linear backoff
+func (d *Dispatcher) deliver(url, eventType string, payload []byte) error {
+	var lastErr error
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-time.After(time.Duration(attempt-1) * d.retryDelay):
+			case <-d.ctx.Done():
+				return fmt.Errorf("cancelled after %d attempts: %w", attempt-1, lastErr)
+			}
+		}
+
+		lastErr = d.post(url, eventType, payload)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", d.maxAttempts, lastErr)
+}
+
+// post sends a single signed delivery attempt
+func (d *Dispatcher) post(url, eventType string, payload []byte) error {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventTypeHeader, eventType)
+	if d.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.secret, payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the payload
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// subscribed reports whether the target receives events of the given type
+func subscribed(target config.WebhookTarget, eventType string) bool {
+	if len(target.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range target.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
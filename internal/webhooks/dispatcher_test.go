@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"makers.anchor/incident/internal/config"
+	"makers.anchor/incident/internal/models"
+)
+
+type receivedRequest struct {
+	eventType string
+	signature string
+	body      []byte
+}
+
+// stubServer records every request and fails the first failures requests with a 500
+func stubServer(t *testing.T, failures int) (*httptest.Server, func() []receivedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var received []receivedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, receivedRequest{
+			eventType: r.Header.Get(EventTypeHeader),
+			signature: r.Header.Get(SignatureHeader),
+			body:      body,
+		})
+		if len(received) <= failures {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []receivedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]receivedRequest(nil), received...)
+	}
+}
+
+func TestDispatcher_SignsPayload(t *testing.T) {
+	// Arrange
+	server, received := stubServer(t, 0)
+	dispatcher := NewDispatcher([]config.WebhookTarget{{URL: server.URL}}, "s3cret")
+
+	event := models.IncidentCreated{Id: "abc", Title: "Disk full", Severity: "high"}
+	payload, _ := event.GetPayload()
+
+	// Act
+	dispatcher.Dispatch(event)
+	dispatcher.Close(context.Background())
+
+	// Assert
+	requests := received()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	if string(requests[0].body) != string(payload) {
+		t.Errorf("Expected body %s, got %s", payload, requests[0].body)
+	}
+	if requests[0].eventType != event.GetEventType() {
+		t.Errorf("Expected event type %s, got %s", event.GetEventType(), requests[0].eventType)
+	}
+	if expected := Sign("s3cret", payload); requests[0].signature != expected {
+		t.Errorf("Expected signature %s, got %s", expected, requests[0].signature)
+	}
+}
+
+func TestDispatcher_FiltersByEventType(t *testing.T) {
+	// Arrange
+	server, received := stubServer(t, 0)
+	dispatcher := NewDispatcher([]config.WebhookTarget{
+		{URL: server.URL, EventTypes: []string{"incident.status.updated"}},
+	}, "s3cret")
+
+	// Act
+	dispatcher.Dispatch(models.IncidentCreated{Id: "abc"})
+	dispatcher.Dispatch(models.IncidentStatusUpdated{Id: "abc", Status: "resolved"})
+	dispatcher.Close(context.Background())
+
+	// Assert
+	requests := received()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	if requests[0].eventType != "incident.status.updated" {
+		t.Errorf("Expected incident.status.updated, got %s", requests[0].eventType)
+	}
+}
+
+func TestDispatcher_RetriesFailedDelivery(t *testing.T) {
+	// Arrange
+	server, received := stubServer(t, 2)
+	dispatcher := NewDispatcher([]config.WebhookTarget{{URL: server.URL}}, "")
+	dispatcher.retryDelay = time.Millisecond
+
+	// Act
+	dispatcher.Dispatch(models.IncidentCreated{Id: "abc"})
+	dispatcher.Close(context.Background())
+
+	// Assert
+	if requests := received(); len(requests) != 3 {
+		t.Errorf("Expected 3 attempts, got %d", len(requests))
+	}
+}
+
+func TestDispatcher_BoundsConcurrentDeliveries(t *testing.T) {
+	// Arrange
+	const limit = 2
+	var mu sync.Mutex
+	inFlight, peak, total := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		total++
+		if inFlight > peak {
+			peak = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(server.Close)
+
+	targets := make([]config.WebhookTarget, 6)
+	for i := range targets {
+		targets[i] = config.WebhookTarget{URL: server.URL}
+	}
+	dispatcher := NewDispatcher(targets, "").WithLimits(limit, 0)
+
+	// Act
+	dispatcher.Dispatch(models.IncidentCreated{Id: "abc"})
+	dispatcher.Dispatch(models.IncidentCreated{Id: "def"})
+	dispatcher.Close(context.Background())
+
+	// Assert
+	if total != 12 {
+		t.Fatalf("Expected 12 deliveries, got %d", total)
+	}
+	if peak > limit {
+		t.Errorf("Expected at most %d concurrent deliveries, got %d", limit, peak)
+	}
+}
+
+func TestDispatcher_ConfiguredMaxAttempts(t *testing.T) {
+	// Arrange
+	server, received := stubServer(t, 10)
+	dispatcher := NewDispatcher([]config.WebhookTarget{{URL: server.URL}}, "").WithLimits(1, 5)
+	dispatcher.retryDelay = time.Millisecond
+
+	// Act
+	dispatcher.Dispatch(models.IncidentCreated{Id: "abc"})
+	dispatcher.Close(context.Background())
+
+	// Assert
+	if requests := received(); len(requests) != 5 {
+		t.Errorf("Expected 5 attempts, got %d", len(requests))
+	}
+}
+
+func TestDispatcher_CloseCancelsRetriesAtDeadline(t *testing.T) {
+	// Arrange
+	server, received := stubServer(t, 100)
+	dispatcher := NewDispatcher([]config.WebhookTarget{{URL: server.URL}}, "")
+	dispatcher.retryDelay = time.Hour
+	dispatcher.Dispatch(models.IncidentCreated{Id: "abc"})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act
+	start := time.Now()
+	err := dispatcher.Close(ctx)
+
+	// Assert
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the deadline to be reported, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Close to return at the deadline, took %s", elapsed)
+	}
+	if err := dispatcher.Close(context.Background()); err != nil {
+		t.Errorf("Expected the cancelled retry to finish, got %v", err)
+	}
+	if requests := received(); len(requests) != 1 {
+		t.Errorf("Expected no retries after the deadline, got %d attempts", len(requests))
+	}
+}